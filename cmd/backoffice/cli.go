@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.uber.org/fx"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/repositories"
+	"loki-backoffice/internal/app/rpcs"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/internal/config/middlewares"
+	"loki-backoffice/pkg/jwt"
+	"loki-backoffice/pkg/rbac"
+)
+
+// TokenEnv holds the bearer token the commands call the SSO service with
+const TokenEnv = "BACKOFFICE_TOKEN"
+
+// connect populates targets from the services, checks that the token grants the permissions and
+// returns a context carrying its claims so that changes are audited under the token owner
+func connect(cfg *config.Config, token string, permissions []string, targets ...interface{}) (context.Context, func(), error) {
+	// the command output is the result, logs only report failures unless configured otherwise
+	if cfg.LogLevel == "" {
+		cfg.LogLevel = logger.ErrorLevel
+	}
+
+	var (
+		tokens jwt.Jwt
+		client rpcs.Client
+	)
+
+	app := fx.New(
+		fx.NopLogger,
+		fx.Supply(cfg),
+		logger.Module,
+		jwt.Module,
+		repositories.Module,
+		rpcs.Module,
+		services.Module,
+		fx.Populate(append(targets, &tokens, &client)...),
+	)
+	if err := app.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	closer := func() { _ = client.Close() }
+
+	claims, err := tokens.Decode(token)
+	if err != nil {
+		closer()
+		return nil, nil, err
+	}
+
+	for _, permission := range permissions {
+		if !rbac.HasPermission(claims.Permissions, permission) {
+			closer()
+			return nil, nil, fmt.Errorf("%w: %s is required", errors.ErrForbidden, permission)
+		}
+	}
+
+	ctx := middlewares.NewContextModifier(context.Background()).
+		WithClaim(claims).
+		WithToken(token).
+		Context()
+
+	return ctx, closer, nil
+}
+
+// open reads the file at path, "-" reads stdin
+func open(path string, stdin io.Reader) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(stdin), nil
+	}
+
+	return os.Open(path)
+}
+
+func printError(w io.Writer, err error) {
+	_, _ = fmt.Fprintf(w, "error: %s\n", err)
+
+	var validationErr *errors.ValidationError
+	if errors.As(err, &validationErr) {
+		for _, field := range validationErr.Fields {
+			_, _ = fmt.Fprintf(w, "  %s: %s %s\n", field.Pointer, field.Code, field.Detail)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"os"
 
 	"go.uber.org/fx"
@@ -14,6 +15,15 @@ import (
 func main() {
 	cfg := config.LoadConfig()
 
+	if args := flag.Args(); len(args) > 0 {
+		switch args[0] {
+		case RBACCommand:
+			os.Exit(runRBAC(cfg, args[1:], os.Stdin, os.Stdout, os.Stderr))
+		case SnapshotCommand:
+			os.Exit(runSnapshot(cfg, args[1:], os.Stdin, os.Stdout, os.Stderr))
+		}
+	}
+
 	fx.New(
 		fx.WithLogger(
 			func(log *logger.Logger) fxevent.Logger {
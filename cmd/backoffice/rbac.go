@@ -0,0 +1,131 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/models/dto"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/pkg/rbac"
+)
+
+const (
+	RBACCommand = "rbac"
+
+	rbacUsage = "usage: backoffice [flags] rbac plan|apply [-token TOKEN] [-prune] [-force] FILE"
+)
+
+var rbacPermissions = map[string][]string{
+	"plan":  {rbac.ReadPermissions, rbac.ReadRoles, rbac.ReadScopes, rbac.ReadUsers},
+	"apply": {rbac.ReadUsers, rbac.WritePermissions, rbac.WriteRoles, rbac.WriteScopes},
+}
+
+// runRBAC plans or applies a YAML or JSON configuration file, "-" reads it from stdin
+func runRBAC(cfg *config.Config, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || rbacPermissions[args[0]] == nil {
+		_, _ = fmt.Fprintln(stderr, rbacUsage)
+		return 2
+	}
+	command := args[0]
+
+	flags := flag.NewFlagSet(RBACCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	token := flags.String("token", os.Getenv(TokenEnv), "bearer token, defaults to $"+TokenEnv)
+	prune := flags.Bool("prune", false, "delete the records missing from the sections of the file")
+	force := flags.Bool("force", false, "delete records that roles or users still depend on")
+	if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 1 {
+		_, _ = fmt.Fprintln(stderr, rbacUsage)
+		return 2
+	}
+
+	var params dto.RBACDocument
+	if err := readRBACDocument(flags.Arg(0), stdin, &params); err != nil {
+		printError(stderr, err)
+		return 1
+	}
+
+	var engine services.RBAC
+	ctx, closer, err := connect(cfg, *token, rbacPermissions[command], &engine)
+	if err != nil {
+		printError(stderr, err)
+		return 1
+	}
+	defer closer()
+
+	options := &services.RBACOptions{Prune: *prune, Force: *force}
+
+	var plan *models.RBACPlan
+	if command == "apply" {
+		plan, err = engine.Apply(ctx, params.Config(), options)
+	} else {
+		plan, err = engine.Plan(ctx, params.Config(), options)
+	}
+	if err != nil {
+		printError(stderr, err)
+		return 1
+	}
+
+	printRBACPlan(stdout, plan)
+
+	for _, change := range plan.Changes {
+		if change.State == services.BulkFailedState {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func readRBACDocument(path string, stdin io.Reader, params *dto.RBACDocument) error {
+	file, err := open(path, stdin)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return params.Validate(file)
+}
+
+// printRBACPlan prints one line per change, + creates, ~ updates and - deletes
+func printRBACPlan(w io.Writer, plan *models.RBACPlan) {
+	symbols := map[string]string{
+		models.CreateActionType: "+",
+		models.UpdateActionType: "~",
+		models.DeleteActionType: "-",
+	}
+
+	for _, change := range plan.Changes {
+		line := fmt.Sprintf("%s %s %s", symbols[change.Action], change.ResourceType, change.Name)
+		if len(change.Fields) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(change.Fields, ", "))
+		}
+
+		switch {
+		case change.Err != nil:
+			line += fmt.Sprintf(": %s, %s", change.State, change.Err)
+		case change.State != "":
+			line += ": " + change.State
+		}
+
+		_, _ = fmt.Fprintln(w, line)
+
+		if change.Dependents != nil {
+			_, _ = fmt.Fprintf(w, "    dependents: %d roles, %d users\n", len(change.Dependents.Roles), len(change.Dependents.Users))
+		}
+
+		if change.Action == models.UpdateActionType && change.ResourceType == models.RoleResourceType {
+			_, _ = fmt.Fprintf(w, "    permissions: [%s] -> [%s]\n", strings.Join(change.Before.Permissions, ", "), strings.Join(change.After.Permissions, ", "))
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "Plan: %d to create, %d to update, %d to delete.\n",
+		plan.Count(models.CreateActionType),
+		plan.Count(models.UpdateActionType),
+		plan.Count(models.DeleteActionType),
+	)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/models/dto"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/pkg/rbac"
+)
+
+const (
+	SnapshotCommand = "snapshot"
+
+	snapshotUsage = "usage: backoffice [flags] snapshot take [-token TOKEN] [-o FILE] [-store] | restore [-token TOKEN] [-dry-run] [-delete-users] [-prune] [-force] FILE|ID"
+)
+
+var snapshotPermissions = map[string][]string{
+	"take":    {rbac.ReadPermissions, rbac.ReadRoles, rbac.ReadScopes, rbac.ReadUsers},
+	"restore": {rbac.WritePermissions, rbac.WriteRoles, rbac.WriteScopes, rbac.WriteUsers},
+}
+
+// runSnapshot takes a snapshot into a file or restores one from a file, stdin or the stored snapshot id
+func runSnapshot(cfg *config.Config, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || snapshotPermissions[args[0]] == nil {
+		_, _ = fmt.Fprintln(stderr, snapshotUsage)
+		return 2
+	}
+	command := args[0]
+
+	flags := flag.NewFlagSet(SnapshotCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	token := flags.String("token", os.Getenv(TokenEnv), "bearer token, defaults to $"+TokenEnv)
+	output := flags.String("o", "-", "archive file, defaults to stdout")
+	store := flags.Bool("store", false, "also store the snapshot in the database")
+	dryRun := flags.Bool("dry-run", false, "only plan the restore")
+	deleteUsers := flags.Bool("delete-users", false, "delete the users missing from the snapshot")
+	prune := flags.Bool("prune", false, "delete the permissions, roles and scopes missing from the snapshot")
+	force := flags.Bool("force", false, "delete permissions, roles and scopes that roles or users still depend on")
+	if err := flags.Parse(args[1:]); err != nil {
+		_, _ = fmt.Fprintln(stderr, snapshotUsage)
+		return 2
+	}
+
+	var snapshots services.Snapshots
+
+	if command == "take" {
+		if flags.NArg() != 0 {
+			_, _ = fmt.Fprintln(stderr, snapshotUsage)
+			return 2
+		}
+
+		permissions := snapshotPermissions[command]
+		if *store {
+			permissions = append([]string{rbac.WriteSnapshots}, permissions...)
+		}
+
+		ctx, closer, err := connect(cfg, *token, permissions, &snapshots)
+		if err != nil {
+			printError(stderr, err)
+			return 1
+		}
+		defer closer()
+
+		if err := takeSnapshot(ctx, snapshots, *output, *store, stdout, stderr); err != nil {
+			printError(stderr, err)
+			return 1
+		}
+
+		return 0
+	}
+
+	if flags.NArg() != 1 {
+		_, _ = fmt.Fprintln(stderr, snapshotUsage)
+		return 2
+	}
+
+	ctx, closer, err := connect(cfg, *token, snapshotPermissions[command], &snapshots)
+	if err != nil {
+		printError(stderr, err)
+		return 1
+	}
+	defer closer()
+
+	snapshot, err := readSnapshot(ctx, snapshots, flags.Arg(0), stdin)
+	if err != nil {
+		printError(stderr, err)
+		return 1
+	}
+
+	options := &services.RestoreOptions{DryRun: *dryRun, DeleteUsers: *deleteUsers, Prune: *prune, Force: *force}
+
+	var result *models.SnapshotRestore
+	if options.DryRun {
+		result, err = snapshots.Plan(ctx, snapshot, options)
+	} else {
+		result, err = snapshots.Restore(ctx, snapshot, options)
+	}
+	if err != nil {
+		printError(stderr, err)
+		return 1
+	}
+
+	printRBACPlan(stdout, result.RBAC)
+	printSnapshotUsers(stdout, result)
+
+	for _, change := range result.RBAC.Changes {
+		if change.State == services.BulkFailedState {
+			return 1
+		}
+	}
+	for _, change := range result.Users {
+		if change.State == services.BulkFailedState {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func takeSnapshot(ctx context.Context, snapshots services.Snapshots, output string, store bool, stdout, stderr io.Writer) error {
+	snapshot, err := snapshots.Take(ctx)
+	if err != nil {
+		return err
+	}
+
+	if store {
+		snapshot, err = snapshots.Save(ctx, snapshot)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(stderr, "Stored snapshot %s\n", snapshot.ID)
+	}
+
+	w := stdout
+	if output != "-" {
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(dto.NewSnapshotArchive(snapshot))
+}
+
+// readSnapshot loads the stored snapshot when source is an id, otherwise reads the archive file
+func readSnapshot(ctx context.Context, snapshots services.Snapshots, source string, stdin io.Reader) (*models.Snapshot, error) {
+	if id, err := uuid.Parse(source); err == nil {
+		return snapshots.FindById(ctx, id)
+	}
+
+	file, err := open(source, stdin)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var params dto.SnapshotArchive
+	if err := params.Validate(file); err != nil {
+		return nil, err
+	}
+
+	return params.Snapshot(), nil
+}
+
+func printSnapshotUsers(w io.Writer, result *models.SnapshotRestore) {
+	symbols := map[string]string{
+		models.CreateActionType: "+",
+		models.UpdateActionType: "~",
+		models.DeleteActionType: "-",
+	}
+
+	counts := make(map[string]int)
+	for _, change := range result.Users {
+		counts[change.Action]++
+
+		line := fmt.Sprintf("%s user %s", symbols[change.Action], change.IdentityNumber)
+		if len(change.Fields) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(change.Fields, ", "))
+		}
+
+		switch {
+		case change.Err != nil:
+			line += fmt.Sprintf(": %s, %s", change.State, change.Err)
+		case change.State != "":
+			line += ": " + change.State
+		}
+
+		_, _ = fmt.Fprintln(w, line)
+	}
+
+	for _, identityNumber := range result.Retained {
+		_, _ = fmt.Fprintf(w, "= user %s: kept, not in the snapshot\n", identityNumber)
+	}
+
+	_, _ = fmt.Fprintf(w, "Users: %d to create, %d to update, %d to delete, %d kept.\n",
+		counts[models.CreateActionType],
+		counts[models.UpdateActionType],
+		counts[models.DeleteActionType],
+		len(result.Retained),
+	)
+	if len(result.Retained) > 0 {
+		_, _ = fmt.Fprintln(w, "Pass -delete-users to delete the users missing from the snapshot.")
+	}
+}
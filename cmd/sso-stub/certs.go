@@ -0,0 +1,168 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"loki-backoffice/internal/app/rpcs"
+	"loki-backoffice/internal/app/rpcs/fake"
+	"loki-backoffice/pkg/jwt"
+)
+
+const (
+	DefaultHosts = "localhost,127.0.0.1,::1,sso-stub"
+
+	certificateTTL = 365 * 24 * time.Hour
+	keySize        = 2048
+)
+
+// generateCertificates writes a development CA with server and client certificates when any of them
+// is missing, and the JWT key pair when it is missing, existing files are left untouched
+func generateCertificates(certPath, hosts string) (bool, error) {
+	generated := false
+
+	tlsFiles := []string{fake.CaFile, fake.CertFile, fake.KeyFile, rpcs.CertFile, rpcs.KeyFile}
+	if missing(certPath, tlsFiles...) {
+		if err := writeTLS(certPath, strings.Split(hosts, ",")); err != nil {
+			return false, err
+		}
+		generated = true
+	}
+
+	jwtPath := filepath.Join(certPath, jwt.Dir)
+	if missing(jwtPath, jwt.PrivateKeyFile, jwt.PublicKeyFile) {
+		if err := writeJWTKeys(jwtPath); err != nil {
+			return false, err
+		}
+		generated = true
+	}
+
+	return generated, nil
+}
+
+func writeTLS(certPath string, hosts []string) error {
+	if err := os.MkdirAll(certPath, 0755); err != nil {
+		return err
+	}
+
+	caKey, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return err
+	}
+
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"Loki SSO Stub"}, CommonName: "Loki SSO Stub CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(certificateTTL),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, ca, ca, &caKey.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	if err := writePEM(filepath.Join(certPath, fake.CaFile), "CERTIFICATE", caDER, 0644); err != nil {
+		return err
+	}
+
+	server := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{Organization: []string{"Loki SSO Stub"}, CommonName: hosts[0]},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			server.IPAddresses = append(server.IPAddresses, ip)
+		} else if host != "" {
+			server.DNSNames = append(server.DNSNames, host)
+		}
+	}
+	if err := writeSigned(certPath, fake.CertFile, fake.KeyFile, server, ca, caKey); err != nil {
+		return err
+	}
+
+	client := &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{Organization: []string{"Loki SSO Stub"}, CommonName: "loki-backoffice"},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	return writeSigned(certPath, rpcs.CertFile, rpcs.KeyFile, client, ca, caKey)
+}
+
+func writeSigned(certPath, certFile, keyFile string, template, ca *x509.Certificate, caKey *rsa.PrivateKey) error {
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return err
+	}
+
+	template.NotBefore = time.Now()
+	template.NotAfter = time.Now().Add(certificateTTL)
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.BasicConstraintsValid = true
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+
+	if err := writePEM(filepath.Join(certPath, certFile), "CERTIFICATE", der, 0644); err != nil {
+		return err
+	}
+
+	return writePEM(filepath.Join(certPath, keyFile), "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key), 0600)
+}
+
+func writeJWTKeys(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	if err := writePEM(filepath.Join(dir, jwt.PrivateKeyFile), "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key), 0600); err != nil {
+		return err
+	}
+
+	return writePEM(filepath.Join(dir, jwt.PublicKeyFile), "PUBLIC KEY", publicKey, 0644)
+}
+
+func writePEM(path, blockType string, bytes []byte, mode os.FileMode) error {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes})
+	if err := os.WriteFile(path, data, mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func missing(dir string, files ...string) bool {
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"loki-backoffice/internal/app/rpcs/fake"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/pkg/jwt"
+)
+
+const (
+	AppName     = "loki-sso-stub"
+	GrpcAddr    = "0.0.0.0:50051"
+	CertPath    = "./certs"
+	DevTokenTTL = 24 * time.Hour
+
+	ServeCommand = "serve"
+	TokenCommand = "token"
+	CertsCommand = "certs"
+
+	usage = "usage: sso-stub [-g ADDRESS] [-p CERT_PATH] serve [-fixtures FILE] [-generate] [-hosts HOSTS] | token [-fixtures FILE] [-ttl TTL] IDENTITY_NUMBER | certs [-hosts HOSTS]"
+)
+
+//go:embed fixtures.yaml
+var defaultFixtures []byte
+
+// main serves the SSO services from fixtures for local development, it is never deployed
+func main() {
+	cfg := config.LoadConfig()
+	cfg.AppName = AppName
+	if cfg.GrpcAddr == "" {
+		cfg.GrpcAddr = GrpcAddr
+	}
+	if cfg.CertPath == "" {
+		cfg.CertPath = CertPath
+	}
+
+	args := flag.Args()
+	if len(args) == 0 {
+		args = []string{ServeCommand}
+	}
+
+	switch args[0] {
+	case ServeCommand:
+		os.Exit(runServe(cfg, args[1:], os.Stderr))
+	case TokenCommand:
+		os.Exit(runToken(cfg, args[1:], os.Stdout, os.Stderr))
+	case CertsCommand:
+		os.Exit(runCerts(cfg, args[1:], os.Stdout, os.Stderr))
+	default:
+		_, _ = fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// runServe serves the fixtures over mutual TLS and logs a development token per fixture user
+func runServe(cfg *config.Config, args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet(ServeCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	fixturesPath := flags.String("fixtures", "", "fixtures file, defaults to the embedded fixtures")
+	generate := flags.Bool("generate", false, "generate missing certificates and JWT keys")
+	hosts := flags.String("hosts", DefaultHosts, "comma separated server certificate hosts")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		_, _ = fmt.Fprintln(stderr, usage)
+		return 2
+	}
+
+	log := logger.NewLogger(cfg)
+
+	if *generate {
+		generated, err := generateCertificates(cfg.CertPath, *hosts)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to generate certificates")
+			return 1
+		}
+		if generated {
+			log.Info().Str("path", cfg.CertPath).Msg("Generated certificates")
+		}
+	}
+
+	credentials, err := fake.NewCredentials(cfg.CertPath)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load certificates")
+		return 1
+	}
+
+	fixtures, err := loadFixtures(*fixturesPath)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load fixtures")
+		return 1
+	}
+
+	server := fake.NewServer(grpc.Creds(credentials))
+	if err := server.Seed(fixtures); err != nil {
+		log.Error().Err(err).Msg("Failed to seed fixtures")
+		return 1
+	}
+
+	listener, err := net.Listen("tcp", cfg.GrpcAddr)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to listen")
+		return 1
+	}
+
+	for _, user := range fixtures.Users {
+		token, err := signToken(cfg, server.Store, user.IdentityNumber, DevTokenTTL)
+		if err != nil {
+			log.Warn().Err(err).Str("identity_number", user.IdentityNumber).Msg("Failed to sign development token")
+			continue
+		}
+		log.Info().Str("identity_number", user.IdentityNumber).Str("token", token).Msg("Development token")
+	}
+
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+		server.Stop()
+	}()
+
+	log.Info().Str("address", cfg.GrpcAddr).Msg("Serving SSO stub")
+	if err := server.Serve(listener); err != nil {
+		log.Error().Err(err).Msg("Failed to serve")
+		return 1
+	}
+
+	return 0
+}
+
+// runToken prints a token for a fixture user, granting the permissions of its roles
+func runToken(cfg *config.Config, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(TokenCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	fixturesPath := flags.String("fixtures", "", "fixtures file, defaults to the embedded fixtures")
+	ttl := flags.Duration("ttl", DevTokenTTL, "token lifetime")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+		_, _ = fmt.Fprintln(stderr, usage)
+		return 2
+	}
+
+	fixtures, err := loadFixtures(*fixturesPath)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	store := fake.NewStore()
+	if err := store.Seed(fixtures); err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	token, err := signToken(cfg, store, flags.Arg(0), *ttl)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	_, _ = fmt.Fprintln(stdout, token)
+	return 0
+}
+
+// runCerts generates missing certificates and JWT keys into the certificate path
+func runCerts(cfg *config.Config, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(CertsCommand, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	hosts := flags.String("hosts", DefaultHosts, "comma separated server certificate hosts")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		_, _ = fmt.Fprintln(stderr, usage)
+		return 2
+	}
+
+	generated, err := generateCertificates(cfg.CertPath, *hosts)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	if generated {
+		_, _ = fmt.Fprintf(stdout, "Generated certificates in %s\n", cfg.CertPath)
+	} else {
+		_, _ = fmt.Fprintf(stdout, "Certificates in %s are up to date\n", cfg.CertPath)
+	}
+	return 0
+}
+
+func loadFixtures(path string) (*fake.Fixtures, error) {
+	if path == "" {
+		return fake.LoadFixtures(bytes.NewReader(defaultFixtures))
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return fake.LoadFixtures(file)
+}
+
+func signToken(cfg *config.Config, store *fake.Store, identityNumber string, ttl time.Duration) (string, error) {
+	grants, ok := store.Grants(identityNumber)
+	if !ok {
+		return "", fmt.Errorf("user %s is not in the fixtures", identityNumber)
+	}
+
+	return jwt.Sign(cfg, cfg.AppName, &jwt.Payload{
+		ID:          identityNumber,
+		Roles:       grants.Roles,
+		Permissions: grants.Permissions,
+		Scope:       grants.Scopes,
+	}, ttl)
+}
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE audit_logs (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  actor_id VARCHAR(255) NOT NULL,
+  action VARCHAR(50) NOT NULL,
+  resource_type VARCHAR(50) NOT NULL,
+  resource_id UUID NOT NULL,
+  before_state JSONB,
+  after_state JSONB,
+  request_id VARCHAR(255) NOT NULL DEFAULT '',
+  trace_id VARCHAR(255) NOT NULL DEFAULT '',
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_logs_resource_idx ON audit_logs (resource_type, resource_id, created_at);
+CREATE INDEX audit_logs_actor_id_idx ON audit_logs (actor_id, created_at);
+CREATE INDEX audit_logs_created_at_idx ON audit_logs (created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS audit_logs;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE idempotency_keys (
+  key VARCHAR(255) NOT NULL,
+  actor_id VARCHAR(255) NOT NULL,
+  route VARCHAR(255) NOT NULL,
+  request_hash VARCHAR(64) NOT NULL,
+  status_code INTEGER NOT NULL DEFAULT 0,
+  content_type VARCHAR(255) NOT NULL DEFAULT '',
+  response_body BYTEA,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  expires_at TIMESTAMP NOT NULL,
+  locked_until TIMESTAMP NOT NULL,
+  owner UUID NOT NULL,
+  PRIMARY KEY (key, actor_id, route)
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,13 @@
+-- +goose Up
+CREATE TABLE snapshots (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  version INTEGER NOT NULL,
+  actor_id VARCHAR(255) NOT NULL,
+  archive JSONB NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX snapshots_created_at_idx ON snapshots (created_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS snapshots;
@@ -30,6 +30,119 @@ CREATE EXTENSION IF NOT EXISTS "uuid-ossp" WITH SCHEMA public;
 COMMENT ON EXTENSION "uuid-ossp" IS 'generate universally unique identifiers (UUIDs)';
 
 
+SET default_tablespace = '';
+
+SET default_table_access_method = heap;
+
+--
+-- Name: audit_logs; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.audit_logs (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    actor_id character varying(255) NOT NULL,
+    action character varying(50) NOT NULL,
+    resource_type character varying(50) NOT NULL,
+    resource_id uuid NOT NULL,
+    before_state jsonb,
+    after_state jsonb,
+    request_id character varying(255) DEFAULT ''::character varying NOT NULL,
+    trace_id character varying(255) DEFAULT ''::character varying NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: idempotency_keys; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.idempotency_keys (
+    key character varying(255) NOT NULL,
+    actor_id character varying(255) NOT NULL,
+    route character varying(255) NOT NULL,
+    request_hash character varying(64) NOT NULL,
+    status_code integer DEFAULT 0 NOT NULL,
+    content_type character varying(255) DEFAULT ''::character varying NOT NULL,
+    response_body bytea,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    expires_at timestamp without time zone NOT NULL,
+    locked_until timestamp without time zone NOT NULL,
+    owner uuid NOT NULL
+);
+
+
+--
+-- Name: snapshots; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.snapshots (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    version integer NOT NULL,
+    actor_id character varying(255) NOT NULL,
+    archive jsonb NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: audit_logs audit_logs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.audit_logs
+    ADD CONSTRAINT audit_logs_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: idempotency_keys idempotency_keys_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.idempotency_keys
+    ADD CONSTRAINT idempotency_keys_pkey PRIMARY KEY (key, actor_id, route);
+
+
+--
+-- Name: snapshots snapshots_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.snapshots
+    ADD CONSTRAINT snapshots_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: audit_logs_actor_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX audit_logs_actor_id_idx ON public.audit_logs USING btree (actor_id, created_at);
+
+
+--
+-- Name: audit_logs_created_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX audit_logs_created_at_idx ON public.audit_logs USING btree (created_at);
+
+
+--
+-- Name: audit_logs_resource_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX audit_logs_resource_idx ON public.audit_logs USING btree (resource_type, resource_id, created_at);
+
+
+--
+-- Name: idempotency_keys_expires_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idempotency_keys_expires_at_idx ON public.idempotency_keys USING btree (expires_at);
+
+
+--
+-- Name: snapshots_created_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX snapshots_created_at_idx ON public.snapshots USING btree (created_at);
+
+
 --
 -- PostgreSQL database dump complete
 --
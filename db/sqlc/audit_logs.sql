@@ -0,0 +1,32 @@
+-- name: CreateAuditLog :one
+INSERT INTO audit_logs (actor_id, action, resource_type, resource_id, before_state, after_state, request_id, trace_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, actor_id, action, resource_type, resource_id, before_state, after_state, request_id, trace_id, created_at;
+
+-- name: FindAuditLogs :many
+SELECT id, actor_id, action, resource_type, resource_id, before_state, after_state, request_id, trace_id, created_at
+FROM audit_logs
+WHERE (@actor_id::varchar = '' OR actor_id = @actor_id)
+  AND (@action::varchar = '' OR action = @action)
+  AND (@resource_type::varchar = '' OR resource_type = @resource_type)
+  AND (@resource_id::uuid = '00000000-0000-0000-0000-000000000000' OR resource_id = @resource_id)
+  AND (sqlc.narg(created_from)::timestamp IS NULL OR created_at >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::timestamp IS NULL OR created_at <= sqlc.narg(created_to))
+  AND (sqlc.narg(seek_created_at)::timestamp IS NULL
+    OR (NOT @reverse::boolean AND (created_at, id) < (sqlc.narg(seek_created_at)::timestamp, @seek_id::uuid))
+    OR (@reverse::boolean AND (created_at, id) > (sqlc.narg(seek_created_at)::timestamp, @seek_id::uuid)))
+ORDER BY
+  CASE WHEN @reverse::boolean THEN created_at END ASC,
+  CASE WHEN @reverse::boolean THEN id END ASC,
+  created_at DESC, id DESC
+LIMIT @query_limit OFFSET @query_offset;
+
+-- name: CountAuditLogs :one
+SELECT COUNT(*)
+FROM audit_logs
+WHERE (@actor_id::varchar = '' OR actor_id = @actor_id)
+  AND (@action::varchar = '' OR action = @action)
+  AND (@resource_type::varchar = '' OR resource_type = @resource_type)
+  AND (@resource_id::uuid = '00000000-0000-0000-0000-000000000000' OR resource_id = @resource_id)
+  AND (sqlc.narg(created_from)::timestamp IS NULL OR created_at >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::timestamp IS NULL OR created_at <= sqlc.narg(created_to));
@@ -0,0 +1,2 @@
+-- name: HealthCheck :one
+SELECT 1;
@@ -0,0 +1,37 @@
+-- name: ReserveIdempotencyKey :execrows
+INSERT INTO idempotency_keys (key, actor_id, route, request_hash, expires_at, locked_until, owner)
+VALUES (@key, @actor_id, @route, @request_hash, CURRENT_TIMESTAMP + @ttl::interval, CURRENT_TIMESTAMP + @lease::interval, @owner)
+ON CONFLICT (key, actor_id, route) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    status_code = 0,
+    content_type = '',
+    response_body = NULL,
+    created_at = CURRENT_TIMESTAMP,
+    expires_at = EXCLUDED.expires_at,
+    locked_until = EXCLUDED.locked_until,
+    owner = EXCLUDED.owner
+WHERE idempotency_keys.expires_at < CURRENT_TIMESTAMP
+   OR (idempotency_keys.status_code = 0 AND idempotency_keys.locked_until < CURRENT_TIMESTAMP);
+
+-- name: FindIdempotencyKey :one
+SELECT key, actor_id, route, request_hash, status_code, content_type, response_body, created_at, expires_at, locked_until, owner
+FROM idempotency_keys
+WHERE key = $1 AND actor_id = $2 AND route = $3;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status_code = $5, content_type = $6, response_body = $7
+WHERE key = $1 AND actor_id = $2 AND route = $3 AND owner = $4;
+
+-- name: RenewIdempotencyKey :execrows
+UPDATE idempotency_keys
+SET locked_until = CURRENT_TIMESTAMP + @lease::interval
+WHERE key = @key AND actor_id = @actor_id AND route = @route AND owner = @owner AND status_code = 0;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE key = $1 AND actor_id = $2 AND route = $3 AND owner = $4;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at < CURRENT_TIMESTAMP;
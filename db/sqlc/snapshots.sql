@@ -0,0 +1,34 @@
+-- name: CreateSnapshot :one
+INSERT INTO snapshots (version, actor_id, archive)
+VALUES ($1, $2, $3)
+RETURNING id, version, actor_id, archive, created_at;
+
+-- name: FindSnapshots :many
+SELECT id, version, actor_id, created_at
+FROM snapshots
+WHERE sqlc.narg(seek_created_at)::timestamp IS NULL
+  OR (NOT @reverse::boolean AND (created_at, id) < (sqlc.narg(seek_created_at)::timestamp, @seek_id::uuid))
+  OR (@reverse::boolean AND (created_at, id) > (sqlc.narg(seek_created_at)::timestamp, @seek_id::uuid))
+ORDER BY
+  CASE WHEN @reverse::boolean THEN created_at END ASC,
+  CASE WHEN @reverse::boolean THEN id END ASC,
+  created_at DESC, id DESC
+LIMIT @query_limit OFFSET @query_offset;
+
+-- name: CountSnapshots :one
+SELECT COUNT(*)
+FROM snapshots;
+
+-- name: FindSnapshotById :one
+SELECT id, version, actor_id, archive, created_at
+FROM snapshots
+WHERE id = $1;
+
+-- name: DeleteSnapshotsBeyondRetention :execrows
+DELETE FROM snapshots
+WHERE id NOT IN (
+  SELECT id
+  FROM snapshots
+  ORDER BY created_at DESC, id DESC
+  LIMIT @keep
+);
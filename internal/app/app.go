@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
@@ -37,6 +38,8 @@ var Module = fx.Options(
 
 	fx.Invoke(registerHooks),
 	fx.Invoke(registerGrpcClient),
+	fx.Invoke(registerIdempotencySweeper),
+	fx.Invoke(registerUserImports),
 	fx.Invoke(registerTelemetry),
 )
 
@@ -82,9 +85,54 @@ func registerGrpcClient(
 	})
 }
 
+func registerIdempotencySweeper(
+	lifecycle fx.Lifecycle,
+	sweeper services.IdempotencySweeper,
+	log *logger.Logger,
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				defer close(done)
+				sweeper.Run(ctx)
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			log.Info().Msg("Stopping idempotency sweeper...")
+			cancel()
+
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	})
+}
+
+func registerUserImports(
+	lifecycle fx.Lifecycle,
+	imports services.UserImports,
+	log *logger.Logger,
+) {
+	lifecycle.Append(fx.Hook{
+		OnStop: func(stopCtx context.Context) error {
+			log.Info().Msg("Waiting for running user imports...")
+			return imports.Shutdown(stopCtx)
+		},
+	})
+}
+
 func registerTelemetry(lifecycle fx.Lifecycle, cfg *config.Config) {
 	var ctx, cancel = context.WithCancel(context.Background())
 	service, _ := telemetry.NewTelemetry(ctx, cfg)
+	metrics, _ := telemetry.NewMetrics(ctx, cfg)
 
 	lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
@@ -92,7 +140,7 @@ func registerTelemetry(lifecycle fx.Lifecycle, cfg *config.Config) {
 		},
 		OnStop: func(ctx context.Context) error {
 			cancel()
-			return service.Shutdown(ctx)
+			return errors.Join(service.Shutdown(ctx), metrics.Shutdown(ctx))
 		},
 	})
 }
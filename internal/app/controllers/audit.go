@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/serializers"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config/logger"
+)
+
+type AuditController interface {
+	List(w http.ResponseWriter, r *http.Request)
+	History(resourceType string) http.HandlerFunc
+}
+
+type auditController struct {
+	audit     services.Audit
+	paginator services.Paginator
+	log       *logger.Logger
+}
+
+func NewAuditController(audit services.Audit, paginator services.Paginator, log *logger.Logger) AuditController {
+	return &auditController{
+		audit:     audit,
+		paginator: paginator,
+		log:       log,
+	}
+}
+
+// List handles the audit trail search
+func (c *auditController) List(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	filter, err := services.NewAuditLogFilter(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("query", r.URL.RawQuery).Msg("Invalid audit log filter")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.render(w, r, filter)
+}
+
+// History handles the change timeline of a single record of the given resource type
+func (c *auditController) History(resourceType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+			serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+			return
+		}
+
+		c.render(w, r, &models.AuditLogFilter{
+			ResourceType: resourceType,
+			ResourceID:   id,
+		})
+	}
+}
+
+func (c *auditController) render(w http.ResponseWriter, r *http.Request, filter *models.AuditLogFilter) {
+	pagination, err := c.paginator.Paginate(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("cursor", r.URL.Query().Get(services.CursorParam)).Msg("Invalid pagination cursor")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	rows, total, err := c.audit.List(r.Context(), pagination, filter)
+	if err != nil {
+		c.log.Error().Err(err).Msg("Failed to fetch audit logs")
+
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	collection := make([]serializers.AuditLogSerializer, 0, len(rows))
+
+	for _, record := range rows {
+		collection = append(collection, serializers.AuditLogSerializer{
+			ID:           record.ID,
+			ActorID:      record.ActorID,
+			Action:       record.Action,
+			ResourceType: record.ResourceType,
+			ResourceID:   record.ResourceID,
+			Before:       record.Before,
+			After:        record.After,
+			RequestID:    record.RequestID,
+			TraceID:      record.TraceID,
+			CreatedAt:    record.CreatedAt,
+		})
+	}
+
+	response := serializers.PaginationResponse[serializers.AuditLogSerializer]{
+		Data: collection,
+		Meta: serializers.PaginationMeta{
+			Page:  pagination.Page,
+			Per:   pagination.PerPage,
+			Total: total,
+			Next:  c.paginator.Next(r, pagination),
+			Prev:  c.paginator.Prev(r, pagination),
+		},
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
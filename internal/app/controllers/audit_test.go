@@ -0,0 +1,295 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/serializers"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func Test_Audit_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewAuditController(audit, paginator, log)
+
+	createdAt := time.Now()
+
+	type result struct {
+		response serializers.PaginationResponse[serializers.AuditLogSerializer]
+		detail   string
+		status   string
+		code     int
+	}
+
+	tests := []struct {
+		name     string
+		before   func()
+		path     string
+		expected result
+		error    bool
+	}{
+		{
+			name: "Success",
+			before: func() {
+				audit.EXPECT().List(gomock.Any(), gomock.Any(), &models.AuditLogFilter{
+					ActorID:      "10000000-1000-1000-1234-000000000002",
+					ResourceType: models.RoleResourceType,
+				}).Return([]models.AuditLog{
+					{
+						ID:           uuid.MustParse("10000000-1000-1000-8000-000000000001"),
+						ActorID:      "10000000-1000-1000-1234-000000000002",
+						Action:       models.UpdateActionType,
+						ResourceType: models.RoleResourceType,
+						ResourceID:   uuid.MustParse("10000000-1000-1000-1000-000000000001"),
+						Before:       json.RawMessage(`{"name":"admin"}`),
+						After:        json.RawMessage(`{"name":"administrator"}`),
+						RequestID:    "request-id",
+						TraceID:      "trace-id",
+						CreatedAt:    createdAt,
+					},
+				}, uint64(1), nil)
+			},
+			path: "/api/backoffice/audit?actor_id=10000000-1000-1000-1234-000000000002&resource_type=role",
+			expected: result{
+				response: serializers.PaginationResponse[serializers.AuditLogSerializer]{
+					Data: []serializers.AuditLogSerializer{
+						{
+							ID:           uuid.MustParse("10000000-1000-1000-8000-000000000001"),
+							ActorID:      "10000000-1000-1000-1234-000000000002",
+							Action:       models.UpdateActionType,
+							ResourceType: models.RoleResourceType,
+							ResourceID:   uuid.MustParse("10000000-1000-1000-1000-000000000001"),
+							Before:       json.RawMessage(`{"name":"admin"}`),
+							After:        json.RawMessage(`{"name":"administrator"}`),
+							RequestID:    "request-id",
+							TraceID:      "trace-id",
+							CreatedAt:    createdAt,
+						},
+					},
+					Meta: serializers.PaginationMeta{
+						Page:  1,
+						Per:   25,
+						Total: 1,
+					},
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+			error: false,
+		},
+		{
+			name: "Invalid filter",
+			before: func() {
+				audit.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			path: "/api/backoffice/audit?resource_id=invalid",
+			expected: result{
+				detail: "invalid arguments",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name: "Failed to fetch results",
+			before: func() {
+				audit.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrFailedToFetchResults)
+			},
+			path: "/api/backoffice/audit",
+			expected: result{
+				detail: "failed to fetch results",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
+			},
+			error: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Get("/api/backoffice/audit", controller.List)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			if tt.error {
+				var response serializers.ProblemSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
+			} else {
+				var response serializers.PaginationResponse[serializers.AuditLogSerializer]
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+
+				for i, item := range response.Data {
+					assert.Equal(t, tt.expected.response.Data[i].ID, item.ID)
+					assert.Equal(t, tt.expected.response.Data[i].ActorID, item.ActorID)
+					assert.Equal(t, tt.expected.response.Data[i].Action, item.Action)
+					assert.Equal(t, tt.expected.response.Data[i].ResourceType, item.ResourceType)
+					assert.Equal(t, tt.expected.response.Data[i].ResourceID, item.ResourceID)
+					assert.JSONEq(t, string(tt.expected.response.Data[i].Before), string(item.Before))
+					assert.JSONEq(t, string(tt.expected.response.Data[i].After), string(item.After))
+					assert.Equal(t, tt.expected.response.Data[i].CreatedAt.Unix(), item.CreatedAt.Unix())
+				}
+				assert.Equal(t, tt.expected.response.Meta, response.Meta)
+			}
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+			assert.Equal(t, tt.expected.status, resp.Status)
+		})
+	}
+}
+
+func Test_Audit_History(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewAuditController(audit, paginator, log)
+
+	id := uuid.MustParse("10000000-1000-1000-1234-000000000001")
+
+	type result struct {
+		total  uint64
+		detail string
+		status string
+		code   int
+	}
+
+	tests := []struct {
+		name     string
+		before   func()
+		path     string
+		expected result
+		error    bool
+	}{
+		{
+			name: "Success",
+			before: func() {
+				audit.EXPECT().List(gomock.Any(), gomock.Any(), &models.AuditLogFilter{
+					ResourceType: models.UserResourceType,
+					ResourceID:   id,
+				}).Return([]models.AuditLog{
+					{
+						ID:           uuid.MustParse("10000000-1000-1000-8000-000000000002"),
+						Action:       models.UpdateActionType,
+						ResourceType: models.UserResourceType,
+						ResourceID:   id,
+					},
+					{
+						ID:           uuid.MustParse("10000000-1000-1000-8000-000000000001"),
+						Action:       models.CreateActionType,
+						ResourceType: models.UserResourceType,
+						ResourceID:   id,
+					},
+				}, uint64(2), nil)
+			},
+			path: "/api/backoffice/users/10000000-1000-1000-1234-000000000001/history",
+			expected: result{
+				total:  2,
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+			error: false,
+		},
+		{
+			name: "Invalid UUID",
+			before: func() {
+				audit.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			path: "/api/backoffice/users/invalid/history",
+			expected: result{
+				detail: "invalid arguments",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name: "Error",
+			before: func() {
+				audit.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), assert.AnError)
+			},
+			path: "/api/backoffice/users/10000000-1000-1000-1234-000000000001/history",
+			expected: result{
+				detail: assert.AnError.Error(),
+				status: "500 Internal Server Error",
+				code:   http.StatusInternalServerError,
+			},
+			error: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Get("/api/backoffice/users/{id}/history", controller.History(models.UserResourceType))
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			if tt.error {
+				var response serializers.ProblemSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
+			} else {
+				var response serializers.PaginationResponse[serializers.AuditLogSerializer]
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Len(t, response.Data, int(tt.expected.total))
+				assert.Equal(t, tt.expected.total, response.Meta.Total)
+			}
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+			assert.Equal(t, tt.expected.status, resp.Status)
+		})
+	}
+}
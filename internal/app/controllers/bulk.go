@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/serializers"
+	"loki-backoffice/internal/app/services"
+)
+
+// bulkStatuses are the statuses the single record endpoints answer an applied operation with
+var bulkStatuses = map[string]int{
+	models.CreateActionType: http.StatusCreated,
+	models.UpdateActionType: http.StatusOK,
+	models.DeleteActionType: http.StatusNoContent,
+}
+
+// writeBulk renders the outcome of every operation as a 207 Multi-Status response
+func writeBulk(w http.ResponseWriter, r *http.Request, results []services.BulkResult, options *services.BulkOptions) {
+	response := serializers.BulkResponseSerializer{
+		Data: make([]serializers.BulkResultSerializer, 0, len(results)),
+		Meta: serializers.BulkMetaSerializer{
+			Total:  len(results),
+			Atomic: options.Atomic,
+		},
+	}
+
+	for _, result := range results {
+		item := serializers.BulkResultSerializer{
+			Index:  result.Index,
+			Op:     result.Op,
+			Status: bulkStatuses[result.Op],
+			State:  result.State,
+			Data:   result.Result,
+		}
+
+		if result.ID != uuid.Nil {
+			id := result.ID
+			item.ID = &id
+		}
+
+		if result.Err != nil {
+			problem := serializers.NewProblem(r, result.Err)
+			item.Error = &problem
+
+			// a failed rollback leaves the operation applied, the error describes the compensation
+			if result.State != services.BulkRollbackFailedState {
+				item.Status = problem.Status
+			}
+		}
+
+		if result.State == services.BulkAppliedState {
+			response.Meta.Succeeded++
+		} else {
+			response.Meta.Failed++
+		}
+
+		response.Data = append(response.Data, item)
+	}
+
+	w.WriteHeader(http.StatusMultiStatus)
+	_ = json.NewEncoder(w).Encode(response)
+}
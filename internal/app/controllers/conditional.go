@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/serializers"
+)
+
+const (
+	ETagHeader        = "ETag"
+	IfMatchHeader     = "If-Match"
+	IfNoneMatchHeader = "If-None-Match"
+)
+
+// ifMatch rejects a write when If-Match does not name the current representation,
+// err is the error of loading current and requests without the header are unconditional.
+// Upstream offers no conditional writes, so this narrows the lost update window without closing it.
+func ifMatch(r *http.Request, current interface{}, err error) error {
+	header := r.Header.Get(IfMatchHeader)
+	if header == "" {
+		return nil
+	}
+
+	if err != nil && !errors.Is(err, errors.ErrRecordNotFound) {
+		return err
+	}
+
+	if current == nil || !matchesETag(header, serializers.ETag(current), false) {
+		return errors.ErrPreconditionFailed
+	}
+
+	return nil
+}
+
+// ifNoneMatch reports whether the client already holds the representation tagged etag
+func ifNoneMatch(r *http.Request, etag string) bool {
+	header := r.Header.Get(IfNoneMatchHeader)
+	if header == "" {
+		return false
+	}
+
+	return matchesETag(header, etag, true)
+}
+
+// matchesETag compares etag against a header list, weak comparison ignores the W/ prefix
+func matchesETag(header string, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+
+		if weak {
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+
+		if candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/serializers"
+)
+
+func Test_ifMatch(t *testing.T) {
+	current := serializers.PermissionSerializer{Name: "read:self"}
+	etag := serializers.ETag(current)
+
+	tests := []struct {
+		name    string
+		header  string
+		current interface{}
+		err     error
+		error   error
+	}{
+		{
+			name:    "Unconditional",
+			current: current,
+		},
+		{
+			name:    "Matching",
+			header:  `"other", ` + etag,
+			current: current,
+		},
+		{
+			name:    "Wildcard",
+			header:  "*",
+			current: current,
+		},
+		{
+			name:    "Stale",
+			header:  `"other"`,
+			current: current,
+			error:   errors.ErrPreconditionFailed,
+		},
+		{
+			name:    "Weak validators never match",
+			header:  "W/" + etag,
+			current: current,
+			error:   errors.ErrPreconditionFailed,
+		},
+		{
+			name:   "Missing resource",
+			header: "*",
+			err:    errors.ErrRecordNotFound,
+			error:  errors.ErrPreconditionFailed,
+		},
+		{
+			name:   "Lookup failure",
+			header: etag,
+			err:    errors.ErrUnavailable,
+			error:  errors.ErrUnavailable,
+		},
+		{
+			name:    "Lookup failure without header",
+			current: nil,
+			err:     errors.ErrUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("PUT", "/", nil)
+			if tt.header != "" {
+				req.Header.Set(IfMatchHeader, tt.header)
+			}
+
+			assert.Equal(t, tt.error, ifMatch(req, tt.current, tt.err))
+		})
+	}
+}
+
+func Test_ifNoneMatch(t *testing.T) {
+	etag := serializers.ETag(serializers.PermissionSerializer{Name: "read:self"})
+
+	tests := []struct {
+		name     string
+		header   string
+		expected bool
+	}{
+		{name: "Absent", header: "", expected: false},
+		{name: "Matching", header: etag, expected: true},
+		{name: "Weak", header: "W/" + etag, expected: true},
+		{name: "Wildcard", header: "*", expected: true},
+		{name: "Stale", header: `"other"`, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			if tt.header != "" {
+				req.Header.Set(IfNoneMatchHeader, tt.header)
+			}
+
+			assert.Equal(t, tt.expected, ifNoneMatch(req, etag))
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package controllers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/models/dto"
+	"loki-backoffice/internal/app/serializers"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config/logger"
+)
+
+const NDJSONContentType = "application/x-ndjson"
+
+var exportContentTypes = map[string]string{
+	services.CSVExportFormat:    dto.CSVContentType + "; charset=utf-8",
+	services.NDJSONExportFormat: NDJSONContentType,
+	services.JSONExportFormat:   "application/json",
+}
+
+type ExportsController interface {
+	Permissions(w http.ResponseWriter, r *http.Request)
+	Roles(w http.ResponseWriter, r *http.Request)
+	Tokens(w http.ResponseWriter, r *http.Request)
+	Users(w http.ResponseWriter, r *http.Request)
+}
+
+type exportsController struct {
+	exports services.Exports
+	log     *logger.Logger
+}
+
+func NewExportsController(exports services.Exports, log *logger.Logger) ExportsController {
+	return &exportsController{
+		exports: exports,
+		log:     log,
+	}
+}
+
+func (c *exportsController) Permissions(w http.ResponseWriter, r *http.Request) {
+	serveExport(w, r, c.log, c.exports.Permissions, &exportSpec[models.Permission]{
+		name:   "permissions",
+		schema: services.PermissionsQuerySchema,
+		header: []string{"id", "name", "description"},
+		row: func(item models.Permission) []string {
+			return []string{item.ID.String(), item.Name, item.Description}
+		},
+		item: func(item models.Permission) any {
+			return serializers.PermissionSerializer{ID: item.ID, Name: item.Name, Description: item.Description}
+		},
+	})
+}
+
+func (c *exportsController) Roles(w http.ResponseWriter, r *http.Request) {
+	serveExport(w, r, c.log, c.exports.Roles, &exportSpec[models.Role]{
+		name:   "roles",
+		schema: services.RolesQuerySchema,
+		header: []string{"id", "name", "description"},
+		row: func(item models.Role) []string {
+			return []string{item.ID.String(), item.Name, item.Description}
+		},
+		item: func(item models.Role) any {
+			return serializers.RoleSerializer{ID: item.ID, Name: item.Name, Description: item.Description}
+		},
+	})
+}
+
+// Tokens exports fingerprints only, token values are available through the audited reveal endpoint
+func (c *exportsController) Tokens(w http.ResponseWriter, r *http.Request) {
+	serveExport(w, r, c.log, c.exports.Tokens, &exportSpec[models.Token]{
+		name:   "tokens",
+		schema: services.TokensQuerySchema,
+		header: []string{"id", "user_id", "type", "fingerprint", "expires_at"},
+		row: func(item models.Token) []string {
+			return []string{item.ID.String(), item.UserId.String(), item.Type, item.Fingerprint, item.ExpiresAt.UTC().Format(time.RFC3339)}
+		},
+		item: func(item models.Token) any {
+			return tokenSerializer(item)
+		},
+	})
+}
+
+// Users exports role and scope names next to their ids, the CSV export can be imported back
+func (c *exportsController) Users(w http.ResponseWriter, r *http.Request) {
+	serveExport(w, r, c.log, c.exports.Users, &exportSpec[models.UserExport]{
+		name:   "users",
+		schema: services.UsersQuerySchema,
+		header: []string{
+			"id",
+			dto.IdentityNumberColumn,
+			dto.PersonalCodeColumn,
+			dto.FirstNameColumn,
+			dto.LastNameColumn,
+			dto.RolesColumn,
+			dto.ScopesColumn,
+		},
+		row: func(item models.UserExport) []string {
+			return []string{
+				item.ID.String(),
+				item.IdentityNumber,
+				item.PersonalCode,
+				item.FirstName,
+				item.LastName,
+				strings.Join(item.Roles, dto.UserImportListSeparator),
+				strings.Join(item.Scopes, dto.UserImportListSeparator),
+			}
+		},
+		item: func(item models.UserExport) any {
+			return serializers.UserExportSerializer{
+				ID:             item.ID,
+				IdentityNumber: item.IdentityNumber,
+				PersonalCode:   item.PersonalCode,
+				FirstName:      item.FirstName,
+				LastName:       item.LastName,
+				RoleIDs:        nonNil(item.RoleIDs),
+				ScopeIDs:       nonNil(item.ScopeIDs),
+				Roles:          nonNil(item.Roles),
+				Scopes:         nonNil(item.Scopes),
+			}
+		},
+	})
+}
+
+// exportSpec describes how the records of a resource are written as CSV rows and JSON items
+type exportSpec[T any] struct {
+	name   string
+	schema *services.QuerySchema
+	header []string
+	row    func(item T) []string
+	item   func(item T) any
+}
+
+// serveExport streams the export in the requested format, the response starts with the first page so
+// errors before it are reported as problems and errors after it abort the connection to signal truncation
+func serveExport[T any](
+	w http.ResponseWriter,
+	r *http.Request,
+	log *logger.Logger,
+	run func(ctx context.Context, query *services.Query, emit func(batch []T) error) error,
+	spec *exportSpec[T],
+) {
+	format := r.URL.Query().Get(services.ExportFormatParam)
+	if format == "" {
+		format = services.JSONExportFormat
+	}
+
+	if _, ok := exportContentTypes[format]; !ok {
+		log.Error().Str("format", format).Msg("Unsupported export format")
+		serializers.WriteProblem(w, r, fmt.Errorf("%w: format must be one of csv, ndjson or json", errors.ErrInvalidArguments))
+		return
+	}
+
+	query, err := services.NewQuery(r, spec.schema)
+	if err != nil {
+		log.Error().Err(err).Str("query", r.URL.RawQuery).Msg("Invalid export query")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	writer := &exportWriter[T]{
+		w:          w,
+		controller: http.NewResponseController(w),
+		format:     format,
+		spec:       spec,
+	}
+
+	// an export of a large collection outlives the server write timeout
+	_ = writer.controller.SetWriteDeadline(time.Time{})
+
+	if err = run(r.Context(), query, writer.write); err == nil {
+		err = writer.finish()
+	}
+
+	if err != nil {
+		log.Error().Err(err).Str("resource", spec.name).Int("records", writer.count).Msg("Failed to export")
+
+		if !writer.started {
+			serializers.WriteProblem(w, r, err)
+			return
+		}
+
+		panic(http.ErrAbortHandler)
+	}
+
+	log.Info().Str("resource", spec.name).Str("format", format).Int("records", writer.count).Msg("Exported")
+}
+
+type exportWriter[T any] struct {
+	w          http.ResponseWriter
+	controller *http.ResponseController
+	format     string
+	spec       *exportSpec[T]
+	csv        *csv.Writer
+	started    bool
+	count      int
+}
+
+func (e *exportWriter[T]) start() error {
+	e.started = true
+
+	e.w.Header().Set("Content-Type", exportContentTypes[e.format])
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, e.spec.name, e.format))
+	e.w.WriteHeader(http.StatusOK)
+
+	switch e.format {
+	case services.CSVExportFormat:
+		e.csv = csv.NewWriter(e.w)
+		return e.csv.Write(e.spec.header)
+	case services.JSONExportFormat:
+		_, err := e.w.Write([]byte("["))
+		return err
+	default:
+		return nil
+	}
+}
+
+func (e *exportWriter[T]) write(batch []T) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range batch {
+		if err := e.encode(item); err != nil {
+			return err
+		}
+
+		e.count++
+	}
+
+	return e.flush()
+}
+
+func (e *exportWriter[T]) encode(item T) error {
+	if e.format == services.CSVExportFormat {
+		return e.csv.Write(e.spec.row(item))
+	}
+
+	data, err := json.Marshal(e.spec.item(item))
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case e.format == services.NDJSONExportFormat:
+		data = append(data, '\n')
+	case e.count > 0:
+		data = append([]byte(","), data...)
+	}
+
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *exportWriter[T]) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+
+	// writers without flushing support are simply buffered until the handler returns
+	_ = e.controller.Flush()
+
+	return nil
+}
+
+func (e *exportWriter[T]) finish() error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+
+	if e.format == services.JSONExportFormat {
+		if _, err := e.w.Write([]byte("]\n")); err != nil {
+			return err
+		}
+	}
+
+	return e.flush()
+}
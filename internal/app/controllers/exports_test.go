@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func Test_Exports_Users(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	exports := services.NewMockExports(ctrl)
+	controller := NewExportsController(exports, log)
+
+	roleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	john := models.UserExport{
+		User: models.User{
+			ID:             uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+			IdentityNumber: "PNOEE-60001017869",
+			PersonalCode:   "60001017869",
+			FirstName:      "John",
+			LastName:       "Doe",
+			RoleIDs:        []uuid.UUID{roleId},
+		},
+		Roles: []string{"admin", "manager"},
+	}
+	jane := models.UserExport{
+		User: models.User{
+			ID:             uuid.MustParse("10000000-1000-1000-1234-000000000002"),
+			IdentityNumber: "PNOEE-60001017870",
+			PersonalCode:   "60001017870",
+			FirstName:      "Jane",
+			LastName:       "Doe",
+		},
+	}
+
+	emitPages := func(_ context.Context, _ *services.Query, emit func(batch []models.UserExport) error) error {
+		if err := emit([]models.UserExport{john}); err != nil {
+			return err
+		}
+
+		return emit([]models.UserExport{jane})
+	}
+
+	type result struct {
+		code        int
+		contentType string
+		body        string
+	}
+
+	tests := []struct {
+		name     string
+		before   func()
+		url      string
+		expected result
+	}{
+		{
+			name: "CSV",
+			before: func() {
+				exports.EXPECT().Users(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(emitPages)
+			},
+			url: "/api/backoffice/users/export?format=csv",
+			expected: result{
+				code:        http.StatusOK,
+				contentType: "text/csv; charset=utf-8",
+				body: "id,identity_number,personal_code,first_name,last_name,roles,scopes\n" +
+					"10000000-1000-1000-1234-000000000001,PNOEE-60001017869,60001017869,John,Doe,admin;manager,\n" +
+					"10000000-1000-1000-1234-000000000002,PNOEE-60001017870,60001017870,Jane,Doe,,\n",
+			},
+		},
+		{
+			name: "NDJSON",
+			before: func() {
+				exports.EXPECT().Users(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(emitPages)
+			},
+			url: "/api/backoffice/users/export?format=ndjson",
+			expected: result{
+				code:        http.StatusOK,
+				contentType: "application/x-ndjson",
+				body: `{"id":"10000000-1000-1000-1234-000000000001","identity_number":"PNOEE-60001017869","personal_code":"60001017869","first_name":"John","last_name":"Doe","role_ids":["10000000-1000-1000-3000-000000000001"],"scope_ids":[],"roles":["admin","manager"],"scopes":[]}` + "\n" +
+					`{"id":"10000000-1000-1000-1234-000000000002","identity_number":"PNOEE-60001017870","personal_code":"60001017870","first_name":"Jane","last_name":"Doe","role_ids":[],"scope_ids":[],"roles":[],"scopes":[]}` + "\n",
+			},
+		},
+		{
+			name: "JSON with filters",
+			before: func() {
+				exports.EXPECT().Users(gomock.Any(), &services.Query{
+					Sort:    []services.SortField{},
+					Filters: map[string]string{"last_name": "Doe"},
+				}, gomock.Any()).DoAndReturn(emitPages)
+			},
+			url: "/api/backoffice/users/export?last_name=Doe",
+			expected: result{
+				code:        http.StatusOK,
+				contentType: "application/json",
+				body: `[{"id":"10000000-1000-1000-1234-000000000001","identity_number":"PNOEE-60001017869","personal_code":"60001017869","first_name":"John","last_name":"Doe","role_ids":["10000000-1000-1000-3000-000000000001"],"scope_ids":[],"roles":["admin","manager"],"scopes":[]},` +
+					`{"id":"10000000-1000-1000-1234-000000000002","identity_number":"PNOEE-60001017870","personal_code":"60001017870","first_name":"Jane","last_name":"Doe","role_ids":[],"scope_ids":[],"roles":[],"scopes":[]}]` + "\n",
+			},
+		},
+		{
+			name: "Empty JSON",
+			before: func() {
+				exports.EXPECT().Users(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			url: "/api/backoffice/users/export",
+			expected: result{
+				code:        http.StatusOK,
+				contentType: "application/json",
+				body:        "[]\n",
+			},
+		},
+		{
+			name: "Unsupported format",
+			before: func() {
+				exports.EXPECT().Users(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			url:      "/api/backoffice/users/export?format=xml",
+			expected: result{code: http.StatusBadRequest, contentType: "application/problem+json"},
+		},
+		{
+			name: "Invalid sort",
+			before: func() {
+				exports.EXPECT().Users(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			url:      "/api/backoffice/users/export?sort=password",
+			expected: result{code: http.StatusBadRequest, contentType: "application/problem+json"},
+		},
+		{
+			name: "Error before the first page",
+			before: func() {
+				exports.EXPECT().Users(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.ErrUnavailable)
+			},
+			url:      "/api/backoffice/users/export?format=csv",
+			expected: result{code: http.StatusServiceUnavailable, contentType: "application/problem+json"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Get("/api/backoffice/users/export", controller.Users)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+			assert.Equal(t, tt.expected.contentType, resp.Header.Get("Content-Type"))
+
+			if tt.expected.body != "" {
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, tt.expected.body, string(body))
+				assert.True(t, w.Flushed)
+			}
+		})
+	}
+
+	t.Run("Error after the first page", func(t *testing.T) {
+		exports.EXPECT().Users(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *services.Query, emit func(batch []models.UserExport) error) error {
+				_ = emit([]models.UserExport{john})
+				return errors.ErrUnavailable
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/backoffice/users/export?format=ndjson", nil)
+		w := httptest.NewRecorder()
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			controller.Users(w, req)
+		})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
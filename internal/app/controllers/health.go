@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"loki-backoffice/internal/app/errors"
 	"loki-backoffice/internal/app/serializers"
 	"loki-backoffice/internal/app/services"
 )
@@ -28,17 +29,17 @@ func (h *healthController) HandleLiveness(w http.ResponseWriter, _ *http.Request
 	_ = json.NewEncoder(w).Encode(serializers.HealthSerializer{Result: "alive"})
 }
 
-// HandleReadiness handles application readiness check
+// HandleReadiness handles application readiness check, circuit breaker states are reported
+// but an open circuit does not fail the check since every replica shares the SSO service
 func (h *healthController) HandleReadiness(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
 	err := h.service.Ping(r.Context())
 	if err != nil {
-		w.WriteHeader(http.StatusServiceUnavailable)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: "unavailable"})
+		serializers.WriteProblem(w, r, errors.ErrUnavailable)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(serializers.HealthSerializer{Result: "ready"})
+	_ = json.NewEncoder(w).Encode(serializers.HealthSerializer{Result: "ready", Circuits: h.service.Circuits()})
 }
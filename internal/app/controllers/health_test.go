@@ -69,7 +69,7 @@ func Test_HealthController_HandleReadiness(t *testing.T) {
 
 	type result struct {
 		response serializers.HealthSerializer
-		error    serializers.ErrorSerializer
+		detail   string
 		code     int
 		status   string
 	}
@@ -83,9 +83,10 @@ func Test_HealthController_HandleReadiness(t *testing.T) {
 			name: "Success",
 			before: func() {
 				service.EXPECT().Ping(gomock.Any()).Return(nil)
+				service.EXPECT().Circuits().Return(map[string]string{"sso.v1.UserService": "open"})
 			},
 			expected: result{
-				response: serializers.HealthSerializer{Result: "ready"},
+				response: serializers.HealthSerializer{Result: "ready", Circuits: map[string]string{"sso.v1.UserService": "open"}},
 				code:     http.StatusOK,
 				status:   "200 OK",
 			},
@@ -96,7 +97,7 @@ func Test_HealthController_HandleReadiness(t *testing.T) {
 				service.EXPECT().Ping(gomock.Any()).Return(assert.AnError)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "unavailable"},
+				detail: "unavailable",
 				code:   http.StatusServiceUnavailable,
 				status: "503 Service Unavailable",
 			},
@@ -115,16 +116,16 @@ func Test_HealthController_HandleReadiness(t *testing.T) {
 			resp := w.Result()
 			defer resp.Body.Close()
 
-			if tt.expected.error.Error != "" {
-				var response serializers.ErrorSerializer
+			if tt.expected.detail != "" {
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error.Error, response.Error)
+				assert.Equal(t, tt.expected.detail, response.Detail)
 			} else {
 				var actual serializers.HealthSerializer
 				err := json.NewDecoder(resp.Body).Decode(&actual)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.response.Result, actual.Result)
+				assert.Equal(t, tt.expected.response, actual)
 			}
 
 			assert.Equal(t, tt.expected.status, resp.Status)
@@ -0,0 +1,33 @@
+package controllers
+
+import (
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/serializers"
+)
+
+func impactSerializer(impact *models.Impact) serializers.ImpactSerializer {
+	result := serializers.ImpactSerializer{
+		ResourceType: impact.ResourceType,
+		ResourceID:   impact.ResourceId,
+		Roles:        make([]serializers.RoleReferenceSerializer, 0, len(impact.Roles)),
+		Users:        make([]serializers.UserReferenceSerializer, 0, len(impact.Users)),
+	}
+
+	for _, role := range impact.Roles {
+		result.Roles = append(result.Roles, serializers.RoleReferenceSerializer{
+			ID:   role.ID,
+			Name: role.Name,
+		})
+	}
+
+	for _, user := range impact.Users {
+		result.Users = append(result.Users, serializers.UserReferenceSerializer{
+			ID:             user.ID,
+			IdentityNumber: user.IdentityNumber,
+			FirstName:      user.FirstName,
+			LastName:       user.LastName,
+		})
+	}
+
+	return result
+}
@@ -3,10 +3,15 @@ package controllers
 import "go.uber.org/fx"
 
 var Module = fx.Options(
+	fx.Provide(NewAuditController),
+	fx.Provide(NewExportsController),
 	fx.Provide(NewHealthController),
 	fx.Provide(NewPermissionsController),
+	fx.Provide(NewRBACController),
 	fx.Provide(NewRolesController),
 	fx.Provide(NewScopesController),
+	fx.Provide(NewSnapshotsController),
 	fx.Provide(NewTokensController),
+	fx.Provide(NewUserImportsController),
 	fx.Provide(NewUsersController),
 )
@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 
@@ -20,17 +22,28 @@ type PermissionsController interface {
 	Get(w http.ResponseWriter, r *http.Request)
 	Create(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
+	Patch(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Bulk(w http.ResponseWriter, r *http.Request)
+	Roles(w http.ResponseWriter, r *http.Request)
 }
 
 type permissionsController struct {
 	permissions services.Permissions
+	index       services.Index
+	impact      services.ImpactAnalyzer
+	audit       services.Audit
+	paginator   services.Paginator
 	log         *logger.Logger
 }
 
-func NewPermissionsController(permissions services.Permissions, log *logger.Logger) PermissionsController {
+func NewPermissionsController(permissions services.Permissions, index services.Index, impact services.ImpactAnalyzer, audit services.Audit, paginator services.Paginator, log *logger.Logger) PermissionsController {
 	return &permissionsController{
 		permissions: permissions,
+		index:       index,
+		impact:      impact,
+		audit:       audit,
+		paginator:   paginator,
 		log:         log,
 	}
 }
@@ -39,19 +52,23 @@ func NewPermissionsController(permissions services.Permissions, log *logger.Logg
 func (c *permissionsController) List(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	pagination := services.NewPagination(r)
-	rows, total, err := c.permissions.List(r.Context(), pagination)
+	query, err := services.NewQuery(r, services.PermissionsQuerySchema)
 	if err != nil {
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrFailedToFetchResults):
-			w.WriteHeader(http.StatusServiceUnavailable)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		c.log.Error().Err(err).Str("query", r.URL.RawQuery).Msg("Invalid list query")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	pagination, err := c.paginator.Paginate(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("cursor", r.URL.Query().Get(services.CursorParam)).Msg("Invalid pagination cursor")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
 
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+	rows, total, err := c.permissions.List(r.Context(), pagination, query)
+	if err != nil {
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -71,6 +88,8 @@ func (c *permissionsController) List(w http.ResponseWriter, r *http.Request) {
 			Page:  pagination.Page,
 			Per:   pagination.PerPage,
 			Total: total,
+			Next:  c.paginator.Next(r, pagination),
+			Prev:  c.paginator.Prev(r, pagination),
 		},
 	}
 
@@ -85,8 +104,7 @@ func (c *permissionsController) Get(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
 		return
 	}
 
@@ -95,14 +113,7 @@ func (c *permissionsController) Get(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get permission")
 
-		switch {
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -112,6 +123,13 @@ func (c *permissionsController) Get(w http.ResponseWriter, r *http.Request) {
 		Description: record.Description,
 	}
 
+	etag := serializers.ETag(response)
+	w.Header().Set(ETagHeader, etag)
+	if ifNoneMatch(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -123,8 +141,7 @@ func (c *permissionsController) Create(w http.ResponseWriter, r *http.Request) {
 	var params dto.PermissionRequest
 	if err := params.Validate(r.Body); err != nil {
 		c.log.Error().Err(err).Str("name", params.Name).Msg("Failed to create permission")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -135,16 +152,7 @@ func (c *permissionsController) Create(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Msg("Failed to create permission")
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrFailedToCreateRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -154,6 +162,9 @@ func (c *permissionsController) Create(w http.ResponseWriter, r *http.Request) {
 		Description: record.Description,
 	}
 
+	c.index.Invalidate()
+	services.RecordApplied(r.Context(), c.audit, c.log, models.CreateActionType, models.PermissionResourceType, record.ID, nil, record.AuditSnapshot())
+
 	w.WriteHeader(http.StatusCreated)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -165,19 +176,73 @@ func (c *permissionsController) Update(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
 		return
 	}
 
 	var params dto.PermissionRequest
 	if err = params.Validate(r.Body); err != nil {
 		c.log.Error().Err(err).Str("name", params.Name).Msg("Failed to create permission")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	before, err := c.permissions.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Failed to load permission state for audit")
+		before = nil
+	}
+
+	if err = ifMatch(r, permissionSerializer(before), err); err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Rejected conditional permission write")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.update(w, r, id, &params, before)
+}
+
+// Patch applies a merge patch or JSON patch document to the permission
+//
+//nolint:dupl
+func (c *permissionsController) Patch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	before, err := c.permissions.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to find permission")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	if err = ifMatch(r, permissionSerializer(before), nil); err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Rejected conditional permission write")
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
+	params := dto.PermissionRequest{
+		Name:        before.Name,
+		Description: before.Description,
+	}
+	if err = params.Patch(r.Header.Get("Content-Type"), http.MaxBytesReader(w, r.Body, dto.MaxPatchBodySize)); err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to patch permission")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.update(w, r, id, &params, before)
+}
+
+// update stores params over the permission, before is the state recorded in the audit log
+func (c *permissionsController) update(w http.ResponseWriter, r *http.Request, id uuid.UUID, params *dto.PermissionRequest, before *models.Permission) {
 	record, err := c.permissions.Update(r.Context(), &models.Permission{
 		ID:          id,
 		Name:        params.Name,
@@ -186,18 +251,7 @@ func (c *permissionsController) Update(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to update permission")
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		case errors.Is(err, errors.ErrFailedToUpdateRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -207,6 +261,9 @@ func (c *permissionsController) Update(w http.ResponseWriter, r *http.Request) {
 		Description: record.Description,
 	}
 
+	c.index.Invalidate()
+	services.RecordApplied(r.Context(), c.audit, c.log, models.UpdateActionType, models.PermissionResourceType, id, before.AuditSnapshot(), record.AuditSnapshot())
+
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -218,29 +275,306 @@ func (c *permissionsController) Delete(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	options, err := services.NewDeleteOptions(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid delete options")
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
+	before, err := c.permissions.FindById(r.Context(), id)
+	if err != nil {
+		if options.DryRun && errors.Is(err, errors.ErrRecordNotFound) {
+			serializers.WriteProblem(w, r, err)
+			return
+		}
+
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Failed to load permission state for audit")
+		before = nil
+	}
+
+	if err = ifMatch(r, permissionSerializer(before), err); err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Rejected conditional permission write")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	if options.Checked() {
+		impact, err := c.impact.Permission(r.Context(), id)
+		if err != nil {
+			c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to analyze permission delete impact")
+
+			serializers.WriteProblem(w, r, err)
+			return
+		}
+
+		if options.DryRun {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(impactSerializer(impact))
+			return
+		}
+
+		if !impact.IsEmpty() {
+			c.log.Warn().Str("id", id.String()).Int("roles", len(impact.Roles)).Int("users", len(impact.Users)).Msg("Refused to delete permission with dependents")
+			w.Header().Set("Content-Type", serializers.ProblemContentType)
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(serializers.DeleteConflictSerializer{
+				ProblemSerializer: serializers.NewProblem(r, errors.ErrResourceHasDependents),
+				Dependents:        impactSerializer(impact),
+			})
+			return
+		}
+	}
+
 	_, err = c.permissions.Delete(r.Context(), id)
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to delete permission")
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		case errors.Is(err, errors.ErrFailedToDeleteRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.index.Invalidate()
+	services.RecordApplied(r.Context(), c.audit, c.log, models.DeleteActionType, models.PermissionResourceType, id, before.AuditSnapshot(), nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Bulk creates, updates and deletes permissions in one request and reports the outcome of every operation
+//
+//nolint:dupl
+func (c *permissionsController) Bulk(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	options, err := services.NewBulkOptions(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("query", r.URL.RawQuery).Msg("Invalid bulk options")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	var params dto.BulkRequest
+	if err = params.Validate(r.Body); err != nil {
+		c.log.Error().Err(err).Msg("Invalid bulk permission request")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	operations := make([]services.BulkOperation, 0, len(params))
+	for _, item := range params {
+		operations = append(operations, c.bulkOperation(item, options))
+	}
+
+	results := services.RunBulk(r.Context(), operations, options)
+	c.index.Invalidate()
+
+	writeBulk(w, r, results, options)
+}
+
+// bulkOperation binds an operation of a bulk request to the permissions service, every applied
+// operation is audited and knows how to undo itself for atomic requests
+//
+//nolint:dupl
+func (c *permissionsController) bulkOperation(item dto.BulkOperationRequest, options *services.BulkOptions) services.BulkOperation {
+	operation := services.BulkOperation{Op: item.Op, ID: item.ID}
+
+	var params dto.PermissionRequest
+	if item.Op != models.DeleteActionType {
+		if operation.Err = params.Validate(bytes.NewReader(item.Data)); operation.Err != nil {
+			return operation
 		}
+	}
+
+	create := func(ctx context.Context, params *models.Permission) (*models.Permission, error) {
+		record, err := c.permissions.Create(ctx, params)
+		if err != nil {
+			c.log.Error().Err(err).Str("name", params.Name).Msg("Failed to create permission")
+			return nil, err
+		}
+
+		services.RecordApplied(ctx, c.audit, c.log, models.CreateActionType, models.PermissionResourceType, record.ID, nil, record.AuditSnapshot())
+		return record, nil
+	}
+
+	update := func(ctx context.Context, params, before *models.Permission) (*models.Permission, error) {
+		record, err := c.permissions.Update(ctx, params)
+		if err != nil {
+			c.log.Error().Err(err).Str("id", params.ID.String()).Msg("Failed to update permission")
+			return nil, err
+		}
+
+		services.RecordApplied(ctx, c.audit, c.log, models.UpdateActionType, models.PermissionResourceType, params.ID, before.AuditSnapshot(), record.AuditSnapshot())
+		return record, nil
+	}
+
+	remove := func(ctx context.Context, before *models.Permission) error {
+		if _, err := c.permissions.Delete(ctx, before.ID); err != nil {
+			c.log.Error().Err(err).Str("id", before.ID.String()).Msg("Failed to delete permission")
+			return err
+		}
+
+		services.RecordApplied(ctx, c.audit, c.log, models.DeleteActionType, models.PermissionResourceType, before.ID, before.AuditSnapshot(), nil)
+		return nil
+	}
+
+	switch item.Op {
+	case models.CreateActionType:
+		operation.Apply = func(ctx context.Context) (*services.BulkApplied, error) {
+			record, err := create(ctx, &models.Permission{
+				Name:        params.Name,
+				Description: params.Description,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return &services.BulkApplied{
+				ID:     record.ID,
+				Result: permissionSerializer(record),
+				Undo: func(ctx context.Context) error {
+					return remove(ctx, record)
+				},
+			}, nil
+		}
+	case models.UpdateActionType:
+		operation.Apply = func(ctx context.Context) (*services.BulkApplied, error) {
+			before, err := c.permissions.FindById(ctx, item.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			record, err := update(ctx, &models.Permission{
+				ID:          item.ID,
+				Name:        params.Name,
+				Description: params.Description,
+			}, before)
+			if err != nil {
+				return nil, err
+			}
+
+			return &services.BulkApplied{
+				ID:     record.ID,
+				Result: permissionSerializer(record),
+				Undo: func(ctx context.Context) error {
+					_, err := update(ctx, before, record)
+					return err
+				},
+			}, nil
+		}
+	case models.DeleteActionType:
+		operation.Apply = func(ctx context.Context) (*services.BulkApplied, error) {
+			before, err := c.permissions.FindById(ctx, item.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			if !options.Force {
+				impact, err := c.impact.Permission(ctx, item.ID)
+				if err != nil {
+					return nil, err
+				}
+				if !impact.IsEmpty() {
+					return nil, services.DependentsError(impact)
+				}
+			}
+
+			if err = remove(ctx, before); err != nil {
+				return nil, err
+			}
+
+			// upstream assigns ids on create, a restored permission comes back under a new id
+			return &services.BulkApplied{
+				ID: item.ID,
+				Undo: func(ctx context.Context) error {
+					_, err := create(ctx, &models.Permission{
+						Name:        before.Name,
+						Description: before.Description,
+					})
+					return err
+				},
+			}, nil
+		}
+	}
+
+	return operation
+}
+
+// Roles lists the roles containing the permission
+//
+//nolint:dupl
+func (c *permissionsController) Roles(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
 
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	pagination, err := c.paginator.Paginate(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("cursor", r.URL.Query().Get(services.CursorParam)).Msg("Invalid pagination cursor")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	if _, err = c.permissions.FindById(r.Context(), id); err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get permission")
+
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	rows, err := c.index.RolesByPermission(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to look up roles containing the permission")
+
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	total := uint64(len(rows))
+	page := services.PageOf(rows, pagination, func(role models.Role) uuid.UUID { return role.ID })
+	collection := make([]serializers.RoleSerializer, 0, len(page))
+
+	for _, role := range page {
+		collection = append(collection, serializers.RoleSerializer{
+			ID:            role.ID,
+			Name:          role.Name,
+			Description:   role.Description,
+			PermissionIDs: role.PermissionIDs,
+		})
+	}
+
+	response := serializers.PaginationResponse[serializers.RoleSerializer]{
+		Data: collection,
+		Meta: serializers.PaginationMeta{
+			Page:  pagination.Page,
+			Per:   pagination.PerPage,
+			Total: total,
+			Next:  c.paginator.Next(r, pagination),
+			Prev:  c.paginator.Prev(r, pagination),
+		},
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// permissionSerializer is the response shape of a permission, nil when there is none, so that If-Match compares against the ETag of Show
+func permissionSerializer(record *models.Permission) interface{} {
+	if record == nil {
+		return nil
+	}
+
+	return serializers.PermissionSerializer{
+		ID:          record.ID,
+		Name:        record.Name,
+		Description: record.Description,
+	}
 }
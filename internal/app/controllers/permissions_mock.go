@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/app/controllers/permissions.go
+// Source: permissions.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/app/controllers/permissions.go -destination=internal/app/controllers/permissions_mock.go -package=controllers
+//	mockgen -source=permissions.go -destination=permissions_mock.go -package=controllers
 //
 
 // Package controllers is a generated GoMock package.
@@ -40,6 +40,18 @@ func (m *MockPermissionsController) EXPECT() *MockPermissionsControllerMockRecor
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockPermissionsController) Bulk(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Bulk", w, r)
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockPermissionsControllerMockRecorder) Bulk(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockPermissionsController)(nil).Bulk), w, r)
+}
+
 // Create mocks base method.
 func (m *MockPermissionsController) Create(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -88,6 +100,30 @@ func (mr *MockPermissionsControllerMockRecorder) List(w, r any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPermissionsController)(nil).List), w, r)
 }
 
+// Patch mocks base method.
+func (m *MockPermissionsController) Patch(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Patch", w, r)
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockPermissionsControllerMockRecorder) Patch(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockPermissionsController)(nil).Patch), w, r)
+}
+
+// Roles mocks base method.
+func (m *MockPermissionsController) Roles(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Roles", w, r)
+}
+
+// Roles indicates an expected call of Roles.
+func (mr *MockPermissionsControllerMockRecorder) Roles(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Roles", reflect.TypeOf((*MockPermissionsController)(nil).Roles), w, r)
+}
+
 // Update mocks base method.
 func (m *MockPermissionsController) Update(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
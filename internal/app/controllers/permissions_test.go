@@ -33,11 +33,16 @@ func Test_Permissions_List(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	permissions := services.NewMockPermissions(ctrl)
-	controller := NewPermissionsController(permissions, log)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewPermissionsController(permissions, index, impact, audit, paginator, log)
 
 	type result struct {
 		response serializers.PaginationResponse[serializers.PermissionSerializer]
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
@@ -51,7 +56,7 @@ func Test_Permissions_List(t *testing.T) {
 		{
 			name: "Success",
 			before: func() {
-				permissions.EXPECT().List(gomock.Any(), gomock.Any()).Return([]models.Permission{
+				permissions.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Permission{
 					{
 						ID:          uuid.MustParse("10000000-1000-1000-3000-000000000001"),
 						Name:        "read:self",
@@ -92,7 +97,7 @@ func Test_Permissions_List(t *testing.T) {
 		{
 			name: "Empty",
 			before: func() {
-				permissions.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), nil)
+				permissions.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), nil)
 			},
 			expected: result{
 				response: serializers.PaginationResponse[serializers.PermissionSerializer]{
@@ -111,10 +116,10 @@ func Test_Permissions_List(t *testing.T) {
 		{
 			name: "Invalid params",
 			before: func() {
-				permissions.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrInvalidArguments)
+				permissions.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrInvalidArguments)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -123,22 +128,22 @@ func Test_Permissions_List(t *testing.T) {
 		{
 			name: "Bad request",
 			before: func() {
-				permissions.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrFailedToFetchResults)
+				permissions.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrFailedToFetchResults)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to fetch results"},
-				status: "503 Service Unavailable",
-				code:   http.StatusServiceUnavailable,
+				detail: "failed to fetch results",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 			error: true,
 		},
 		{
 			name: "Error",
 			before: func() {
-				permissions.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), assert.AnError)
+				permissions.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), assert.AnError)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -161,10 +166,11 @@ func Test_Permissions_List(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.PaginationResponse[serializers.PermissionSerializer]
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -190,13 +196,18 @@ func Test_Permissions_Get(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	permissions := services.NewMockPermissions(ctrl)
-	controller := NewPermissionsController(permissions, log)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewPermissionsController(permissions, index, impact, audit, paginator, log)
 
 	id := uuid.MustParse("10000000-1000-1000-3000-000000000001")
 
 	type result struct {
 		response serializers.PermissionSerializer
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
@@ -233,7 +244,7 @@ func Test_Permissions_Get(t *testing.T) {
 				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{}, errors.ErrRecordNotFound)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: errors.ErrRecordNotFound.Error()},
+				detail: errors.ErrRecordNotFound.Error(),
 				status: "404 Not Found",
 				code:   http.StatusNotFound,
 			},
@@ -244,7 +255,7 @@ func Test_Permissions_Get(t *testing.T) {
 				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{}, assert.AnError)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -267,10 +278,11 @@ func Test_Permissions_Get(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.PermissionSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -296,13 +308,18 @@ func Test_Permissions_Create(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	permissions := services.NewMockPermissions(ctrl)
-	controller := NewPermissionsController(permissions, log)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewPermissionsController(permissions, index, impact, audit, paginator, log)
 
 	id := uuid.MustParse("10000000-1000-1000-3000-000000000001")
 
 	type result struct {
 		response serializers.PermissionSerializer
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
@@ -325,6 +342,7 @@ func Test_Permissions_Create(t *testing.T) {
 					Name:        "read:self",
 					Description: "Read own data",
 				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.CreateActionType, models.PermissionResourceType, id, nil, gomock.Any()).Return(nil)
 			},
 			body: strings.NewReader(`{"name": "read:self", "description" :"Read own data"}`),
 			expected: result{
@@ -345,7 +363,7 @@ func Test_Permissions_Create(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "read:self"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "empty description"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -361,7 +379,7 @@ func Test_Permissions_Create(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "read:self", "description": "Read own data"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -376,9 +394,9 @@ func Test_Permissions_Create(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "read:self", "description": "Read own data"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to create record"},
-				status: "422 Unprocessable Entity",
-				code:   http.StatusUnprocessableEntity,
+				detail: "failed to create record",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 			error: true,
 		},
@@ -392,7 +410,7 @@ func Test_Permissions_Create(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "read:self", "description": "Read own data"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -415,10 +433,11 @@ func Test_Permissions_Create(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.PermissionSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -444,13 +463,18 @@ func Test_Permissions_Update(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	permissions := services.NewMockPermissions(ctrl)
-	controller := NewPermissionsController(permissions, log)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewPermissionsController(permissions, index, impact, audit, paginator, log)
 
 	id := uuid.MustParse("10000000-1000-1000-3000-000000000001")
 
 	type result struct {
 		response serializers.PermissionSerializer
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
@@ -465,6 +489,7 @@ func Test_Permissions_Update(t *testing.T) {
 		{
 			name: "Success",
 			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
 				permissions.EXPECT().Update(gomock.Any(), &models.Permission{
 					ID:          id,
 					Name:        "read:self",
@@ -474,6 +499,7 @@ func Test_Permissions_Update(t *testing.T) {
 					Name:        "read:self",
 					Description: "Read own data",
 				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.PermissionResourceType, id, gomock.Any(), gomock.Any()).Return(nil)
 			},
 			body: strings.NewReader(`{"name": "read:self", "description": "Read own data"}`),
 			expected: result{
@@ -494,7 +520,7 @@ func Test_Permissions_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "read:self"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "empty description"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -503,6 +529,7 @@ func Test_Permissions_Update(t *testing.T) {
 		{
 			name: "Invalid arguments",
 			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
 				permissions.EXPECT().Update(gomock.Any(), &models.Permission{
 					ID:          id,
 					Name:        "read:self",
@@ -511,7 +538,7 @@ func Test_Permissions_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "read:self", "description": "Read own data"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -520,6 +547,7 @@ func Test_Permissions_Update(t *testing.T) {
 		{
 			name: "Not found",
 			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
 				permissions.EXPECT().Update(gomock.Any(), &models.Permission{
 					ID:          id,
 					Name:        "read:self",
@@ -528,7 +556,7 @@ func Test_Permissions_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "read:self", "description": "Read own data"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: errors.ErrRecordNotFound.Error()},
+				detail: errors.ErrRecordNotFound.Error(),
 				status: "404 Not Found",
 				code:   http.StatusNotFound,
 			},
@@ -536,6 +564,7 @@ func Test_Permissions_Update(t *testing.T) {
 		{
 			name: "Bad request",
 			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
 				permissions.EXPECT().Update(gomock.Any(), &models.Permission{
 					ID:          id,
 					Name:        "read:self",
@@ -544,14 +573,15 @@ func Test_Permissions_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "read:self", "description": "Read own data"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to update record"},
-				status: "422 Unprocessable Entity",
-				code:   http.StatusUnprocessableEntity,
+				detail: "failed to update record",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 		},
 		{
 			name: "Error",
 			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
 				permissions.EXPECT().Update(gomock.Any(), &models.Permission{
 					ID:          id,
 					Name:        "read:self",
@@ -560,7 +590,7 @@ func Test_Permissions_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "read:self", "description": "Read own data"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -583,10 +613,11 @@ func Test_Permissions_Update(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.PermissionSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -612,18 +643,28 @@ func Test_Permissions_Delete(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	permissions := services.NewMockPermissions(ctrl)
-	controller := NewPermissionsController(permissions, log)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewPermissionsController(permissions, index, impact, audit, paginator, log)
 
 	id := uuid.MustParse("10000000-1000-1000-3000-000000000001")
 
+	userId := uuid.MustParse("10000000-1000-1000-1234-000000000001")
+
 	type result struct {
-		error  serializers.ErrorSerializer
-		status string
-		code   int
+		impact   serializers.ImpactSerializer
+		conflict serializers.DeleteConflictSerializer
+		detail   string
+		status   string
+		code     int
 	}
 
 	tests := []struct {
 		name     string
+		query    string
 		before   func()
 		expected result
 		error    bool
@@ -631,7 +672,10 @@ func Test_Permissions_Delete(t *testing.T) {
 		{
 			name: "Success",
 			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
+				impact.EXPECT().Permission(gomock.Any(), id).Return(&models.Impact{}, nil)
 				permissions.EXPECT().Delete(gomock.Any(), id).Return(true, nil)
+				audit.EXPECT().Record(gomock.Any(), models.DeleteActionType, models.PermissionResourceType, id, gomock.Any(), nil).Return(nil)
 			},
 			expected: result{
 				status: "204 No Content",
@@ -642,10 +686,12 @@ func Test_Permissions_Delete(t *testing.T) {
 		{
 			name: "Invalid arguments",
 			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
+				impact.EXPECT().Permission(gomock.Any(), id).Return(&models.Impact{}, nil)
 				permissions.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(false, errors.ErrInvalidArguments)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -654,10 +700,12 @@ func Test_Permissions_Delete(t *testing.T) {
 		{
 			name: "Not found",
 			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
+				impact.EXPECT().Permission(gomock.Any(), id).Return(&models.Impact{}, nil)
 				permissions.EXPECT().Delete(gomock.Any(), id).Return(false, errors.ErrRecordNotFound)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: errors.ErrRecordNotFound.Error()},
+				detail: errors.ErrRecordNotFound.Error(),
 				status: "404 Not Found",
 				code:   http.StatusNotFound,
 			},
@@ -665,33 +713,141 @@ func Test_Permissions_Delete(t *testing.T) {
 		{
 			name: "Bad request",
 			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
+				impact.EXPECT().Permission(gomock.Any(), id).Return(&models.Impact{}, nil)
 				permissions.EXPECT().Delete(gomock.Any(), id).Return(false, errors.ErrFailedToDeleteRecord)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to delete record"},
-				status: "422 Unprocessable Entity",
-				code:   http.StatusUnprocessableEntity,
+				detail: "failed to delete record",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 		},
 		{
 			name: "Error",
 			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
+				impact.EXPECT().Permission(gomock.Any(), id).Return(&models.Impact{}, nil)
 				permissions.EXPECT().Delete(gomock.Any(), id).Return(false, assert.AnError)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
 			error: true,
 		},
+		{
+			name: "Dependents",
+			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
+				impact.EXPECT().Permission(gomock.Any(), id).Return(&models.Impact{
+					ResourceType: models.PermissionResourceType,
+					ResourceId:   id,
+					Roles:        []models.Role{{ID: uuid.MustParse("10000000-1000-1000-3000-000000000001"), Name: "admin"}},
+					Users:        []models.User{{ID: userId, IdentityNumber: "PNOEE-60001017869", FirstName: "EID2016", LastName: "TESTNUMBER"}},
+				}, nil)
+			},
+			expected: result{
+				conflict: serializers.DeleteConflictSerializer{
+					ProblemSerializer: serializers.ProblemSerializer{
+						Type:     serializers.ProblemDefaultType,
+						Title:    "Conflict",
+						Status:   http.StatusConflict,
+						Detail:   errors.ErrResourceHasDependents.Error(),
+						Instance: "/api/backoffice/permissions/" + id.String(),
+						Code:     "has_dependents",
+					},
+					Dependents: serializers.ImpactSerializer{
+						ResourceType: models.PermissionResourceType,
+						ResourceID:   id,
+						Roles:        []serializers.RoleReferenceSerializer{{ID: uuid.MustParse("10000000-1000-1000-3000-000000000001"), Name: "admin"}},
+						Users:        []serializers.UserReferenceSerializer{{ID: userId, IdentityNumber: "PNOEE-60001017869", FirstName: "EID2016", LastName: "TESTNUMBER"}},
+					},
+				},
+				status: "409 Conflict",
+				code:   http.StatusConflict,
+			},
+		},
+		{
+			name:  "Force",
+			query: "?force=true",
+			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
+				permissions.EXPECT().Delete(gomock.Any(), id).Return(true, nil)
+				audit.EXPECT().Record(gomock.Any(), models.DeleteActionType, models.PermissionResourceType, id, gomock.Any(), nil).Return(nil)
+			},
+			expected: result{
+				status: "204 No Content",
+				code:   http.StatusNoContent,
+			},
+		},
+		{
+			name:  "Dry run",
+			query: "?dry_run=true&force=true",
+			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
+				impact.EXPECT().Permission(gomock.Any(), id).Return(&models.Impact{
+					ResourceType: models.PermissionResourceType,
+					ResourceId:   id,
+					Users:        []models.User{{ID: userId, IdentityNumber: "PNOEE-60001017869", FirstName: "EID2016", LastName: "TESTNUMBER"}},
+				}, nil)
+			},
+			expected: result{
+				impact: serializers.ImpactSerializer{
+					ResourceType: models.PermissionResourceType,
+					ResourceID:   id,
+					Roles:        []serializers.RoleReferenceSerializer{},
+					Users:        []serializers.UserReferenceSerializer{{ID: userId, IdentityNumber: "PNOEE-60001017869", FirstName: "EID2016", LastName: "TESTNUMBER"}},
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name:  "Dry run not found",
+			query: "?dry_run=true",
+			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(nil, errors.ErrRecordNotFound)
+			},
+			expected: result{
+				detail: errors.ErrRecordNotFound.Error(),
+				status: "404 Not Found",
+				code:   http.StatusNotFound,
+			},
+			error: true,
+		},
+		{
+			name:   "Invalid force",
+			query:  "?force=maybe",
+			before: func() {},
+			expected: result{
+				detail: errors.ErrInvalidArguments.Error(),
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name: "Impact unavailable",
+			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
+				impact.EXPECT().Permission(gomock.Any(), id).Return(nil, errors.ErrFailedToFetchResults)
+			},
+			expected: result{
+				detail: errors.ErrFailedToFetchResults.Error(),
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
+			},
+			error: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.before()
 
-			req := httptest.NewRequest(http.MethodDelete, "/api/backoffice/permissions/10000000-1000-1000-3000-000000000001", nil)
+			req := httptest.NewRequest(http.MethodDelete, "/api/backoffice/permissions/10000000-1000-1000-3000-000000000001"+tt.query, nil)
 			w := httptest.NewRecorder()
 
 			r := chi.NewRouter()
@@ -701,11 +857,193 @@ func Test_Permissions_Delete(t *testing.T) {
 			resp := w.Result()
 			defer resp.Body.Close()
 
+			switch {
+			case tt.error:
+				var response serializers.ProblemSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
+			case tt.expected.code == http.StatusConflict:
+				var response serializers.DeleteConflictSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.conflict, response)
+			case tt.expected.code == http.StatusOK:
+				var response serializers.ImpactSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.impact, response)
+			}
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+			assert.Equal(t, tt.expected.status, resp.Status)
+		})
+	}
+}
+
+func Test_Permissions_Roles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	permissions := services.NewMockPermissions(ctrl)
+	index := services.NewMockIndex(ctrl)
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewPermissionsController(permissions, index, impact, audit, paginator, log)
+
+	id := uuid.MustParse("10000000-1000-1000-4000-000000000001")
+
+	type result struct {
+		response serializers.PaginationResponse[serializers.RoleSerializer]
+		detail   string
+		status   string
+		code     int
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		before   func()
+		expected result
+		error    bool
+	}{
+		{
+			name: "Success",
+			path: "/api/backoffice/permissions/10000000-1000-1000-4000-000000000001/roles",
+			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
+				index.EXPECT().RolesByPermission(gomock.Any(), id).Return([]models.Role{
+					{
+						ID:            uuid.MustParse("10000000-1000-1000-3000-000000000001"),
+						Name:          "admin",
+						Description:   "Admin role",
+						PermissionIDs: []uuid.UUID{id},
+					},
+				}, nil)
+			},
+			expected: result{
+				response: serializers.PaginationResponse[serializers.RoleSerializer]{
+					Data: []serializers.RoleSerializer{
+						{
+							ID:            uuid.MustParse("10000000-1000-1000-3000-000000000001"),
+							Name:          "admin",
+							Description:   "Admin role",
+							PermissionIDs: []uuid.UUID{id},
+						},
+					},
+					Meta: serializers.PaginationMeta{
+						Page:  1,
+						Per:   25,
+						Total: 1,
+					},
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+			error: false,
+		},
+		{
+			name: "Page beyond results",
+			path: "/api/backoffice/permissions/10000000-1000-1000-4000-000000000001/roles?page=2&per=1",
+			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
+				index.EXPECT().RolesByPermission(gomock.Any(), id).Return([]models.Role{
+					{
+						ID:            uuid.MustParse("10000000-1000-1000-3000-000000000001"),
+						Name:          "admin",
+						Description:   "Admin role",
+						PermissionIDs: []uuid.UUID{id},
+					},
+				}, nil)
+			},
+			expected: result{
+				response: serializers.PaginationResponse[serializers.RoleSerializer]{
+					Data: []serializers.RoleSerializer{},
+					Meta: serializers.PaginationMeta{
+						Page:  2,
+						Per:   1,
+						Total: 1,
+					},
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+			error: false,
+		},
+		{
+			name: "Not found",
+			path: "/api/backoffice/permissions/10000000-1000-1000-4000-000000000001/roles",
+			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(nil, errors.ErrRecordNotFound)
+			},
+			expected: result{
+				detail: errors.ErrRecordNotFound.Error(),
+				status: "404 Not Found",
+				code:   http.StatusNotFound,
+			},
+			error: true,
+		},
+		{
+			name: "Index unavailable",
+			path: "/api/backoffice/permissions/10000000-1000-1000-4000-000000000001/roles",
+			before: func() {
+				permissions.EXPECT().FindById(gomock.Any(), id).Return(&models.Permission{ID: id}, nil)
+				index.EXPECT().RolesByPermission(gomock.Any(), id).Return(nil, errors.ErrFailedToFetchResults)
+			},
+			expected: result{
+				detail: errors.ErrFailedToFetchResults.Error(),
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
+			},
+			error: true,
+		},
+		{
+			name:   "Invalid id",
+			path:   "/api/backoffice/permissions/invalid/roles",
+			before: func() {},
+			expected: result{
+				detail: errors.ErrInvalidArguments.Error(),
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Get("/api/backoffice/permissions/{id}/roles", controller.Roles)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
+			} else {
+				var response serializers.PaginationResponse[serializers.RoleSerializer]
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.response, response)
 			}
 
 			assert.Equal(t, tt.expected.code, resp.StatusCode)
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/models/dto"
+	"loki-backoffice/internal/app/serializers"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config/logger"
+)
+
+type RBACController interface {
+	Plan(w http.ResponseWriter, r *http.Request)
+	Apply(w http.ResponseWriter, r *http.Request)
+}
+
+type rbacController struct {
+	rbac services.RBAC
+	log  *logger.Logger
+}
+
+func NewRBACController(rbac services.RBAC, log *logger.Logger) RBACController {
+	return &rbacController{
+		rbac: rbac,
+		log:  log,
+	}
+}
+
+// Plan reports the changes applying a YAML or JSON configuration would make without making them,
+// deletes are only planned with prune and list the roles and users that depend on the deleted records
+func (c *rbacController) Plan(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var params dto.RBACDocument
+	if err := params.Validate(r.Body); err != nil {
+		c.log.Error().Err(err).Msg("Invalid RBAC configuration")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	options, err := services.NewRBACOptions(r)
+	if err != nil {
+		c.log.Error().Err(err).Msg("Invalid RBAC options")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	plan, err := c.rbac.Plan(r.Context(), params.Config(), options)
+	if err != nil {
+		c.log.Error().Err(err).Msg("Failed to plan RBAC configuration")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(rbacPlanSerializer(r, plan))
+}
+
+// Apply applies a YAML or JSON configuration, a failed change skips the remaining ones and answers 207.
+// A delete with dependents blocks the whole configuration unless forced
+func (c *rbacController) Apply(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var params dto.RBACDocument
+	if err := params.Validate(r.Body); err != nil {
+		c.log.Error().Err(err).Msg("Invalid RBAC configuration")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	options, err := services.NewRBACOptions(r)
+	if err != nil {
+		c.log.Error().Err(err).Msg("Invalid RBAC options")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	plan, err := c.rbac.Apply(r.Context(), params.Config(), options)
+	if err != nil {
+		c.log.Error().Err(err).Msg("Failed to apply RBAC configuration")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	response := rbacPlanSerializer(r, plan)
+
+	status := http.StatusOK
+	if response.Meta.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func rbacPlanSerializer(r *http.Request, plan *models.RBACPlan) serializers.RBACPlanSerializer {
+	response := serializers.RBACPlanSerializer{
+		Data: make([]serializers.RBACChangeSerializer, 0, len(plan.Changes)),
+		Meta: serializers.RBACPlanMetaSerializer{
+			Create: plan.Count(models.CreateActionType),
+			Update: plan.Count(models.UpdateActionType),
+			Delete: plan.Count(models.DeleteActionType),
+		},
+	}
+
+	for _, change := range plan.Changes {
+		item := serializers.RBACChangeSerializer{
+			Action:       change.Action,
+			ResourceType: change.ResourceType,
+			Name:         change.Name,
+			Fields:       change.Fields,
+			Before:       rbacResourceSerializer(change.Before),
+			After:        rbacResourceSerializer(change.After),
+			State:        change.State,
+		}
+
+		if change.Dependents != nil {
+			dependents := impactSerializer(change.Dependents)
+			item.Dependents = &dependents
+		}
+
+		if change.ID != uuid.Nil {
+			id := change.ID
+			item.ID = &id
+		}
+
+		if change.Err != nil {
+			problem := serializers.NewProblem(r, change.Err)
+			item.Error = &problem
+		}
+
+		switch change.State {
+		case services.BulkAppliedState:
+			response.Meta.Applied++
+		case services.BulkFailedState:
+			response.Meta.Failed++
+		case services.BulkSkippedState:
+			response.Meta.Skipped++
+		}
+
+		response.Data = append(response.Data, item)
+	}
+
+	return response
+}
+
+func rbacResourceSerializer(resource *models.RBACResource) *serializers.RBACResourceSerializer {
+	if resource == nil {
+		return nil
+	}
+
+	return &serializers.RBACResourceSerializer{
+		Name:        resource.Name,
+		Description: resource.Description,
+		Permissions: resource.Permissions,
+	}
+}
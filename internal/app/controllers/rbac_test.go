@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/serializers"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func Test_RBAC_Apply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	rbac := services.NewMockRBAC(ctrl)
+	controller := NewRBACController(rbac, log)
+
+	permissionId := uuid.MustParse("10000000-1000-1000-4000-000000000001")
+	roleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	userId := uuid.MustParse("10000000-1000-1000-1000-000000000001")
+
+	body := `
+permissions:
+  - name: read:users
+    description: Read users
+roles:
+  - name: admin
+    description: Administrator
+    permissions: [read:users]
+`
+
+	type result struct {
+		meta serializers.RBACPlanMetaSerializer
+		code int
+	}
+
+	tests := []struct {
+		name     string
+		before   func()
+		query    string
+		body     string
+		expected result
+	}{
+		{
+			name: "Success",
+			before: func() {
+				rbac.EXPECT().Apply(gomock.Any(), &models.RBACConfig{
+					Permissions: []models.RBACResource{{Name: "read:users", Description: "Read users"}},
+					Roles:       []models.RBACResource{{Name: "admin", Description: "Administrator", Permissions: []string{"read:users"}}},
+				}, &services.RBACOptions{Prune: true}).Return(&models.RBACPlan{Changes: []models.RBACChange{
+					{Action: models.CreateActionType, ResourceType: models.PermissionResourceType, ID: permissionId, Name: "read:users", State: services.BulkAppliedState},
+					{Action: models.CreateActionType, ResourceType: models.RoleResourceType, ID: roleId, Name: "admin", State: services.BulkAppliedState},
+				}}, nil)
+			},
+			query: "?prune=true",
+			body:  body,
+			expected: result{
+				meta: serializers.RBACPlanMetaSerializer{Create: 2, Applied: 2},
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "Partially applied",
+			before: func() {
+				rbac.EXPECT().Apply(gomock.Any(), gomock.Any(), &services.RBACOptions{Prune: true, Force: true}).Return(&models.RBACPlan{Changes: []models.RBACChange{
+					{Action: models.CreateActionType, ResourceType: models.PermissionResourceType, ID: permissionId, Name: "read:users", State: services.BulkAppliedState},
+					{Action: models.DeleteActionType, ResourceType: models.RoleResourceType, ID: roleId, Name: "guest", State: services.BulkFailedState, Err: errors.ErrUnavailable},
+					{Action: models.DeleteActionType, ResourceType: models.ScopeResourceType, Name: "legacy", State: services.BulkSkippedState},
+				}}, nil)
+			},
+			query: "?prune=true&force=true",
+			body:  body,
+			expected: result{
+				meta: serializers.RBACPlanMetaSerializer{Create: 1, Delete: 2, Applied: 1, Failed: 1, Skipped: 1},
+				code: http.StatusMultiStatus,
+			},
+		},
+		{
+			name: "Blocked by dependents",
+			before: func() {
+				rbac.EXPECT().Apply(gomock.Any(), gomock.Any(), &services.RBACOptions{Prune: true}).Return(&models.RBACPlan{Changes: []models.RBACChange{
+					{Action: models.CreateActionType, ResourceType: models.PermissionResourceType, Name: "read:users", State: services.BulkSkippedState},
+					{
+						Action:       models.DeleteActionType,
+						ResourceType: models.RoleResourceType,
+						ID:           roleId,
+						Name:         "guest",
+						Dependents:   &models.Impact{ResourceType: models.RoleResourceType, ResourceId: roleId, Users: []models.User{{ID: userId}}},
+						State:        services.BulkFailedState,
+						Err:          services.DependentsError(&models.Impact{Users: []models.User{{ID: userId}}}),
+					},
+				}}, nil)
+			},
+			query: "?prune=true",
+			body:  body,
+			expected: result{
+				meta: serializers.RBACPlanMetaSerializer{Create: 1, Delete: 1, Failed: 1, Skipped: 1},
+				code: http.StatusMultiStatus,
+			},
+		},
+		{
+			name: "Invalid configuration",
+			before: func() {
+				rbac.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			body:     "permissions: []\nroles:\n  - name: admin\n    description: Administrator\n    permissions: [read:users]\n",
+			expected: result{code: http.StatusBadRequest},
+		},
+		{
+			name: "Invalid prune",
+			before: func() {
+				rbac.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			query:    "?prune=maybe",
+			body:     body,
+			expected: result{code: http.StatusBadRequest},
+		},
+		{
+			name: "Upstream error",
+			before: func() {
+				rbac.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.ErrUnavailable)
+			},
+			body:     body,
+			expected: result{code: http.StatusServiceUnavailable},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/backoffice/rbac/apply"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/yaml")
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Post("/api/backoffice/rbac/apply", controller.Apply)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+
+			if tt.expected.code == http.StatusOK || tt.expected.code == http.StatusMultiStatus {
+				var response serializers.RBACPlanSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.meta, response.Meta)
+			}
+		})
+	}
+}
+
+func Test_RBAC_Plan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	rbac := services.NewMockRBAC(ctrl)
+	controller := NewRBACController(rbac, log)
+
+	scopeId := uuid.MustParse("10000000-1000-1000-2000-000000000001")
+
+	rbac.EXPECT().Plan(gomock.Any(), &models.RBACConfig{
+		Scopes: []models.RBACResource{{Name: "sso-service", Description: "SSO"}},
+	}, &services.RBACOptions{}).Return(&models.RBACPlan{Changes: []models.RBACChange{
+		{
+			Action:       models.UpdateActionType,
+			ResourceType: models.ScopeResourceType,
+			ID:           scopeId,
+			Name:         "sso-service",
+			Fields:       []string{services.DescriptionField},
+			Before:       &models.RBACResource{Name: "sso-service", Description: "SSO service"},
+			After:        &models.RBACResource{Name: "sso-service", Description: "SSO"},
+		},
+	}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/backoffice/rbac/plan", strings.NewReader(`{"scopes": [{"name": "sso-service", "description": "SSO"}]}`))
+	w := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Post("/api/backoffice/rbac/plan", controller.Plan)
+	r.ServeHTTP(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	var response serializers.RBACPlanSerializer
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, serializers.RBACPlanSerializer{
+		Data: []serializers.RBACChangeSerializer{
+			{
+				Action:       models.UpdateActionType,
+				ResourceType: models.ScopeResourceType,
+				ID:           &scopeId,
+				Name:         "sso-service",
+				Fields:       []string{services.DescriptionField},
+				Before:       &serializers.RBACResourceSerializer{Name: "sso-service", Description: "SSO service"},
+				After:        &serializers.RBACResourceSerializer{Name: "sso-service", Description: "SSO"},
+			},
+		},
+		Meta: serializers.RBACPlanMetaSerializer{Update: 1},
+	}, response)
+}
@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 
@@ -20,18 +22,29 @@ type RolesController interface {
 	Get(w http.ResponseWriter, r *http.Request)
 	Create(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
+	Patch(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Bulk(w http.ResponseWriter, r *http.Request)
+	Users(w http.ResponseWriter, r *http.Request)
 }
 
 type rolesController struct {
-	roles services.Roles
-	log   *logger.Logger
+	roles     services.Roles
+	index     services.Index
+	impact    services.ImpactAnalyzer
+	audit     services.Audit
+	paginator services.Paginator
+	log       *logger.Logger
 }
 
-func NewRolesController(roles services.Roles, log *logger.Logger) RolesController {
+func NewRolesController(roles services.Roles, index services.Index, impact services.ImpactAnalyzer, audit services.Audit, paginator services.Paginator, log *logger.Logger) RolesController {
 	return &rolesController{
-		roles: roles,
-		log:   log,
+		roles:     roles,
+		index:     index,
+		impact:    impact,
+		audit:     audit,
+		paginator: paginator,
+		log:       log,
 	}
 }
 
@@ -39,19 +52,23 @@ func NewRolesController(roles services.Roles, log *logger.Logger) RolesControlle
 func (c *rolesController) List(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	pagination := services.NewPagination(r)
-	rows, total, err := c.roles.List(r.Context(), pagination)
+	query, err := services.NewQuery(r, services.RolesQuerySchema)
 	if err != nil {
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrFailedToFetchResults):
-			w.WriteHeader(http.StatusServiceUnavailable)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		c.log.Error().Err(err).Str("query", r.URL.RawQuery).Msg("Invalid list query")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	pagination, err := c.paginator.Paginate(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("cursor", r.URL.Query().Get(services.CursorParam)).Msg("Invalid pagination cursor")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
 
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+	rows, total, err := c.roles.List(r.Context(), pagination, query)
+	if err != nil {
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -71,6 +88,8 @@ func (c *rolesController) List(w http.ResponseWriter, r *http.Request) {
 			Page:  pagination.Page,
 			Per:   pagination.PerPage,
 			Total: total,
+			Next:  c.paginator.Next(r, pagination),
+			Prev:  c.paginator.Prev(r, pagination),
 		},
 	}
 
@@ -85,8 +104,7 @@ func (c *rolesController) Get(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
 		return
 	}
 
@@ -95,14 +113,7 @@ func (c *rolesController) Get(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get role")
 
-		switch {
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -113,6 +124,13 @@ func (c *rolesController) Get(w http.ResponseWriter, r *http.Request) {
 		PermissionIDs: record.PermissionIDs,
 	}
 
+	etag := serializers.ETag(response)
+	w.Header().Set(ETagHeader, etag)
+	if ifNoneMatch(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -124,8 +142,7 @@ func (c *rolesController) Create(w http.ResponseWriter, r *http.Request) {
 	var params dto.RoleRequest
 	if err := params.Validate(r.Body); err != nil {
 		c.log.Error().Err(err).Str("name", params.Name).Msg("Failed to create role")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -137,16 +154,7 @@ func (c *rolesController) Create(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Msg("Failed to create role")
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrFailedToCreateRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -156,6 +164,9 @@ func (c *rolesController) Create(w http.ResponseWriter, r *http.Request) {
 		Description: record.Description,
 	}
 
+	c.index.Invalidate()
+	services.RecordApplied(r.Context(), c.audit, c.log, models.CreateActionType, models.RoleResourceType, record.ID, nil, record.AuditSnapshot())
+
 	w.WriteHeader(http.StatusCreated)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -167,19 +178,74 @@ func (c *rolesController) Update(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
 		return
 	}
 
 	var params dto.RoleRequest
 	if err = params.Validate(r.Body); err != nil {
 		c.log.Error().Err(err).Str("name", params.Name).Msg("Failed to create role")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	before, err := c.roles.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Failed to load role state for audit")
+		before = nil
+	}
+
+	if err = ifMatch(r, roleSerializer(before), err); err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Rejected conditional role write")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.update(w, r, id, &params, before)
+}
+
+// Patch applies a merge patch or JSON patch document to the role
+//
+//nolint:dupl
+func (c *rolesController) Patch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	before, err := c.roles.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to find role")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	if err = ifMatch(r, roleSerializer(before), nil); err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Rejected conditional role write")
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
+	params := dto.RoleRequest{
+		Name:          before.Name,
+		Description:   before.Description,
+		PermissionIDs: before.PermissionIDs,
+	}
+	if err = params.Patch(r.Header.Get("Content-Type"), http.MaxBytesReader(w, r.Body, dto.MaxPatchBodySize)); err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to patch role")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.update(w, r, id, &params, before)
+}
+
+// update stores params over the role, before is the state recorded in the audit log
+func (c *rolesController) update(w http.ResponseWriter, r *http.Request, id uuid.UUID, params *dto.RoleRequest, before *models.Role) {
 	record, err := c.roles.Update(r.Context(), &models.Role{
 		ID:            id,
 		Name:          params.Name,
@@ -189,18 +255,7 @@ func (c *rolesController) Update(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to update role")
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		case errors.Is(err, errors.ErrFailedToUpdateRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -210,6 +265,9 @@ func (c *rolesController) Update(w http.ResponseWriter, r *http.Request) {
 		Description: record.Description,
 	}
 
+	c.index.Invalidate()
+	services.RecordApplied(r.Context(), c.audit, c.log, models.UpdateActionType, models.RoleResourceType, id, before.AuditSnapshot(), record.AuditSnapshot())
+
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -221,29 +279,313 @@ func (c *rolesController) Delete(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	options, err := services.NewDeleteOptions(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid delete options")
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
+	before, err := c.roles.FindById(r.Context(), id)
+	if err != nil {
+		if options.DryRun && errors.Is(err, errors.ErrRecordNotFound) {
+			serializers.WriteProblem(w, r, err)
+			return
+		}
+
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Failed to load role state for audit")
+		before = nil
+	}
+
+	if err = ifMatch(r, roleSerializer(before), err); err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Rejected conditional role write")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	if options.Checked() {
+		impact, err := c.impact.Role(r.Context(), id)
+		if err != nil {
+			c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to analyze role delete impact")
+
+			serializers.WriteProblem(w, r, err)
+			return
+		}
+
+		if options.DryRun {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(impactSerializer(impact))
+			return
+		}
+
+		if !impact.IsEmpty() {
+			c.log.Warn().Str("id", id.String()).Int("roles", len(impact.Roles)).Int("users", len(impact.Users)).Msg("Refused to delete role with dependents")
+			w.Header().Set("Content-Type", serializers.ProblemContentType)
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(serializers.DeleteConflictSerializer{
+				ProblemSerializer: serializers.NewProblem(r, errors.ErrResourceHasDependents),
+				Dependents:        impactSerializer(impact),
+			})
+			return
+		}
+	}
+
 	_, err = c.roles.Delete(r.Context(), id)
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to delete role")
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		case errors.Is(err, errors.ErrFailedToDeleteRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.index.Invalidate()
+	services.RecordApplied(r.Context(), c.audit, c.log, models.DeleteActionType, models.RoleResourceType, id, before.AuditSnapshot(), nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Bulk creates, updates and deletes roles in one request and reports the outcome of every operation
+//
+//nolint:dupl
+func (c *rolesController) Bulk(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	options, err := services.NewBulkOptions(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("query", r.URL.RawQuery).Msg("Invalid bulk options")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	var params dto.BulkRequest
+	if err = params.Validate(r.Body); err != nil {
+		c.log.Error().Err(err).Msg("Invalid bulk role request")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	operations := make([]services.BulkOperation, 0, len(params))
+	for _, item := range params {
+		operations = append(operations, c.bulkOperation(item, options))
+	}
+
+	results := services.RunBulk(r.Context(), operations, options)
+	c.index.Invalidate()
+
+	writeBulk(w, r, results, options)
+}
+
+// bulkOperation binds an operation of a bulk request to the roles service, every applied
+// operation is audited and knows how to undo itself for atomic requests
+//
+//nolint:dupl
+func (c *rolesController) bulkOperation(item dto.BulkOperationRequest, options *services.BulkOptions) services.BulkOperation {
+	operation := services.BulkOperation{Op: item.Op, ID: item.ID}
+
+	var params dto.RoleRequest
+	if item.Op != models.DeleteActionType {
+		if operation.Err = params.Validate(bytes.NewReader(item.Data)); operation.Err != nil {
+			return operation
 		}
+	}
+
+	create := func(ctx context.Context, params *models.Role) (*models.Role, error) {
+		record, err := c.roles.Create(ctx, params)
+		if err != nil {
+			c.log.Error().Err(err).Str("name", params.Name).Msg("Failed to create role")
+			return nil, err
+		}
+
+		services.RecordApplied(ctx, c.audit, c.log, models.CreateActionType, models.RoleResourceType, record.ID, nil, record.AuditSnapshot())
+		return record, nil
+	}
+
+	update := func(ctx context.Context, params, before *models.Role) (*models.Role, error) {
+		record, err := c.roles.Update(ctx, params)
+		if err != nil {
+			c.log.Error().Err(err).Str("id", params.ID.String()).Msg("Failed to update role")
+			return nil, err
+		}
+
+		services.RecordApplied(ctx, c.audit, c.log, models.UpdateActionType, models.RoleResourceType, params.ID, before.AuditSnapshot(), record.AuditSnapshot())
+		return record, nil
+	}
+
+	remove := func(ctx context.Context, before *models.Role) error {
+		if _, err := c.roles.Delete(ctx, before.ID); err != nil {
+			c.log.Error().Err(err).Str("id", before.ID.String()).Msg("Failed to delete role")
+			return err
+		}
+
+		services.RecordApplied(ctx, c.audit, c.log, models.DeleteActionType, models.RoleResourceType, before.ID, before.AuditSnapshot(), nil)
+		return nil
+	}
+
+	switch item.Op {
+	case models.CreateActionType:
+		operation.Apply = func(ctx context.Context) (*services.BulkApplied, error) {
+			record, err := create(ctx, &models.Role{
+				Name:          params.Name,
+				Description:   params.Description,
+				PermissionIDs: params.PermissionIDs,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return &services.BulkApplied{
+				ID:     record.ID,
+				Result: roleSerializer(record),
+				Undo: func(ctx context.Context) error {
+					return remove(ctx, record)
+				},
+			}, nil
+		}
+	case models.UpdateActionType:
+		operation.Apply = func(ctx context.Context) (*services.BulkApplied, error) {
+			before, err := c.roles.FindById(ctx, item.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			record, err := update(ctx, &models.Role{
+				ID:            item.ID,
+				Name:          params.Name,
+				Description:   params.Description,
+				PermissionIDs: params.PermissionIDs,
+			}, before)
+			if err != nil {
+				return nil, err
+			}
+
+			return &services.BulkApplied{
+				ID:     record.ID,
+				Result: roleSerializer(record),
+				Undo: func(ctx context.Context) error {
+					_, err := update(ctx, before, record)
+					return err
+				},
+			}, nil
+		}
+	case models.DeleteActionType:
+		operation.Apply = func(ctx context.Context) (*services.BulkApplied, error) {
+			before, err := c.roles.FindById(ctx, item.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			if !options.Force {
+				impact, err := c.impact.Role(ctx, item.ID)
+				if err != nil {
+					return nil, err
+				}
+				if !impact.IsEmpty() {
+					return nil, services.DependentsError(impact)
+				}
+			}
+
+			if err = remove(ctx, before); err != nil {
+				return nil, err
+			}
+
+			// upstream assigns ids on create, a restored role comes back under a new id
+			return &services.BulkApplied{
+				ID: item.ID,
+				Undo: func(ctx context.Context) error {
+					_, err := create(ctx, &models.Role{
+						Name:          before.Name,
+						Description:   before.Description,
+						PermissionIDs: before.PermissionIDs,
+					})
+					return err
+				},
+			}, nil
+		}
+	}
+
+	return operation
+}
+
+// Users lists the users holding the role
+//
+//nolint:dupl
+func (c *rolesController) Users(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
 
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	pagination, err := c.paginator.Paginate(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("cursor", r.URL.Query().Get(services.CursorParam)).Msg("Invalid pagination cursor")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	if _, err = c.roles.FindById(r.Context(), id); err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get role")
+
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	rows, err := c.index.UsersByRole(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to look up users holding the role")
+
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	total := uint64(len(rows))
+	page := services.PageOf(rows, pagination, func(user models.User) uuid.UUID { return user.ID })
+	collection := make([]serializers.UserSerializer, 0, len(page))
+
+	for _, user := range page {
+		collection = append(collection, serializers.UserSerializer{
+			ID:             user.ID,
+			IdentityNumber: user.IdentityNumber,
+			PersonalCode:   user.PersonalCode,
+			FirstName:      user.FirstName,
+			LastName:       user.LastName,
+			RoleIDs:        user.RoleIDs,
+			ScopeIDs:       user.ScopeIDs,
+		})
+	}
+
+	response := serializers.PaginationResponse[serializers.UserSerializer]{
+		Data: collection,
+		Meta: serializers.PaginationMeta{
+			Page:  pagination.Page,
+			Per:   pagination.PerPage,
+			Total: total,
+			Next:  c.paginator.Next(r, pagination),
+			Prev:  c.paginator.Prev(r, pagination),
+		},
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// roleSerializer is the response shape of a role, nil when there is none, so that If-Match compares against the ETag of Show
+func roleSerializer(record *models.Role) interface{} {
+	if record == nil {
+		return nil
+	}
+
+	return serializers.RoleSerializer{
+		ID:            record.ID,
+		Name:          record.Name,
+		Description:   record.Description,
+		PermissionIDs: record.PermissionIDs,
+	}
 }
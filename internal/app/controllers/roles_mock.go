@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/app/controllers/roles.go
+// Source: roles.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/app/controllers/roles.go -destination=internal/app/controllers/roles_mock.go -package=controllers
+//	mockgen -source=roles.go -destination=roles_mock.go -package=controllers
 //
 
 // Package controllers is a generated GoMock package.
@@ -40,6 +40,18 @@ func (m *MockRolesController) EXPECT() *MockRolesControllerMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockRolesController) Bulk(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Bulk", w, r)
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockRolesControllerMockRecorder) Bulk(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockRolesController)(nil).Bulk), w, r)
+}
+
 // Create mocks base method.
 func (m *MockRolesController) Create(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -88,6 +100,18 @@ func (mr *MockRolesControllerMockRecorder) List(w, r any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRolesController)(nil).List), w, r)
 }
 
+// Patch mocks base method.
+func (m *MockRolesController) Patch(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Patch", w, r)
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockRolesControllerMockRecorder) Patch(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockRolesController)(nil).Patch), w, r)
+}
+
 // Update mocks base method.
 func (m *MockRolesController) Update(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -99,3 +123,15 @@ func (mr *MockRolesControllerMockRecorder) Update(w, r any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRolesController)(nil).Update), w, r)
 }
+
+// Users mocks base method.
+func (m *MockRolesController) Users(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Users", w, r)
+}
+
+// Users indicates an expected call of Users.
+func (mr *MockRolesControllerMockRecorder) Users(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockRolesController)(nil).Users), w, r)
+}
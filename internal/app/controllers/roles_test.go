@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -33,11 +34,16 @@ func Test_Roles_List(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	roles := services.NewMockRoles(ctrl)
-	controller := NewRolesController(roles, log)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewRolesController(roles, index, impact, audit, paginator, log)
 
 	type result struct {
 		response serializers.PaginationResponse[serializers.RoleSerializer]
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
@@ -51,7 +57,7 @@ func Test_Roles_List(t *testing.T) {
 		{
 			name: "Success",
 			before: func() {
-				roles.EXPECT().List(gomock.Any(), gomock.Any()).Return([]models.Role{
+				roles.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Role{
 					{
 						ID:          uuid.MustParse("10000000-1000-1000-1000-000000000001"),
 						Name:        "admin",
@@ -102,7 +108,7 @@ func Test_Roles_List(t *testing.T) {
 		{
 			name: "Empty",
 			before: func() {
-				roles.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), nil)
+				roles.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), nil)
 			},
 			expected: result{
 				response: serializers.PaginationResponse[serializers.RoleSerializer]{
@@ -121,10 +127,10 @@ func Test_Roles_List(t *testing.T) {
 		{
 			name: "Invalid params",
 			before: func() {
-				roles.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrInvalidArguments)
+				roles.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrInvalidArguments)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -133,22 +139,22 @@ func Test_Roles_List(t *testing.T) {
 		{
 			name: "Bad request",
 			before: func() {
-				roles.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrFailedToFetchResults)
+				roles.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrFailedToFetchResults)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to fetch results"},
-				status: "503 Service Unavailable",
-				code:   http.StatusServiceUnavailable,
+				detail: "failed to fetch results",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 			error: true,
 		},
 		{
 			name: "Error",
 			before: func() {
-				roles.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), assert.AnError)
+				roles.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), assert.AnError)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -171,10 +177,11 @@ func Test_Roles_List(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.PaginationResponse[serializers.RoleSerializer]
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -200,13 +207,18 @@ func Test_Roles_Get(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	roles := services.NewMockRoles(ctrl)
-	controller := NewRolesController(roles, log)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewRolesController(roles, index, impact, audit, paginator, log)
 
 	id := uuid.MustParse("10000000-1000-1000-1000-000000000001")
 
 	type result struct {
 		response serializers.RoleSerializer
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
@@ -243,7 +255,7 @@ func Test_Roles_Get(t *testing.T) {
 				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{}, errors.ErrRecordNotFound)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: errors.ErrRecordNotFound.Error()},
+				detail: errors.ErrRecordNotFound.Error(),
 				status: "404 Not Found",
 				code:   http.StatusNotFound,
 			},
@@ -254,7 +266,7 @@ func Test_Roles_Get(t *testing.T) {
 				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{}, assert.AnError)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -277,10 +289,11 @@ func Test_Roles_Get(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.RoleSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -306,13 +319,18 @@ func Test_Roles_Create(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	roles := services.NewMockRoles(ctrl)
-	controller := NewRolesController(roles, log)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewRolesController(roles, index, impact, audit, paginator, log)
 
 	id := uuid.MustParse("10000000-1000-1000-1000-000000000001")
 
 	type result struct {
 		response serializers.RoleSerializer
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
@@ -335,6 +353,7 @@ func Test_Roles_Create(t *testing.T) {
 					Name:        "admin",
 					Description: "Admin role",
 				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.CreateActionType, models.RoleResourceType, id, nil, gomock.Any()).Return(nil)
 			},
 			body: strings.NewReader(`{"name": "admin", "description" :"Admin role"}`),
 			expected: result{
@@ -355,7 +374,7 @@ func Test_Roles_Create(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "admin"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "empty description"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -371,7 +390,7 @@ func Test_Roles_Create(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "admin", "description": "Admin role"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -386,9 +405,9 @@ func Test_Roles_Create(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "admin", "description": "Admin role"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to create record"},
-				status: "422 Unprocessable Entity",
-				code:   http.StatusUnprocessableEntity,
+				detail: "failed to create record",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 			error: true,
 		},
@@ -402,7 +421,7 @@ func Test_Roles_Create(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "admin", "description": "Admin role"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -425,10 +444,11 @@ func Test_Roles_Create(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.RoleSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -454,13 +474,18 @@ func Test_Roles_Update(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	roles := services.NewMockRoles(ctrl)
-	controller := NewRolesController(roles, log)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewRolesController(roles, index, impact, audit, paginator, log)
 
 	id := uuid.MustParse("10000000-1000-1000-1000-000000000001")
 
 	type result struct {
 		response serializers.RoleSerializer
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
@@ -475,6 +500,7 @@ func Test_Roles_Update(t *testing.T) {
 		{
 			name: "Success",
 			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
 				roles.EXPECT().Update(gomock.Any(), &models.Role{
 					ID:          id,
 					Name:        "admin",
@@ -484,6 +510,7 @@ func Test_Roles_Update(t *testing.T) {
 					Name:        "admin",
 					Description: "Admin role",
 				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.RoleResourceType, id, gomock.Any(), gomock.Any()).Return(nil)
 			},
 			body: strings.NewReader(`{"name": "admin", "description": "Admin role"}`),
 			expected: result{
@@ -504,7 +531,7 @@ func Test_Roles_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "admin"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "empty description"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -513,6 +540,7 @@ func Test_Roles_Update(t *testing.T) {
 		{
 			name: "Invalid arguments",
 			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
 				roles.EXPECT().Update(gomock.Any(), &models.Role{
 					ID:          id,
 					Name:        "admin",
@@ -521,7 +549,7 @@ func Test_Roles_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "admin", "description": "Admin role"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -530,6 +558,7 @@ func Test_Roles_Update(t *testing.T) {
 		{
 			name: "Not found",
 			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
 				roles.EXPECT().Update(gomock.Any(), &models.Role{
 					ID:          id,
 					Name:        "admin",
@@ -538,7 +567,7 @@ func Test_Roles_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "admin", "description": "Admin role"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: errors.ErrRecordNotFound.Error()},
+				detail: errors.ErrRecordNotFound.Error(),
 				status: "404 Not Found",
 				code:   http.StatusNotFound,
 			},
@@ -546,6 +575,7 @@ func Test_Roles_Update(t *testing.T) {
 		{
 			name: "Bad request",
 			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
 				roles.EXPECT().Update(gomock.Any(), &models.Role{
 					ID:          id,
 					Name:        "admin",
@@ -554,14 +584,15 @@ func Test_Roles_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "admin", "description": "Admin role"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to update record"},
-				status: "422 Unprocessable Entity",
-				code:   http.StatusUnprocessableEntity,
+				detail: "failed to update record",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 		},
 		{
 			name: "Error",
 			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
 				roles.EXPECT().Update(gomock.Any(), &models.Role{
 					ID:          id,
 					Name:        "admin",
@@ -570,7 +601,7 @@ func Test_Roles_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"name": "admin", "description": "Admin role"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -593,10 +624,262 @@ func Test_Roles_Update(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
+			} else {
+				var response serializers.RoleSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.response, response)
+			}
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+			assert.Equal(t, tt.expected.status, resp.Status)
+		})
+	}
+}
+
+func Test_Roles_Patch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	roles := services.NewMockRoles(ctrl)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewRolesController(roles, index, impact, audit, paginator, log)
+
+	id := uuid.MustParse("10000000-1000-1000-1000-000000000001")
+	readId := uuid.MustParse("10000000-1000-1000-4000-000000000001")
+	writeId := uuid.MustParse("10000000-1000-1000-4000-000000000002")
+
+	current := &models.Role{
+		ID:            id,
+		Name:          "admin",
+		Description:   "Admin role",
+		PermissionIDs: []uuid.UUID{readId},
+	}
+
+	type result struct {
+		response serializers.RoleSerializer
+		detail   string
+		status   string
+		code     int
+	}
+
+	tests := []struct {
+		name        string
+		before      func()
+		contentType string
+		body        io.Reader
+		expected    result
+		error       bool
+	}{
+		{
+			name: "Merge patch",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(current, nil)
+				roles.EXPECT().Update(gomock.Any(), &models.Role{
+					ID:            id,
+					Name:          "admin",
+					Description:   "Administrators",
+					PermissionIDs: []uuid.UUID{readId},
+				}).Return(&models.Role{
+					ID:          id,
+					Name:        "admin",
+					Description: "Administrators",
+				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.RoleResourceType, id, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			contentType: "application/merge-patch+json",
+			body:        strings.NewReader(`{"description": "Administrators"}`),
+			expected: result{
+				response: serializers.RoleSerializer{
+					ID:          id,
+					Name:        "admin",
+					Description: "Administrators",
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name: "Merge patch clears permissions",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(current, nil)
+				roles.EXPECT().Update(gomock.Any(), &models.Role{
+					ID:          id,
+					Name:        "admin",
+					Description: "Admin role",
+				}).Return(&models.Role{
+					ID:          id,
+					Name:        "admin",
+					Description: "Admin role",
+				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.RoleResourceType, id, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			contentType: "application/merge-patch+json",
+			body:        strings.NewReader(`{"permission_ids": null}`),
+			expected: result{
+				response: serializers.RoleSerializer{
+					ID:          id,
+					Name:        "admin",
+					Description: "Admin role",
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name: "JSON patch adds permission",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(current, nil)
+				roles.EXPECT().Update(gomock.Any(), &models.Role{
+					ID:            id,
+					Name:          "admin",
+					Description:   "Admin role",
+					PermissionIDs: []uuid.UUID{readId, writeId},
+				}).Return(&models.Role{
+					ID:          id,
+					Name:        "admin",
+					Description: "Admin role",
+				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.RoleResourceType, id, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			contentType: "application/json-patch+json",
+			body:        strings.NewReader(`[{"op": "add", "path": "/permission_ids/-", "value": "` + writeId.String() + `"}]`),
+			expected: result{
+				response: serializers.RoleSerializer{
+					ID:          id,
+					Name:        "admin",
+					Description: "Admin role",
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name: "JSON patch removes permission",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(current, nil)
+				roles.EXPECT().Update(gomock.Any(), &models.Role{
+					ID:            id,
+					Name:          "admin",
+					Description:   "Admin role",
+					PermissionIDs: []uuid.UUID{},
+				}).Return(&models.Role{
+					ID:          id,
+					Name:        "admin",
+					Description: "Admin role",
+				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.RoleResourceType, id, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			contentType: "application/json-patch+json",
+			body:        strings.NewReader(`[{"op": "remove", "path": "/permission_ids/0"}]`),
+			expected: result{
+				response: serializers.RoleSerializer{
+					ID:          id,
+					Name:        "admin",
+					Description: "Admin role",
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name: "Invalid operation",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(current, nil)
+				roles.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+			},
+			contentType: "application/json-patch+json",
+			body:        strings.NewReader(`[{"op": "remove", "path": "/permission_ids/5"}]`),
+			expected: result{
+				detail: "invalid arguments",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name: "Blank name",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(current, nil)
+				roles.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+			},
+			contentType: "application/merge-patch+json",
+			body:        strings.NewReader(`{"name": ""}`),
+			expected: result{
+				detail: "invalid arguments",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name: "Unsupported media type",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(current, nil)
+				roles.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+			},
+			contentType: "application/json",
+			body:        strings.NewReader(`{"name": "admin"}`),
+			expected: result{
+				detail: errors.ErrUnsupportedMediaType.Error(),
+				status: "415 Unsupported Media Type",
+				code:   http.StatusUnsupportedMediaType,
+			},
+			error: true,
+		},
+		{
+			name: "Not found",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(nil, errors.ErrRecordNotFound)
+			},
+			contentType: "application/merge-patch+json",
+			body:        strings.NewReader(`{"description": "Administrators"}`),
+			expected: result{
+				detail: errors.ErrRecordNotFound.Error(),
+				status: "404 Not Found",
+				code:   http.StatusNotFound,
+			},
+			error: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/backoffice/roles/10000000-1000-1000-1000-000000000001", tt.body)
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Patch("/api/backoffice/roles/{id}", controller.Patch)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			if tt.error {
+				var response serializers.ProblemSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.RoleSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -622,18 +905,28 @@ func Test_Roles_Delete(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	roles := services.NewMockRoles(ctrl)
-	controller := NewRolesController(roles, log)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewRolesController(roles, index, impact, audit, paginator, log)
 
 	id := uuid.MustParse("10000000-1000-1000-1000-000000000001")
 
+	userId := uuid.MustParse("10000000-1000-1000-1234-000000000001")
+
 	type result struct {
-		error  serializers.ErrorSerializer
-		status string
-		code   int
+		impact   serializers.ImpactSerializer
+		conflict serializers.DeleteConflictSerializer
+		detail   string
+		status   string
+		code     int
 	}
 
 	tests := []struct {
 		name     string
+		query    string
 		before   func()
 		expected result
 		error    bool
@@ -641,7 +934,10 @@ func Test_Roles_Delete(t *testing.T) {
 		{
 			name: "Success",
 			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
+				impact.EXPECT().Role(gomock.Any(), id).Return(&models.Impact{}, nil)
 				roles.EXPECT().Delete(gomock.Any(), id).Return(true, nil)
+				audit.EXPECT().Record(gomock.Any(), models.DeleteActionType, models.RoleResourceType, id, gomock.Any(), nil).Return(nil)
 			},
 			expected: result{
 				status: "204 No Content",
@@ -652,10 +948,12 @@ func Test_Roles_Delete(t *testing.T) {
 		{
 			name: "Invalid arguments",
 			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
+				impact.EXPECT().Role(gomock.Any(), id).Return(&models.Impact{}, nil)
 				roles.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(false, errors.ErrInvalidArguments)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -664,10 +962,12 @@ func Test_Roles_Delete(t *testing.T) {
 		{
 			name: "Not found",
 			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
+				impact.EXPECT().Role(gomock.Any(), id).Return(&models.Impact{}, nil)
 				roles.EXPECT().Delete(gomock.Any(), id).Return(false, errors.ErrRecordNotFound)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: errors.ErrRecordNotFound.Error()},
+				detail: errors.ErrRecordNotFound.Error(),
 				status: "404 Not Found",
 				code:   http.StatusNotFound,
 			},
@@ -675,33 +975,140 @@ func Test_Roles_Delete(t *testing.T) {
 		{
 			name: "Bad request",
 			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
+				impact.EXPECT().Role(gomock.Any(), id).Return(&models.Impact{}, nil)
 				roles.EXPECT().Delete(gomock.Any(), id).Return(false, errors.ErrFailedToDeleteRecord)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to delete record"},
-				status: "422 Unprocessable Entity",
-				code:   http.StatusUnprocessableEntity,
+				detail: "failed to delete record",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 		},
 		{
 			name: "Error",
 			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
+				impact.EXPECT().Role(gomock.Any(), id).Return(&models.Impact{}, nil)
 				roles.EXPECT().Delete(gomock.Any(), id).Return(false, assert.AnError)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
 			error: true,
 		},
+		{
+			name: "Dependents",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
+				impact.EXPECT().Role(gomock.Any(), id).Return(&models.Impact{
+					ResourceType: models.RoleResourceType,
+					ResourceId:   id,
+					Users:        []models.User{{ID: userId, IdentityNumber: "PNOEE-60001017869", FirstName: "EID2016", LastName: "TESTNUMBER"}},
+				}, nil)
+			},
+			expected: result{
+				conflict: serializers.DeleteConflictSerializer{
+					ProblemSerializer: serializers.ProblemSerializer{
+						Type:     serializers.ProblemDefaultType,
+						Title:    "Conflict",
+						Status:   http.StatusConflict,
+						Detail:   errors.ErrResourceHasDependents.Error(),
+						Instance: "/api/backoffice/roles/" + id.String(),
+						Code:     "has_dependents",
+					},
+					Dependents: serializers.ImpactSerializer{
+						ResourceType: models.RoleResourceType,
+						ResourceID:   id,
+						Roles:        []serializers.RoleReferenceSerializer{},
+						Users:        []serializers.UserReferenceSerializer{{ID: userId, IdentityNumber: "PNOEE-60001017869", FirstName: "EID2016", LastName: "TESTNUMBER"}},
+					},
+				},
+				status: "409 Conflict",
+				code:   http.StatusConflict,
+			},
+		},
+		{
+			name:  "Force",
+			query: "?force=true",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
+				roles.EXPECT().Delete(gomock.Any(), id).Return(true, nil)
+				audit.EXPECT().Record(gomock.Any(), models.DeleteActionType, models.RoleResourceType, id, gomock.Any(), nil).Return(nil)
+			},
+			expected: result{
+				status: "204 No Content",
+				code:   http.StatusNoContent,
+			},
+		},
+		{
+			name:  "Dry run",
+			query: "?dry_run=true&force=true",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
+				impact.EXPECT().Role(gomock.Any(), id).Return(&models.Impact{
+					ResourceType: models.RoleResourceType,
+					ResourceId:   id,
+					Users:        []models.User{{ID: userId, IdentityNumber: "PNOEE-60001017869", FirstName: "EID2016", LastName: "TESTNUMBER"}},
+				}, nil)
+			},
+			expected: result{
+				impact: serializers.ImpactSerializer{
+					ResourceType: models.RoleResourceType,
+					ResourceID:   id,
+					Roles:        []serializers.RoleReferenceSerializer{},
+					Users:        []serializers.UserReferenceSerializer{{ID: userId, IdentityNumber: "PNOEE-60001017869", FirstName: "EID2016", LastName: "TESTNUMBER"}},
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name:  "Dry run not found",
+			query: "?dry_run=true",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(nil, errors.ErrRecordNotFound)
+			},
+			expected: result{
+				detail: errors.ErrRecordNotFound.Error(),
+				status: "404 Not Found",
+				code:   http.StatusNotFound,
+			},
+			error: true,
+		},
+		{
+			name:   "Invalid force",
+			query:  "?force=maybe",
+			before: func() {},
+			expected: result{
+				detail: errors.ErrInvalidArguments.Error(),
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name: "Impact unavailable",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
+				impact.EXPECT().Role(gomock.Any(), id).Return(nil, errors.ErrFailedToFetchResults)
+			},
+			expected: result{
+				detail: errors.ErrFailedToFetchResults.Error(),
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
+			},
+			error: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.before()
 
-			req := httptest.NewRequest(http.MethodDelete, "/api/backoffice/roles/10000000-1000-1000-1000-000000000001", nil)
+			req := httptest.NewRequest(http.MethodDelete, "/api/backoffice/roles/10000000-1000-1000-1000-000000000001"+tt.query, nil)
 			w := httptest.NewRecorder()
 
 			r := chi.NewRouter()
@@ -711,11 +1118,199 @@ func Test_Roles_Delete(t *testing.T) {
 			resp := w.Result()
 			defer resp.Body.Close()
 
+			switch {
+			case tt.error:
+				var response serializers.ProblemSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
+			case tt.expected.code == http.StatusConflict:
+				var response serializers.DeleteConflictSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.conflict, response)
+			case tt.expected.code == http.StatusOK:
+				var response serializers.ImpactSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.impact, response)
+			}
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+			assert.Equal(t, tt.expected.status, resp.Status)
+		})
+	}
+}
+
+func Test_Roles_Users(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	roles := services.NewMockRoles(ctrl)
+	index := services.NewMockIndex(ctrl)
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewRolesController(roles, index, impact, audit, paginator, log)
+
+	id := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+
+	type result struct {
+		response serializers.PaginationResponse[serializers.UserSerializer]
+		detail   string
+		status   string
+		code     int
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		before   func()
+		expected result
+		error    bool
+	}{
+		{
+			name: "Success",
+			path: "/api/backoffice/roles/10000000-1000-1000-3000-000000000001/users",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
+				index.EXPECT().UsersByRole(gomock.Any(), id).Return([]models.User{
+					{
+						ID:             uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+						IdentityNumber: "PNOEE-60001017869",
+						PersonalCode:   "60001017869",
+						FirstName:      "EID2016",
+						LastName:       "TESTNUMBER",
+						RoleIDs:        []uuid.UUID{id},
+					},
+				}, nil)
+			},
+			expected: result{
+				response: serializers.PaginationResponse[serializers.UserSerializer]{
+					Data: []serializers.UserSerializer{
+						{
+							ID:             uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+							IdentityNumber: "PNOEE-60001017869",
+							PersonalCode:   "60001017869",
+							FirstName:      "EID2016",
+							LastName:       "TESTNUMBER",
+							RoleIDs:        []uuid.UUID{id},
+						},
+					},
+					Meta: serializers.PaginationMeta{
+						Page:  1,
+						Per:   25,
+						Total: 1,
+					},
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+			error: false,
+		},
+		{
+			name: "Page beyond results",
+			path: "/api/backoffice/roles/10000000-1000-1000-3000-000000000001/users?page=2&per=1",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
+				index.EXPECT().UsersByRole(gomock.Any(), id).Return([]models.User{
+					{
+						ID:             uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+						IdentityNumber: "PNOEE-60001017869",
+						PersonalCode:   "60001017869",
+						FirstName:      "EID2016",
+						LastName:       "TESTNUMBER",
+						RoleIDs:        []uuid.UUID{id},
+					},
+				}, nil)
+			},
+			expected: result{
+				response: serializers.PaginationResponse[serializers.UserSerializer]{
+					Data: []serializers.UserSerializer{},
+					Meta: serializers.PaginationMeta{
+						Page:  2,
+						Per:   1,
+						Total: 1,
+					},
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+			error: false,
+		},
+		{
+			name: "Not found",
+			path: "/api/backoffice/roles/10000000-1000-1000-3000-000000000001/users",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(nil, errors.ErrRecordNotFound)
+			},
+			expected: result{
+				detail: errors.ErrRecordNotFound.Error(),
+				status: "404 Not Found",
+				code:   http.StatusNotFound,
+			},
+			error: true,
+		},
+		{
+			name: "Index unavailable",
+			path: "/api/backoffice/roles/10000000-1000-1000-3000-000000000001/users",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(&models.Role{ID: id}, nil)
+				index.EXPECT().UsersByRole(gomock.Any(), id).Return(nil, errors.ErrFailedToFetchResults)
+			},
+			expected: result{
+				detail: errors.ErrFailedToFetchResults.Error(),
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
+			},
+			error: true,
+		},
+		{
+			name:   "Invalid id",
+			path:   "/api/backoffice/roles/invalid/users",
+			before: func() {},
+			expected: result{
+				detail: errors.ErrInvalidArguments.Error(),
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Get("/api/backoffice/roles/{id}/users", controller.Users)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
+			} else {
+				var response serializers.PaginationResponse[serializers.UserSerializer]
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.response, response)
 			}
 
 			assert.Equal(t, tt.expected.code, resp.StatusCode)
@@ -723,3 +1318,371 @@ func Test_Roles_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_Roles_Create_UnknownReferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	roles := services.NewMockRoles(ctrl)
+	index := services.NewMockIndex(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewRolesController(roles, index, services.NewMockImpactAnalyzer(ctrl), audit, paginator, log)
+
+	unknownId := uuid.MustParse("10000000-1000-1000-4000-000000000009")
+
+	roles.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, &errors.ValidationError{
+		Err: errors.ErrUnknownReferences,
+		Fields: []errors.FieldError{
+			{Pointer: "/permission_ids/0", Code: errors.UnknownIdCode, Detail: unknownId.String() + " does not exist"},
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/backoffice/roles", strings.NewReader(`{"name": "admin", "description": "Admin role", "permission_ids": ["10000000-1000-1000-4000-000000000009"]}`))
+	w := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Post("/api/backoffice/roles", controller.Create)
+	r.ServeHTTP(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	var response serializers.ProblemSerializer
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Equal(t, serializers.ProblemSerializer{
+		Type:     serializers.ProblemDefaultType,
+		Title:    "Unprocessable Entity",
+		Status:   http.StatusUnprocessableEntity,
+		Detail:   errors.ErrUnknownReferences.Error(),
+		Instance: "/api/backoffice/roles",
+		Code:     "unknown_references",
+		Errors: []serializers.FieldErrorSerializer{
+			{Pointer: "/permission_ids/0", Code: "unknown_id", Detail: unknownId.String() + " does not exist"},
+		},
+	}, response)
+	assert.Equal(t, serializers.ProblemContentType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func Test_Roles_ConditionalRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	roles := services.NewMockRoles(ctrl)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	audit.EXPECT().Record(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewRolesController(roles, index, impact, audit, paginator, log)
+
+	id := uuid.MustParse("10000000-1000-1000-1000-000000000001")
+	permissionId := uuid.MustParse("10000000-1000-1000-4000-000000000001")
+
+	role := &models.Role{ID: id, Name: "admin", Description: "Admin role"}
+	changed := &models.Role{ID: id, Name: "admin", Description: "Admin role", PermissionIDs: []uuid.UUID{permissionId}}
+
+	r := chi.NewRouter()
+	r.Get("/api/backoffice/roles/{id}", controller.Get)
+	r.Put("/api/backoffice/roles/{id}", controller.Update)
+	r.Patch("/api/backoffice/roles/{id}", controller.Patch)
+	r.Delete("/api/backoffice/roles/{id}", controller.Delete)
+
+	roles.EXPECT().FindById(gomock.Any(), id).Return(role, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/backoffice/roles/"+id.String(), nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	etag := w.Result().Header.Get(ETagHeader)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, etag)
+
+	tests := []struct {
+		name        string
+		before      func()
+		method      string
+		path        string
+		header      string
+		value       string
+		contentType string
+		body        string
+		code        int
+	}{
+		{
+			name: "Get not modified",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(role, nil)
+			},
+			method: http.MethodGet,
+			header: IfNoneMatchHeader,
+			value:  etag,
+			code:   http.StatusNotModified,
+		},
+		{
+			name: "Get modified",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(changed, nil)
+			},
+			method: http.MethodGet,
+			header: IfNoneMatchHeader,
+			value:  etag,
+			code:   http.StatusOK,
+		},
+		{
+			name: "Update current",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(role, nil)
+				roles.EXPECT().Update(gomock.Any(), gomock.Any()).Return(role, nil)
+			},
+			method: http.MethodPut,
+			header: IfMatchHeader,
+			value:  etag,
+			body:   `{"name": "admin", "description": "Admin role"}`,
+			code:   http.StatusOK,
+		},
+		{
+			name: "Update stale",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(changed, nil)
+				roles.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+			},
+			method: http.MethodPut,
+			header: IfMatchHeader,
+			value:  etag,
+			body:   `{"name": "admin", "description": "Admin role"}`,
+			code:   http.StatusPreconditionFailed,
+		},
+		{
+			name: "Patch stale",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(changed, nil)
+				roles.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+			},
+			method:      http.MethodPatch,
+			header:      IfMatchHeader,
+			value:       etag,
+			contentType: "application/merge-patch+json",
+			body:        `{"description": "Administrators"}`,
+			code:        http.StatusPreconditionFailed,
+		},
+		{
+			name: "Delete stale",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(changed, nil)
+				roles.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+			},
+			method: http.MethodDelete,
+			path:   "?force=true",
+			header: IfMatchHeader,
+			value:  etag,
+			code:   http.StatusPreconditionFailed,
+		},
+		{
+			name: "Delete missing",
+			before: func() {
+				roles.EXPECT().FindById(gomock.Any(), id).Return(nil, errors.ErrRecordNotFound)
+				roles.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+			},
+			method: http.MethodDelete,
+			path:   "?force=true",
+			header: IfMatchHeader,
+			value:  "*",
+			code:   http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(tt.method, "/api/backoffice/roles/"+id.String()+tt.path, strings.NewReader(tt.body))
+			req.Header.Set(tt.header, tt.value)
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Roles_Bulk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	roles := services.NewMockRoles(ctrl)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	impact := services.NewMockImpactAnalyzer(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewRolesController(roles, index, impact, audit, paginator, log)
+
+	createdId := uuid.MustParse("10000000-1000-1000-1000-000000000001")
+	updatedId := uuid.MustParse("10000000-1000-1000-1000-000000000002")
+	deletedId := uuid.MustParse("10000000-1000-1000-1000-000000000003")
+
+	type item struct {
+		status int
+		state  string
+		code   string
+	}
+
+	type result struct {
+		code  int
+		items []item
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		before   func()
+		body     string
+		expected result
+	}{
+		{
+			name: "Per item results",
+			path: "/api/backoffice/roles/bulk",
+			before: func() {
+				roles.EXPECT().Create(gomock.Any(), &models.Role{Name: "admin", Description: "Admin role"}).
+					Return(&models.Role{ID: createdId, Name: "admin", Description: "Admin role"}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.CreateActionType, models.RoleResourceType, createdId, nil, gomock.Any()).Return(nil)
+				roles.EXPECT().FindById(gomock.Any(), updatedId).Return(nil, errors.ErrRecordNotFound)
+				roles.EXPECT().FindById(gomock.Any(), deletedId).Return(&models.Role{ID: deletedId, Name: "manager"}, nil)
+				impact.EXPECT().Role(gomock.Any(), deletedId).Return(&models.Impact{Users: []models.User{{ID: createdId}}}, nil)
+			},
+			body: `[
+				{"op": "create", "data": {"name": "admin", "description": "Admin role"}},
+				{"op": "update", "id": "` + updatedId.String() + `", "data": {"name": "manager", "description": "Manager role"}},
+				{"op": "delete", "id": "` + deletedId.String() + `"},
+				{"op": "create", "data": {"name": "viewer"}}
+			]`,
+			expected: result{
+				code: http.StatusMultiStatus,
+				items: []item{
+					{status: http.StatusCreated, state: services.BulkAppliedState},
+					{status: http.StatusNotFound, state: services.BulkFailedState, code: "record_not_found"},
+					{status: http.StatusConflict, state: services.BulkFailedState, code: "has_dependents"},
+					{status: http.StatusBadRequest, state: services.BulkFailedState, code: "invalid_arguments"},
+				},
+			},
+		},
+		{
+			name: "Atomic with invalid item",
+			path: "/api/backoffice/roles/bulk?atomic=true",
+			before: func() {
+				roles.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+				roles.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+			},
+			body: `[
+				{"op": "create", "data": {"name": "admin", "description": "Admin role"}},
+				{"op": "create", "data": {"name": "viewer"}},
+				{"op": "delete", "id": "` + deletedId.String() + `"}
+			]`,
+			expected: result{
+				code: http.StatusMultiStatus,
+				items: []item{
+					{status: http.StatusFailedDependency, state: services.BulkSkippedState, code: "failed_dependency"},
+					{status: http.StatusBadRequest, state: services.BulkFailedState, code: "invalid_arguments"},
+					{status: http.StatusFailedDependency, state: services.BulkSkippedState, code: "failed_dependency"},
+				},
+			},
+		},
+		{
+			name: "Atomic rollback",
+			path: "/api/backoffice/roles/bulk?atomic=true&force=true",
+			before: func() {
+				deleted := make(chan struct{})
+				roles.EXPECT().FindById(gomock.Any(), deletedId).Return(&models.Role{ID: deletedId, Name: "manager", Description: "Manager role"}, nil)
+				roles.EXPECT().Delete(gomock.Any(), deletedId).DoAndReturn(func(_ context.Context, _ uuid.UUID) (bool, error) {
+					close(deleted)
+					return true, nil
+				})
+				audit.EXPECT().Record(gomock.Any(), models.DeleteActionType, models.RoleResourceType, deletedId, gomock.Any(), nil).Return(nil)
+				roles.EXPECT().FindById(gomock.Any(), updatedId).DoAndReturn(func(_ context.Context, _ uuid.UUID) (*models.Role, error) {
+					<-deleted
+					return nil, errors.ErrUnavailable
+				})
+				roles.EXPECT().Create(gomock.Any(), &models.Role{Name: "manager", Description: "Manager role"}).
+					Return(&models.Role{ID: createdId, Name: "manager", Description: "Manager role"}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.CreateActionType, models.RoleResourceType, createdId, nil, gomock.Any()).Return(nil)
+			},
+			body: `[
+				{"op": "delete", "id": "` + deletedId.String() + `"},
+				{"op": "update", "id": "` + updatedId.String() + `", "data": {"name": "admin", "description": "Admin role"}}
+			]`,
+			expected: result{
+				code: http.StatusMultiStatus,
+				items: []item{
+					{status: http.StatusFailedDependency, state: services.BulkRolledBackState, code: "failed_dependency"},
+					{status: http.StatusServiceUnavailable, state: services.BulkFailedState, code: "unavailable"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Post("/api/backoffice/roles/bulk", controller.Bulk)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			var response serializers.BulkResponseSerializer
+			err := json.NewDecoder(resp.Body).Decode(&response)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+			assert.Equal(t, len(tt.expected.items), response.Meta.Total)
+
+			for i, expected := range tt.expected.items {
+				actual := response.Data[i]
+				assert.Equal(t, i, actual.Index)
+				assert.Equal(t, expected.status, actual.Status)
+				assert.Equal(t, expected.state, actual.State)
+				if expected.code != "" {
+					assert.Equal(t, expected.code, actual.Error.Code)
+				} else {
+					assert.Nil(t, actual.Error)
+				}
+			}
+		})
+	}
+}
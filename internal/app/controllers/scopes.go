@@ -20,18 +20,28 @@ type ScopesController interface {
 	Get(w http.ResponseWriter, r *http.Request)
 	Create(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
+	Patch(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Users(w http.ResponseWriter, r *http.Request)
 }
 
 type scopesController struct {
-	scopes services.Scopes
-	log    *logger.Logger
+	scopes    services.Scopes
+	index     services.Index
+	impact    services.ImpactAnalyzer
+	audit     services.Audit
+	paginator services.Paginator
+	log       *logger.Logger
 }
 
-func NewScopesController(scopes services.Scopes, log *logger.Logger) ScopesController {
+func NewScopesController(scopes services.Scopes, index services.Index, impact services.ImpactAnalyzer, audit services.Audit, paginator services.Paginator, log *logger.Logger) ScopesController {
 	return &scopesController{
-		scopes: scopes,
-		log:    log,
+		scopes:    scopes,
+		index:     index,
+		impact:    impact,
+		audit:     audit,
+		paginator: paginator,
+		log:       log,
 	}
 }
 
@@ -39,19 +49,23 @@ func NewScopesController(scopes services.Scopes, log *logger.Logger) ScopesContr
 func (c *scopesController) List(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	pagination := services.NewPagination(r)
-	rows, total, err := c.scopes.List(r.Context(), pagination)
+	query, err := services.NewQuery(r, services.ScopesQuerySchema)
 	if err != nil {
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrFailedToFetchResults):
-			w.WriteHeader(http.StatusServiceUnavailable)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		c.log.Error().Err(err).Str("query", r.URL.RawQuery).Msg("Invalid list query")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	pagination, err := c.paginator.Paginate(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("cursor", r.URL.Query().Get(services.CursorParam)).Msg("Invalid pagination cursor")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
 
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+	rows, total, err := c.scopes.List(r.Context(), pagination, query)
+	if err != nil {
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -71,6 +85,8 @@ func (c *scopesController) List(w http.ResponseWriter, r *http.Request) {
 			Page:  pagination.Page,
 			Per:   pagination.PerPage,
 			Total: total,
+			Next:  c.paginator.Next(r, pagination),
+			Prev:  c.paginator.Prev(r, pagination),
 		},
 	}
 
@@ -85,8 +101,7 @@ func (c *scopesController) Get(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
 		return
 	}
 
@@ -95,14 +110,7 @@ func (c *scopesController) Get(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get scope")
 
-		switch {
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -112,6 +120,13 @@ func (c *scopesController) Get(w http.ResponseWriter, r *http.Request) {
 		Description: record.Description,
 	}
 
+	etag := serializers.ETag(response)
+	w.Header().Set(ETagHeader, etag)
+	if ifNoneMatch(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -123,8 +138,7 @@ func (c *scopesController) Create(w http.ResponseWriter, r *http.Request) {
 	var params dto.ScopeRequest
 	if err := params.Validate(r.Body); err != nil {
 		c.log.Error().Err(err).Str("name", params.Name).Msg("Failed to create scope")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -135,16 +149,7 @@ func (c *scopesController) Create(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Msg("Failed to create scope")
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrFailedToCreateRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -154,6 +159,9 @@ func (c *scopesController) Create(w http.ResponseWriter, r *http.Request) {
 		Description: record.Description,
 	}
 
+	c.index.Invalidate()
+	services.RecordApplied(r.Context(), c.audit, c.log, models.CreateActionType, models.ScopeResourceType, record.ID, nil, record.AuditSnapshot())
+
 	w.WriteHeader(http.StatusCreated)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -165,19 +173,73 @@ func (c *scopesController) Update(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
 		return
 	}
 
 	var params dto.ScopeRequest
 	if err = params.Validate(r.Body); err != nil {
 		c.log.Error().Err(err).Str("name", params.Name).Msg("Failed to create scope")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	before, err := c.scopes.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Failed to load scope state for audit")
+		before = nil
+	}
+
+	if err = ifMatch(r, scopeSerializer(before), err); err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Rejected conditional scope write")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.update(w, r, id, &params, before)
+}
+
+// Patch applies a merge patch or JSON patch document to the scope
+//
+//nolint:dupl
+func (c *scopesController) Patch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	before, err := c.scopes.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to find scope")
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
+	if err = ifMatch(r, scopeSerializer(before), nil); err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Rejected conditional scope write")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	params := dto.ScopeRequest{
+		Name:        before.Name,
+		Description: before.Description,
+	}
+	if err = params.Patch(r.Header.Get("Content-Type"), http.MaxBytesReader(w, r.Body, dto.MaxPatchBodySize)); err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to patch scope")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.update(w, r, id, &params, before)
+}
+
+// update stores params over the scope, before is the state recorded in the audit log
+func (c *scopesController) update(w http.ResponseWriter, r *http.Request, id uuid.UUID, params *dto.ScopeRequest, before *models.Scope) {
 	record, err := c.scopes.Update(r.Context(), &models.Scope{
 		ID:          id,
 		Name:        params.Name,
@@ -186,18 +248,7 @@ func (c *scopesController) Update(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to update scope")
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		case errors.Is(err, errors.ErrFailedToUpdateRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -207,6 +258,9 @@ func (c *scopesController) Update(w http.ResponseWriter, r *http.Request) {
 		Description: record.Description,
 	}
 
+	c.index.Invalidate()
+	services.RecordApplied(r.Context(), c.audit, c.log, models.UpdateActionType, models.ScopeResourceType, id, before.AuditSnapshot(), record.AuditSnapshot())
+
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -218,29 +272,150 @@ func (c *scopesController) Delete(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
 		return
 	}
 
-	_, err = c.scopes.Delete(r.Context(), id)
+	options, err := services.NewDeleteOptions(r)
 	if err != nil {
-		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to delete scope")
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid delete options")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	before, err := c.scopes.FindById(r.Context(), id)
+	if err != nil {
+		if options.DryRun && errors.Is(err, errors.ErrRecordNotFound) {
+			serializers.WriteProblem(w, r, err)
+			return
+		}
+
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Failed to load scope state for audit")
+		before = nil
+	}
+
+	if err = ifMatch(r, scopeSerializer(before), err); err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Rejected conditional scope write")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	if options.Checked() {
+		impact, err := c.impact.Scope(r.Context(), id)
+		if err != nil {
+			c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to analyze scope delete impact")
+
+			serializers.WriteProblem(w, r, err)
+			return
+		}
+
+		if options.DryRun {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(impactSerializer(impact))
+			return
+		}
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		case errors.Is(err, errors.ErrFailedToDeleteRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
+		if !impact.IsEmpty() {
+			c.log.Warn().Str("id", id.String()).Int("roles", len(impact.Roles)).Int("users", len(impact.Users)).Msg("Refused to delete scope with dependents")
+			w.Header().Set("Content-Type", serializers.ProblemContentType)
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(serializers.DeleteConflictSerializer{
+				ProblemSerializer: serializers.NewProblem(r, errors.ErrResourceHasDependents),
+				Dependents:        impactSerializer(impact),
+			})
+			return
 		}
+	}
 
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+	_, err = c.scopes.Delete(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to delete scope")
+
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
+	c.index.Invalidate()
+	services.RecordApplied(r.Context(), c.audit, c.log, models.DeleteActionType, models.ScopeResourceType, id, before.AuditSnapshot(), nil)
+
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// Users lists the users holding the scope
+//
+//nolint:dupl
+func (c *scopesController) Users(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	pagination, err := c.paginator.Paginate(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("cursor", r.URL.Query().Get(services.CursorParam)).Msg("Invalid pagination cursor")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	if _, err = c.scopes.FindById(r.Context(), id); err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get scope")
+
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	rows, err := c.index.UsersByScope(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to look up users holding the scope")
+
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	total := uint64(len(rows))
+	page := services.PageOf(rows, pagination, func(user models.User) uuid.UUID { return user.ID })
+	collection := make([]serializers.UserSerializer, 0, len(page))
+
+	for _, user := range page {
+		collection = append(collection, serializers.UserSerializer{
+			ID:             user.ID,
+			IdentityNumber: user.IdentityNumber,
+			PersonalCode:   user.PersonalCode,
+			FirstName:      user.FirstName,
+			LastName:       user.LastName,
+			RoleIDs:        user.RoleIDs,
+			ScopeIDs:       user.ScopeIDs,
+		})
+	}
+
+	response := serializers.PaginationResponse[serializers.UserSerializer]{
+		Data: collection,
+		Meta: serializers.PaginationMeta{
+			Page:  pagination.Page,
+			Per:   pagination.PerPage,
+			Total: total,
+			Next:  c.paginator.Next(r, pagination),
+			Prev:  c.paginator.Prev(r, pagination),
+		},
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// scopeSerializer is the response shape of a scope, nil when there is none, so that If-Match compares against the ETag of Show
+func scopeSerializer(record *models.Scope) interface{} {
+	if record == nil {
+		return nil
+	}
+
+	return serializers.ScopeSerializer{
+		ID:          record.ID,
+		Name:        record.Name,
+		Description: record.Description,
+	}
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/app/controllers/scopes.go
+// Source: scopes.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/app/controllers/scopes.go -destination=internal/app/controllers/scopes_mock.go -package=controllers
+//	mockgen -source=scopes.go -destination=scopes_mock.go -package=controllers
 //
 
 // Package controllers is a generated GoMock package.
@@ -88,6 +88,18 @@ func (mr *MockScopesControllerMockRecorder) List(w, r any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockScopesController)(nil).List), w, r)
 }
 
+// Patch mocks base method.
+func (m *MockScopesController) Patch(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Patch", w, r)
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockScopesControllerMockRecorder) Patch(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockScopesController)(nil).Patch), w, r)
+}
+
 // Update mocks base method.
 func (m *MockScopesController) Update(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -99,3 +111,15 @@ func (mr *MockScopesControllerMockRecorder) Update(w, r any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockScopesController)(nil).Update), w, r)
 }
+
+// Users mocks base method.
+func (m *MockScopesController) Users(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Users", w, r)
+}
+
+// Users indicates an expected call of Users.
+func (mr *MockScopesControllerMockRecorder) Users(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockScopesController)(nil).Users), w, r)
+}
@@ -0,0 +1,285 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/models/dto"
+	"loki-backoffice/internal/app/serializers"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config/logger"
+)
+
+// SnapshotFileLayout names downloaded archives after the time the snapshot was taken
+const SnapshotFileLayout = "20060102T150405Z"
+
+type SnapshotsController interface {
+	List(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Current(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
+	RestoreById(w http.ResponseWriter, r *http.Request)
+}
+
+type snapshotsController struct {
+	snapshots services.Snapshots
+	paginator services.Paginator
+	log       *logger.Logger
+}
+
+func NewSnapshotsController(snapshots services.Snapshots, paginator services.Paginator, log *logger.Logger) SnapshotsController {
+	return &snapshotsController{
+		snapshots: snapshots,
+		paginator: paginator,
+		log:       log,
+	}
+}
+
+// List returns the stored snapshots, newest first
+func (c *snapshotsController) List(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	pagination, err := c.paginator.Paginate(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("cursor", r.URL.Query().Get(services.CursorParam)).Msg("Invalid pagination cursor")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	rows, total, err := c.snapshots.List(r.Context(), pagination)
+	if err != nil {
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	collection := make([]serializers.SnapshotSerializer, 0, len(rows))
+	for _, record := range rows {
+		collection = append(collection, serializers.SnapshotSerializer{
+			ID:        record.ID,
+			Version:   record.Version,
+			CreatedBy: record.ActorID,
+			CreatedAt: record.CreatedAt,
+		})
+	}
+
+	response := serializers.PaginationResponse[serializers.SnapshotSerializer]{
+		Data: collection,
+		Meta: serializers.PaginationMeta{
+			Page:  pagination.Page,
+			Per:   pagination.PerPage,
+			Total: total,
+			Next:  c.paginator.Next(r, pagination),
+			Prev:  c.paginator.Prev(r, pagination),
+		},
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// Create takes a snapshot of the live state and stores it
+func (c *snapshotsController) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	snapshot, err := c.snapshots.Take(r.Context())
+	if err != nil {
+		c.log.Error().Err(err).Msg("Failed to take snapshot")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	result, err := c.snapshots.Save(r.Context(), snapshot)
+	if err != nil {
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", r.URL.Path+"/"+result.ID.String())
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(serializers.SnapshotSerializer{
+		ID:        result.ID,
+		Version:   result.Version,
+		CreatedBy: result.ActorID,
+		CreatedAt: result.CreatedAt,
+	})
+}
+
+// Current downloads the archive of a snapshot of the live state without storing it
+func (c *snapshotsController) Current(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := c.snapshots.Take(r.Context())
+	if err != nil {
+		c.log.Error().Err(err).Msg("Failed to take snapshot")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.download(w, snapshot)
+}
+
+// Get downloads the archive of a stored snapshot
+func (c *snapshotsController) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	snapshot, err := c.snapshots.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get snapshot")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.download(w, snapshot)
+}
+
+// Restore restores an uploaded archive, with dry_run=true the changes are only planned
+func (c *snapshotsController) Restore(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var params dto.SnapshotArchive
+	if err := params.Validate(r.Body); err != nil {
+		c.log.Error().Err(err).Msg("Invalid snapshot archive")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.restore(w, r, params.Snapshot())
+}
+
+// RestoreById restores a stored snapshot, with dry_run=true the changes are only planned
+func (c *snapshotsController) RestoreById(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	snapshot, err := c.snapshots.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get snapshot")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.restore(w, r, snapshot)
+}
+
+func (c *snapshotsController) restore(w http.ResponseWriter, r *http.Request, snapshot *models.Snapshot) {
+	options, err := services.NewRestoreOptions(r)
+	if err != nil {
+		c.log.Error().Err(err).Msg("Invalid restore options")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	var result *models.SnapshotRestore
+	if options.DryRun {
+		result, err = c.snapshots.Plan(r.Context(), snapshot, options)
+	} else {
+		result, err = c.snapshots.Restore(r.Context(), snapshot, options)
+	}
+	if err != nil {
+		c.log.Error().Err(err).Msg("Failed to restore snapshot")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	response := snapshotRestoreSerializer(r, result)
+
+	status := http.StatusOK
+	if response.RBAC.Meta.Failed > 0 || response.Users.Meta.Failed > 0 {
+		status = http.StatusMultiStatus
+	}
+
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (c *snapshotsController) download(w http.ResponseWriter, snapshot *models.Snapshot) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="snapshot-%s.json"`, snapshot.CreatedAt.UTC().Format(SnapshotFileLayout)))
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(dto.NewSnapshotArchive(snapshot))
+}
+
+func snapshotRestoreSerializer(r *http.Request, result *models.SnapshotRestore) serializers.SnapshotRestoreSerializer {
+	response := serializers.SnapshotRestoreSerializer{
+		RBAC: rbacPlanSerializer(r, result.RBAC),
+		Users: serializers.SnapshotUserPlanSerializer{
+			Data:        make([]serializers.SnapshotUserChangeSerializer, 0, len(result.Users)),
+			DeleteUsers: result.DeleteUsers,
+			Retained:    result.Retained,
+		},
+	}
+
+	for _, change := range result.Users {
+		item := serializers.SnapshotUserChangeSerializer{
+			Action:         change.Action,
+			IdentityNumber: change.IdentityNumber,
+			Fields:         change.Fields,
+			Before:         snapshotUserSerializer(change.Before),
+			After:          snapshotUserSerializer(change.After),
+			State:          change.State,
+		}
+
+		if change.ID != uuid.Nil {
+			id := change.ID
+			item.ID = &id
+		}
+
+		if change.Err != nil {
+			problem := serializers.NewProblem(r, change.Err)
+			item.Error = &problem
+		}
+
+		switch change.Action {
+		case models.CreateActionType:
+			response.Users.Meta.Create++
+		case models.UpdateActionType:
+			response.Users.Meta.Update++
+		case models.DeleteActionType:
+			response.Users.Meta.Delete++
+		}
+
+		switch change.State {
+		case services.BulkAppliedState:
+			response.Users.Meta.Applied++
+		case services.BulkFailedState:
+			response.Users.Meta.Failed++
+		case services.BulkSkippedState:
+			response.Users.Meta.Skipped++
+		}
+
+		response.Users.Data = append(response.Users.Data, item)
+	}
+
+	return response
+}
+
+func snapshotUserSerializer(user *models.SnapshotUser) *serializers.SnapshotUserSerializer {
+	if user == nil {
+		return nil
+	}
+
+	return &serializers.SnapshotUserSerializer{
+		IdentityNumber: user.IdentityNumber,
+		PersonalCode:   user.PersonalCode,
+		FirstName:      user.FirstName,
+		LastName:       user.LastName,
+		Roles:          user.Roles,
+		Scopes:         user.Scopes,
+	}
+}
@@ -0,0 +1,336 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/models/dto"
+	"loki-backoffice/internal/app/serializers"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func Test_Snapshots_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	snapshots := services.NewMockSnapshots(ctrl)
+	paginator := services.NewMockPaginator(ctrl)
+	controller := NewSnapshotsController(snapshots, paginator, log)
+
+	id := uuid.MustParse("10000000-1000-1000-5000-000000000001")
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	snapshot := &models.Snapshot{Version: models.SnapshotVersion, ActorID: "actor", CreatedAt: createdAt}
+
+	type result struct {
+		response serializers.SnapshotSerializer
+		location string
+		code     int
+	}
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected result
+	}{
+		{
+			name: "Success",
+			before: func() {
+				snapshots.EXPECT().Take(gomock.Any()).Return(snapshot, nil)
+				snapshots.EXPECT().Save(gomock.Any(), snapshot).Return(&models.Snapshot{ID: id, Version: models.SnapshotVersion, ActorID: "actor", CreatedAt: createdAt}, nil)
+			},
+			expected: result{
+				response: serializers.SnapshotSerializer{ID: id, Version: models.SnapshotVersion, CreatedBy: "actor", CreatedAt: createdAt},
+				location: "/api/backoffice/snapshots/" + id.String(),
+				code:     http.StatusCreated,
+			},
+		},
+		{
+			name: "Upstream error",
+			before: func() {
+				snapshots.EXPECT().Take(gomock.Any()).Return(nil, errors.ErrUnavailable)
+			},
+			expected: result{code: http.StatusServiceUnavailable},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/backoffice/snapshots", nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Post("/api/backoffice/snapshots", controller.Create)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+
+			if tt.expected.code == http.StatusCreated {
+				var response serializers.SnapshotSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.response, response)
+				assert.Equal(t, tt.expected.location, resp.Header.Get("Location"))
+			}
+		})
+	}
+}
+
+func Test_Snapshots_Current(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	snapshots := services.NewMockSnapshots(ctrl)
+	paginator := services.NewMockPaginator(ctrl)
+	controller := NewSnapshotsController(snapshots, paginator, log)
+
+	permissionId := uuid.MustParse("10000000-1000-1000-4000-000000000001")
+
+	snapshots.EXPECT().Take(gomock.Any()).Return(&models.Snapshot{
+		Version:     models.SnapshotVersion,
+		ActorID:     "actor",
+		CreatedAt:   time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Permissions: []models.Permission{{ID: permissionId, Name: "read:users", Description: "Read users"}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/backoffice/snapshots/current", nil)
+	w := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Get("/api/backoffice/snapshots/current", controller.Current)
+	r.ServeHTTP(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `attachment; filename="snapshot-20250601T120000Z.json"`, resp.Header.Get("Content-Disposition"))
+
+	var response dto.SnapshotArchive
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Equal(t, models.SnapshotVersion, response.Version)
+	assert.Equal(t, []dto.SnapshotPermissionRequest{{ID: permissionId, Name: "read:users", Description: "Read users"}}, response.Permissions)
+	assert.Equal(t, []dto.SnapshotUserRequest{}, response.Users)
+}
+
+func Test_Snapshots_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	snapshots := services.NewMockSnapshots(ctrl)
+	paginator := services.NewMockPaginator(ctrl)
+	controller := NewSnapshotsController(snapshots, paginator, log)
+
+	userId := uuid.MustParse("10000000-1000-1000-1000-000000000001")
+
+	body := `{
+		"version": 1,
+		"created_by": "actor",
+		"created_at": "2025-06-01T12:00:00Z",
+		"permissions": [{"id": "10000000-1000-1000-4000-000000000001", "name": "read:users", "description": "Read users"}],
+		"roles": [],
+		"scopes": [],
+		"users": []
+	}`
+
+	type result struct {
+		users serializers.RBACPlanMetaSerializer
+		code  int
+	}
+
+	tests := []struct {
+		name     string
+		before   func()
+		query    string
+		body     string
+		expected result
+	}{
+		{
+			name: "Dry run",
+			before: func() {
+				snapshots.EXPECT().Plan(gomock.Any(), gomock.Any(), &services.RestoreOptions{DryRun: true, DeleteUsers: true}).Return(&models.SnapshotRestore{
+					RBAC: &models.RBACPlan{},
+					Users: []models.SnapshotUserChange{
+						{Action: models.DeleteActionType, ID: userId, IdentityNumber: "PNOEE-60001017869", Before: &models.SnapshotUser{IdentityNumber: "PNOEE-60001017869"}},
+					},
+					DeleteUsers: true,
+				}, nil)
+			},
+			query:    "?dry_run=true&delete_users=true",
+			body:     body,
+			expected: result{users: serializers.RBACPlanMetaSerializer{Delete: 1}, code: http.StatusOK},
+		},
+		{
+			name: "Partially restored",
+			before: func() {
+				snapshots.EXPECT().Restore(gomock.Any(), gomock.Any(), &services.RestoreOptions{}).Return(&models.SnapshotRestore{
+					RBAC: &models.RBACPlan{},
+					Users: []models.SnapshotUserChange{
+						{Action: models.DeleteActionType, ID: userId, IdentityNumber: "PNOEE-60001017869", State: services.BulkFailedState, Err: errors.ErrUnavailable},
+						{Action: models.CreateActionType, IdentityNumber: "PNOEE-60001018800", State: services.BulkSkippedState},
+					},
+				}, nil)
+			},
+			body:     body,
+			expected: result{users: serializers.RBACPlanMetaSerializer{Create: 1, Delete: 1, Failed: 1, Skipped: 1}, code: http.StatusMultiStatus},
+		},
+		{
+			name: "Unsupported version",
+			before: func() {
+				snapshots.EXPECT().Restore(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			body:     `{"version": 2}`,
+			expected: result{code: http.StatusBadRequest},
+		},
+		{
+			name: "Invalid dry run",
+			before: func() {
+				snapshots.EXPECT().Plan(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			query:    "?dry_run=maybe",
+			body:     body,
+			expected: result{code: http.StatusBadRequest},
+		},
+		{
+			name: "Invalid delete users",
+			before: func() {
+				snapshots.EXPECT().Restore(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			query:    "?delete_users=maybe",
+			body:     body,
+			expected: result{code: http.StatusBadRequest},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/backoffice/snapshots/restore"+tt.query, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Post("/api/backoffice/snapshots/restore", controller.Restore)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+
+			if tt.expected.code == http.StatusOK || tt.expected.code == http.StatusMultiStatus {
+				var response serializers.SnapshotRestoreSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.users, response.Users.Meta)
+			}
+		})
+	}
+}
+
+func Test_Snapshots_RestoreById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	snapshots := services.NewMockSnapshots(ctrl)
+	paginator := services.NewMockPaginator(ctrl)
+	controller := NewSnapshotsController(snapshots, paginator, log)
+
+	id := uuid.MustParse("10000000-1000-1000-5000-000000000001")
+	snapshot := &models.Snapshot{ID: id, Version: models.SnapshotVersion}
+
+	tests := []struct {
+		name     string
+		before   func()
+		id       string
+		expected int
+	}{
+		{
+			name: "Success",
+			before: func() {
+				snapshots.EXPECT().FindById(gomock.Any(), id).Return(snapshot, nil)
+				snapshots.EXPECT().Restore(gomock.Any(), snapshot, &services.RestoreOptions{}).Return(&models.SnapshotRestore{RBAC: &models.RBACPlan{}}, nil)
+			},
+			id:       id.String(),
+			expected: http.StatusOK,
+		},
+		{
+			name: "Not found",
+			before: func() {
+				snapshots.EXPECT().FindById(gomock.Any(), id).Return(nil, errors.ErrRecordNotFound)
+			},
+			id:       id.String(),
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "Invalid id",
+			before:   func() {},
+			id:       "invalid",
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/backoffice/snapshots/"+tt.id+"/restore", nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Post("/api/backoffice/snapshots/{id}/restore", controller.RestoreById)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expected, resp.StatusCode)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 
 	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
 	"loki-backoffice/internal/app/serializers"
 	"loki-backoffice/internal/app/services"
 	"loki-backoffice/internal/config/logger"
@@ -16,17 +17,23 @@ import (
 type TokensController interface {
 	List(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	RevokeAll(w http.ResponseWriter, r *http.Request)
+	Reveal(w http.ResponseWriter, r *http.Request)
 }
 
 type tokensController struct {
-	tokens services.Tokens
-	log    *logger.Logger
+	tokens    services.Tokens
+	audit     services.Audit
+	paginator services.Paginator
+	log       *logger.Logger
 }
 
-func NewTokensController(tokens services.Tokens, log *logger.Logger) TokensController {
+func NewTokensController(tokens services.Tokens, audit services.Audit, paginator services.Paginator, log *logger.Logger) TokensController {
 	return &tokensController{
-		tokens: tokens,
-		log:    log,
+		tokens:    tokens,
+		audit:     audit,
+		paginator: paginator,
+		log:       log,
 	}
 }
 
@@ -34,32 +41,30 @@ func NewTokensController(tokens services.Tokens, log *logger.Logger) TokensContr
 func (c *tokensController) List(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	pagination := services.NewPagination(r)
-	rows, total, err := c.tokens.List(r.Context(), pagination)
+	query, err := services.NewQuery(r, services.TokensQuerySchema)
 	if err != nil {
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrFailedToFetchResults):
-			w.WriteHeader(http.StatusServiceUnavailable)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		c.log.Error().Err(err).Str("query", r.URL.RawQuery).Msg("Invalid list query")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
 
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+	pagination, err := c.paginator.Paginate(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("cursor", r.URL.Query().Get(services.CursorParam)).Msg("Invalid pagination cursor")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	rows, total, err := c.tokens.List(r.Context(), pagination, query)
+	if err != nil {
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
 	collection := make([]serializers.TokenSerializer, 0, len(rows))
 
 	for _, token := range rows {
-		collection = append(collection, serializers.TokenSerializer{
-			ID:        token.ID,
-			UserId:    token.UserId,
-			Type:      token.Type,
-			Value:     token.Value,
-			ExpiresAt: token.ExpiresAt,
-		})
+		collection = append(collection, tokenSerializer(token))
 	}
 
 	response := serializers.PaginationResponse[serializers.TokenSerializer]{
@@ -68,6 +73,8 @@ func (c *tokensController) List(w http.ResponseWriter, r *http.Request) {
 			Page:  pagination.Page,
 			Per:   pagination.PerPage,
 			Total: total,
+			Next:  c.paginator.Next(r, pagination),
+			Prev:  c.paginator.Prev(r, pagination),
 		},
 	}
 
@@ -82,8 +89,16 @@ func (c *tokensController) Delete(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	// The SSO token service has no Get call, FindById walks the list so that the audit shows what was revoked
+	token, err := c.tokens.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to find token")
+
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -91,20 +106,124 @@ func (c *tokensController) Delete(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to delete token")
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		case errors.Is(err, errors.ErrFailedToDeleteRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
+	services.RecordApplied(r.Context(), c.audit, c.log, models.DeleteActionType, models.TokenResourceType, id, token.AuditSnapshot(), nil)
+
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// Reveal returns the raw token value, every successful call is audited
+func (c *tokensController) Reveal(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	token, err := c.tokens.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to find token")
+
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	// Unlike other mutations the value is withheld when the reveal cannot be audited
+	snapshot := tokenSerializer(*token)
+	if err = c.audit.Record(r.Context(), models.RevealActionType, models.TokenResourceType, id, nil, snapshot); err != nil {
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(serializers.TokenValueSerializer{
+		ID:    token.ID,
+		Value: token.Value,
+	})
+}
+
+// RevokeAll deletes every token of the user and reports what was revoked and what failed
+func (c *tokensController) RevokeAll(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userId, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	result, err := c.tokens.RevokeAll(r.Context(), userId, r.URL.Query().Get("type"))
+	if err != nil {
+		c.log.Error().Err(err).Str("user_id", userId.String()).Msg("Failed to revoke user tokens")
+
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	response := serializers.TokenRevocationSerializer{
+		UserId:  result.UserId,
+		Revoked: make([]serializers.RevokedTokenSerializer, 0, len(result.Revoked)),
+		Failed:  make([]serializers.FailedRevocationSerializer, 0, len(result.Failed)),
+	}
+
+	before := make([]serializers.RevokedTokenSerializer, 0, len(result.Revoked)+len(result.Failed))
+	after := make([]serializers.RevokedTokenSerializer, 0, len(result.Failed))
+
+	for _, token := range result.Revoked {
+		item := serializers.RevokedTokenSerializer{ID: token.ID, Type: token.Type, ExpiresAt: token.ExpiresAt}
+		response.Revoked = append(response.Revoked, item)
+		before = append(before, item)
+	}
+
+	for _, failure := range result.Failed {
+		response.Failed = append(response.Failed, serializers.FailedRevocationSerializer{
+			ID:    failure.Token.ID,
+			Type:  failure.Token.Type,
+			Error: failure.Error.Error(),
+		})
+
+		item := serializers.RevokedTokenSerializer{ID: failure.Token.ID, Type: failure.Token.Type, ExpiresAt: failure.Token.ExpiresAt}
+		before = append(before, item)
+		after = append(after, item)
+	}
+
+	if len(result.Revoked) > 0 {
+		services.RecordApplied(r.Context(), c.audit, c.log, models.RevokeTokensActionType, models.UserResourceType, userId, before, after)
+	}
+
+	if len(result.Failed) > 0 {
+		w.WriteHeader(http.StatusMultiStatus)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func tokenSerializer(token models.Token) serializers.TokenSerializer {
+	result := serializers.TokenSerializer{
+		ID:          token.ID,
+		UserId:      token.UserId,
+		Type:        token.Type,
+		Fingerprint: token.Fingerprint,
+		ExpiresAt:   token.ExpiresAt,
+	}
+
+	if token.Metadata != nil {
+		result.Metadata = &serializers.TokenMetadataSerializer{
+			Issuer:   token.Metadata.Issuer,
+			IssuedAt: token.Metadata.IssuedAt,
+			Scope:    token.Metadata.Scope,
+		}
+	}
+
+	return result
+}
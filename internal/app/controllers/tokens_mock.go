@@ -63,3 +63,27 @@ func (mr *MockTokensControllerMockRecorder) List(w, r any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTokensController)(nil).List), w, r)
 }
+
+// Reveal mocks base method.
+func (m *MockTokensController) Reveal(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Reveal", w, r)
+}
+
+// Reveal indicates an expected call of Reveal.
+func (mr *MockTokensControllerMockRecorder) Reveal(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reveal", reflect.TypeOf((*MockTokensController)(nil).Reveal), w, r)
+}
+
+// RevokeAll mocks base method.
+func (m *MockTokensController) RevokeAll(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeAll", w, r)
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockTokensControllerMockRecorder) RevokeAll(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockTokensController)(nil).RevokeAll), w, r)
+}
@@ -32,20 +32,23 @@ func Test_Tokens_List(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	tokens := services.NewMockTokens(ctrl)
-	controller := NewTokensController(tokens, log)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewTokensController(tokens, audit, paginator, log)
 
 	accessTokenExp := time.Now().Add(models.AccessTokenExp)
 	refreshTokenExp := time.Now().Add(models.RefreshTokenExp)
 
 	type result struct {
 		response serializers.PaginationResponse[serializers.TokenSerializer]
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
 
 	tests := []struct {
 		name     string
+		query    string
 		before   func()
 		expected result
 		error    bool
@@ -53,20 +56,23 @@ func Test_Tokens_List(t *testing.T) {
 		{
 			name: "Success",
 			before: func() {
-				tokens.EXPECT().List(gomock.Any(), gomock.Any()).Return([]models.Token{
+				tokens.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Token{
 					{
-						ID:        uuid.MustParse("10000000-1000-1000-6000-000000000001"),
-						UserId:    uuid.MustParse("10000000-1000-1000-1234-000000000001"),
-						Type:      models.AccessTokenType,
-						Value:     "access-token-value",
-						ExpiresAt: accessTokenExp,
+						ID:          uuid.MustParse("10000000-1000-1000-6000-000000000001"),
+						UserId:      uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+						Type:        models.AccessTokenType,
+						Value:       "access-token-value",
+						Fingerprint: "889813cbebaf2f8a",
+						Metadata:    &models.TokenMetadata{Issuer: "loki", Scope: []string{"sso-service"}},
+						ExpiresAt:   accessTokenExp,
 					},
 					{
-						ID:        uuid.MustParse("10000000-1000-1000-6000-000000000002"),
-						UserId:    uuid.MustParse("10000000-1000-1000-1234-000000000002"),
-						Type:      models.RefreshTokenType,
-						Value:     "refresh-token-value",
-						ExpiresAt: refreshTokenExp,
+						ID:          uuid.MustParse("10000000-1000-1000-6000-000000000002"),
+						UserId:      uuid.MustParse("10000000-1000-1000-1234-000000000002"),
+						Type:        models.RefreshTokenType,
+						Value:       "refresh-token-value",
+						Fingerprint: "e65009f6e0ae9fc2",
+						ExpiresAt:   refreshTokenExp,
 					},
 				}, uint64(2), nil)
 			},
@@ -74,18 +80,19 @@ func Test_Tokens_List(t *testing.T) {
 				response: serializers.PaginationResponse[serializers.TokenSerializer]{
 					Data: []serializers.TokenSerializer{
 						{
-							ID:        uuid.MustParse("10000000-1000-1000-6000-000000000001"),
-							UserId:    uuid.MustParse("10000000-1000-1000-1234-000000000001"),
-							Type:      models.AccessTokenType,
-							Value:     "access-token-value",
-							ExpiresAt: accessTokenExp,
+							ID:          uuid.MustParse("10000000-1000-1000-6000-000000000001"),
+							UserId:      uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+							Type:        models.AccessTokenType,
+							Fingerprint: "889813cbebaf2f8a",
+							Metadata:    &serializers.TokenMetadataSerializer{Issuer: "loki", Scope: []string{"sso-service"}},
+							ExpiresAt:   accessTokenExp,
 						},
 						{
-							ID:        uuid.MustParse("10000000-1000-1000-6000-000000000002"),
-							UserId:    uuid.MustParse("10000000-1000-1000-1234-000000000002"),
-							Type:      models.RefreshTokenType,
-							Value:     "refresh-token-value",
-							ExpiresAt: refreshTokenExp,
+							ID:          uuid.MustParse("10000000-1000-1000-6000-000000000002"),
+							UserId:      uuid.MustParse("10000000-1000-1000-1234-000000000002"),
+							Type:        models.RefreshTokenType,
+							Fingerprint: "e65009f6e0ae9fc2",
+							ExpiresAt:   refreshTokenExp,
 						},
 					},
 					Meta: serializers.PaginationMeta{
@@ -102,7 +109,7 @@ func Test_Tokens_List(t *testing.T) {
 		{
 			name: "Empty",
 			before: func() {
-				tokens.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), nil)
+				tokens.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), nil)
 			},
 			expected: result{
 				response: serializers.PaginationResponse[serializers.TokenSerializer]{
@@ -118,13 +125,39 @@ func Test_Tokens_List(t *testing.T) {
 			},
 			error: false,
 		},
+		{
+			name:  "Invalid query",
+			query: "?sort=-value",
+			before: func() {
+				tokens.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expected: result{
+				detail: "invalid arguments",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name:  "Invalid cursor",
+			query: "?cursor=invalid",
+			before: func() {
+				tokens.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expected: result{
+				detail: "invalid cursor",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
 		{
 			name: "Invalid params",
 			before: func() {
-				tokens.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrInvalidArguments)
+				tokens.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrInvalidArguments)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -133,22 +166,22 @@ func Test_Tokens_List(t *testing.T) {
 		{
 			name: "Bad request",
 			before: func() {
-				tokens.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrFailedToFetchResults)
+				tokens.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrFailedToFetchResults)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to fetch results"},
-				status: "503 Service Unavailable",
-				code:   http.StatusServiceUnavailable,
+				detail: "failed to fetch results",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 			error: true,
 		},
 		{
 			name: "Error",
 			before: func() {
-				tokens.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), assert.AnError)
+				tokens.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), assert.AnError)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -160,7 +193,7 @@ func Test_Tokens_List(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.before()
 
-			req := httptest.NewRequest(http.MethodGet, "/api/backoffice/tokens", nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/backoffice/tokens"+tt.query, nil)
 			w := httptest.NewRecorder()
 
 			r := chi.NewRouter()
@@ -171,10 +204,11 @@ func Test_Tokens_List(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.PaginationResponse[serializers.TokenSerializer]
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -184,7 +218,8 @@ func Test_Tokens_List(t *testing.T) {
 					assert.Equal(t, tt.expected.response.Data[i].ID, item.ID)
 					assert.Equal(t, tt.expected.response.Data[i].UserId, item.UserId)
 					assert.Equal(t, tt.expected.response.Data[i].Type, item.Type)
-					assert.Equal(t, tt.expected.response.Data[i].Value, item.Value)
+					assert.Equal(t, tt.expected.response.Data[i].Fingerprint, item.Fingerprint)
+					assert.Equal(t, tt.expected.response.Data[i].Metadata, item.Metadata)
 					assert.Equal(t, tt.expected.response.Data[i].ExpiresAt.Unix(), item.ExpiresAt.Unix())
 				}
 				assert.Equal(t, tt.expected.response.Meta, response.Meta)
@@ -208,10 +243,21 @@ func Test_Tokens_Delete(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	tokens := services.NewMockTokens(ctrl)
-	controller := NewTokensController(tokens, log)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewTokensController(tokens, audit, paginator, log)
+
+	id := uuid.MustParse("10000000-1000-1000-6000-000000000001")
+	token := &models.Token{
+		ID:          id,
+		UserId:      uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+		Type:        models.AccessTokenType,
+		Value:       "access-token",
+		Fingerprint: "fingerprint",
+	}
 
 	type result struct {
-		error  serializers.ErrorSerializer
+		detail string
 		status string
 		code   int
 	}
@@ -225,7 +271,27 @@ func Test_Tokens_Delete(t *testing.T) {
 		{
 			name: "Success",
 			before: func() {
-				tokens.EXPECT().Delete(gomock.Any(), uuid.MustParse("10000000-1000-1000-6000-000000000001")).Return(true, nil)
+				tokens.EXPECT().FindById(gomock.Any(), id).Return(token, nil)
+				tokens.EXPECT().Delete(gomock.Any(), id).Return(true, nil)
+				audit.EXPECT().Record(gomock.Any(), models.DeleteActionType, models.TokenResourceType, id, models.TokenAuditSnapshot{
+					ID:          id,
+					UserID:      token.UserId,
+					Type:        models.AccessTokenType,
+					Fingerprint: "fingerprint",
+				}, nil).Return(nil)
+			},
+			expected: result{
+				status: "204 No Content",
+				code:   http.StatusNoContent,
+			},
+			error: false,
+		},
+		{
+			name: "Audit failure",
+			before: func() {
+				tokens.EXPECT().FindById(gomock.Any(), id).Return(token, nil)
+				tokens.EXPECT().Delete(gomock.Any(), id).Return(true, nil)
+				audit.EXPECT().Record(gomock.Any(), models.DeleteActionType, models.TokenResourceType, id, gomock.Any(), nil).Return(assert.AnError)
 			},
 			expected: result{
 				status: "204 No Content",
@@ -236,10 +302,11 @@ func Test_Tokens_Delete(t *testing.T) {
 		{
 			name: "Invalid arguments",
 			before: func() {
+				tokens.EXPECT().FindById(gomock.Any(), id).Return(token, nil)
 				tokens.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(false, errors.ErrInvalidArguments)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -248,10 +315,10 @@ func Test_Tokens_Delete(t *testing.T) {
 		{
 			name: "Not found",
 			before: func() {
-				tokens.EXPECT().Delete(gomock.Any(), uuid.MustParse("10000000-1000-1000-6000-000000000001")).Return(false, errors.ErrRecordNotFound)
+				tokens.EXPECT().FindById(gomock.Any(), id).Return(nil, errors.ErrRecordNotFound)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: errors.ErrRecordNotFound.Error()},
+				detail: errors.ErrRecordNotFound.Error(),
 				status: "404 Not Found",
 				code:   http.StatusNotFound,
 			},
@@ -259,21 +326,23 @@ func Test_Tokens_Delete(t *testing.T) {
 		{
 			name: "Bad request",
 			before: func() {
-				tokens.EXPECT().Delete(gomock.Any(), uuid.MustParse("10000000-1000-1000-6000-000000000001")).Return(false, errors.ErrFailedToDeleteRecord)
+				tokens.EXPECT().FindById(gomock.Any(), id).Return(token, nil)
+				tokens.EXPECT().Delete(gomock.Any(), id).Return(false, errors.ErrFailedToDeleteRecord)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to delete record"},
-				status: "422 Unprocessable Entity",
-				code:   http.StatusUnprocessableEntity,
+				detail: "failed to delete record",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 		},
 		{
 			name: "Error",
 			before: func() {
-				tokens.EXPECT().Delete(gomock.Any(), uuid.MustParse("10000000-1000-1000-6000-000000000001")).Return(false, assert.AnError)
+				tokens.EXPECT().FindById(gomock.Any(), id).Return(token, nil)
+				tokens.EXPECT().Delete(gomock.Any(), id).Return(false, assert.AnError)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -296,12 +365,325 @@ func Test_Tokens_Delete(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
+			}
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+			assert.Equal(t, tt.expected.status, resp.Status)
+		})
+	}
+}
+
+func Test_Tokens_RevokeAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	tokens := services.NewMockTokens(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewTokensController(tokens, audit, paginator, log)
+
+	userId := uuid.MustParse("10000000-1000-1000-1234-000000000001")
+	expiresAt := time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	accessToken := models.Token{
+		ID:        uuid.MustParse("10000000-1000-1000-6000-000000000001"),
+		UserId:    userId,
+		Type:      models.AccessTokenType,
+		ExpiresAt: expiresAt,
+	}
+	refreshToken := models.Token{
+		ID:        uuid.MustParse("10000000-1000-1000-6000-000000000002"),
+		UserId:    userId,
+		Type:      models.RefreshTokenType,
+		ExpiresAt: expiresAt,
+	}
+
+	type result struct {
+		response serializers.TokenRevocationSerializer
+		detail   string
+		status   string
+		code     int
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		before   func()
+		expected result
+		error    bool
+	}{
+		{
+			name: "Success",
+			path: "/api/backoffice/users/10000000-1000-1000-1234-000000000001/tokens",
+			before: func() {
+				tokens.EXPECT().RevokeAll(gomock.Any(), userId, "").Return(&models.TokenRevocation{
+					UserId:  userId,
+					Revoked: []models.Token{accessToken, refreshToken},
+					Failed:  []models.TokenRevocationFailure{},
+				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.RevokeTokensActionType, models.UserResourceType, userId, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expected: result{
+				response: serializers.TokenRevocationSerializer{
+					UserId: userId,
+					Revoked: []serializers.RevokedTokenSerializer{
+						{ID: accessToken.ID, Type: models.AccessTokenType, ExpiresAt: expiresAt},
+						{ID: refreshToken.ID, Type: models.RefreshTokenType, ExpiresAt: expiresAt},
+					},
+					Failed: []serializers.FailedRevocationSerializer{},
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name: "Partial failure with type filter",
+			path: "/api/backoffice/users/10000000-1000-1000-1234-000000000001/tokens?type=refresh_token",
+			before: func() {
+				tokens.EXPECT().RevokeAll(gomock.Any(), userId, models.RefreshTokenType).Return(&models.TokenRevocation{
+					UserId:  userId,
+					Revoked: []models.Token{},
+					Failed: []models.TokenRevocationFailure{
+						{Token: refreshToken, Error: errors.ErrFailedToDeleteRecord},
+					},
+				}, nil)
+			},
+			expected: result{
+				response: serializers.TokenRevocationSerializer{
+					UserId:  userId,
+					Revoked: []serializers.RevokedTokenSerializer{},
+					Failed: []serializers.FailedRevocationSerializer{
+						{ID: refreshToken.ID, Type: models.RefreshTokenType, Error: "failed to delete record"},
+					},
+				},
+				status: "207 Multi-Status",
+				code:   http.StatusMultiStatus,
+			},
+		},
+		{
+			name: "Invalid user id",
+			path: "/api/backoffice/users/invalid/tokens",
+			before: func() {
+				tokens.EXPECT().RevokeAll(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expected: result{
+				detail: "invalid arguments",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name: "Invalid type",
+			path: "/api/backoffice/users/10000000-1000-1000-1234-000000000001/tokens?type=id_token",
+			before: func() {
+				tokens.EXPECT().RevokeAll(gomock.Any(), userId, "id_token").Return(nil, errors.ErrInvalidArguments)
+			},
+			expected: result{
+				detail: "invalid arguments",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name: "Service unavailable",
+			path: "/api/backoffice/users/10000000-1000-1000-1234-000000000001/tokens",
+			before: func() {
+				tokens.EXPECT().RevokeAll(gomock.Any(), userId, "").Return(nil, errors.ErrFailedToFetchResults)
+			},
+			expected: result{
+				detail: "failed to fetch results",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
+			},
+			error: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Delete("/api/backoffice/users/{id}/tokens", controller.RevokeAll)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			if tt.error {
+				var response serializers.ProblemSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
+			} else {
+				var response serializers.TokenRevocationSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.response, response)
+			}
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+			assert.Equal(t, tt.expected.status, resp.Status)
+		})
+	}
+}
+
+func Test_Tokens_Reveal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	tokens := services.NewMockTokens(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewTokensController(tokens, audit, paginator, log)
+
+	id := uuid.MustParse("10000000-1000-1000-6000-000000000001")
+	token := &models.Token{
+		ID:          id,
+		UserId:      uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+		Type:        models.AccessTokenType,
+		Value:       "access-token-value",
+		Fingerprint: "889813cbebaf2f8a",
+	}
+
+	type result struct {
+		response serializers.TokenValueSerializer
+		detail   string
+		status   string
+		code     int
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		before   func()
+		expected result
+		error    bool
+	}{
+		{
+			name: "Success",
+			path: "/api/backoffice/tokens/10000000-1000-1000-6000-000000000001/value",
+			before: func() {
+				tokens.EXPECT().FindById(gomock.Any(), id).Return(token, nil)
+				audit.EXPECT().Record(gomock.Any(), models.RevealActionType, models.TokenResourceType, id, nil, gomock.Any()).Return(nil)
+			},
+			expected: result{
+				response: serializers.TokenValueSerializer{
+					ID:    id,
+					Value: "access-token-value",
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name: "Audit failure",
+			path: "/api/backoffice/tokens/10000000-1000-1000-6000-000000000001/value",
+			before: func() {
+				tokens.EXPECT().FindById(gomock.Any(), id).Return(token, nil)
+				audit.EXPECT().Record(gomock.Any(), models.RevealActionType, models.TokenResourceType, id, nil, gomock.Any()).Return(assert.AnError)
+			},
+			expected: result{
+				detail: assert.AnError.Error(),
+				status: "500 Internal Server Error",
+				code:   http.StatusInternalServerError,
+			},
+			error: true,
+		},
+		{
+			name: "Invalid id",
+			path: "/api/backoffice/tokens/invalid/value",
+			before: func() {
+				tokens.EXPECT().FindById(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expected: result{
+				detail: "invalid arguments",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name: "Not found",
+			path: "/api/backoffice/tokens/10000000-1000-1000-6000-000000000001/value",
+			before: func() {
+				tokens.EXPECT().FindById(gomock.Any(), id).Return(nil, errors.ErrRecordNotFound)
+			},
+			expected: result{
+				detail: errors.ErrRecordNotFound.Error(),
+				status: "404 Not Found",
+				code:   http.StatusNotFound,
+			},
+			error: true,
+		},
+		{
+			name: "Service unavailable",
+			path: "/api/backoffice/tokens/10000000-1000-1000-6000-000000000001/value",
+			before: func() {
+				tokens.EXPECT().FindById(gomock.Any(), id).Return(nil, errors.ErrFailedToFetchResults)
+			},
+			expected: result{
+				detail: "failed to fetch results",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
+			},
+			error: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Get("/api/backoffice/tokens/{id}/value", controller.Reveal)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			if tt.error {
+				var response serializers.ProblemSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
+			} else {
+				var response serializers.TokenValueSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.response, response)
 			}
 
+			assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
 			assert.Equal(t, tt.expected.code, resp.StatusCode)
 			assert.Equal(t, tt.expected.status, resp.Status)
 		})
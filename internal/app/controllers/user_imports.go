@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/models/dto"
+	"loki-backoffice/internal/app/serializers"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config/logger"
+)
+
+type UserImportsController interface {
+	Preview(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request)
+	Confirm(w http.ResponseWriter, r *http.Request)
+}
+
+type userImportsController struct {
+	imports services.UserImports
+	log     *logger.Logger
+}
+
+func NewUserImportsController(imports services.UserImports, log *logger.Logger) UserImportsController {
+	return &userImportsController{
+		imports: imports,
+		log:     log,
+	}
+}
+
+// Preview validates a CSV file of users and stores it until confirmed
+func (c *userImportsController) Preview(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var params dto.UserImportRequest
+	if err := params.Parse(r.Header.Get("Content-Type"), r.Body); err != nil {
+		c.log.Error().Err(err).Msg("Failed to parse user import")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	record := &models.UserImport{
+		Rows:      make([]models.UserImportRow, 0, len(params.Rows)),
+		HasRoles:  params.HasRoles,
+		HasScopes: params.HasScopes,
+	}
+	for _, row := range params.Rows {
+		record.Rows = append(record.Rows, models.UserImportRow{
+			Line: row.Line,
+			User: models.User{
+				IdentityNumber: row.IdentityNumber,
+				PersonalCode:   row.PersonalCode,
+				FirstName:      row.FirstName,
+				LastName:       row.LastName,
+			},
+			Roles:  row.Roles,
+			Scopes: row.Scopes,
+			Errors: row.Errors,
+		})
+	}
+
+	result, err := c.imports.Preview(r.Context(), record)
+	if err != nil {
+		c.log.Error().Err(err).Msg("Failed to preview user import")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", r.URL.Path+"/"+result.ID.String())
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(userImportSerializer(r, result))
+}
+
+// Get reports the preview of an import or the outcome of every row once it ran
+func (c *userImportsController) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	result, err := c.imports.Find(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get user import")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(userImportSerializer(r, result))
+}
+
+// Confirm applies a previewed import in the background, the import is polled through Get
+func (c *userImportsController) Confirm(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	result, err := c.imports.Confirm(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to confirm user import")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.log.Info().Str("id", id.String()).Int("rows", len(result.Rows)).Msg("Started user import")
+
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(userImportSerializer(r, result))
+}
+
+func userImportSerializer(r *http.Request, record *models.UserImport) serializers.UserImportSerializer {
+	result := serializers.UserImportSerializer{
+		ID:        record.ID,
+		Status:    record.Status,
+		Rows:      make([]serializers.UserImportRowSerializer, 0, len(record.Rows)),
+		CreatedAt: record.CreatedAt,
+		ExpiresAt: record.ExpiresAt,
+	}
+
+	for _, row := range record.Rows {
+		item := serializers.UserImportRowSerializer{
+			Line:           row.Line,
+			Action:         row.Action,
+			IdentityNumber: row.User.IdentityNumber,
+			PersonalCode:   row.User.PersonalCode,
+			FirstName:      row.User.FirstName,
+			LastName:       row.User.LastName,
+			Roles:          nonNil(row.Roles),
+			Scopes:         nonNil(row.Scopes),
+			RoleIDs:        nonNil(row.User.RoleIDs),
+			ScopeIDs:       nonNil(row.User.ScopeIDs),
+			State:          row.State,
+		}
+
+		if row.User.ID != uuid.Nil {
+			id := row.User.ID
+			item.UserID = &id
+		}
+
+		for _, field := range row.Errors {
+			item.Errors = append(item.Errors, serializers.FieldErrorSerializer{
+				Pointer: field.Pointer,
+				Code:    field.Code,
+				Detail:  field.Detail,
+			})
+		}
+
+		if row.Err != nil {
+			problem := serializers.NewProblem(r, row.Err)
+			item.Error = &problem
+		}
+
+		switch {
+		case len(row.Errors) > 0:
+			result.Meta.Invalid++
+		case row.Action == models.UpdateActionType:
+			result.Meta.Update++
+		default:
+			result.Meta.Create++
+		}
+
+		switch row.State {
+		case services.BulkAppliedState:
+			result.Meta.Succeeded++
+		case services.BulkFailedState:
+			result.Meta.Failed++
+		}
+
+		result.Rows = append(result.Rows, item)
+	}
+
+	result.Meta.Total = len(result.Rows)
+
+	return result
+}
+
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+
+	return items
+}
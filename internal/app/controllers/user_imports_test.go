@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/serializers"
+	"loki-backoffice/internal/app/services"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func Test_UserImports_Preview(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	imports := services.NewMockUserImports(ctrl)
+	controller := NewUserImportsController(imports, log)
+
+	id := uuid.MustParse("10000000-1000-1000-5000-000000000001")
+	roleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+
+	type result struct {
+		meta     serializers.UserImportMetaSerializer
+		location string
+		code     int
+	}
+
+	tests := []struct {
+		name        string
+		before      func()
+		contentType string
+		body        string
+		expected    result
+	}{
+		{
+			name: "Success",
+			before: func() {
+				imports.EXPECT().Preview(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, params *models.UserImport) (*models.UserImport, error) {
+						assert.True(t, params.HasRoles)
+						assert.Len(t, params.Rows, 2)
+
+						params.ID = id
+						params.Status = models.PendingImportStatus
+						params.Rows[0].Action = models.CreateActionType
+						params.Rows[0].User.RoleIDs = []uuid.UUID{roleId}
+						params.Rows[1].Action = models.CreateActionType
+						return params, nil
+					})
+			},
+			contentType: "text/csv",
+			body: "identity_number,personal_code,first_name,last_name,roles\n" +
+				"PNOEE-60001017869,60001017869,John,Doe,admin\n" +
+				"PNOEE-60001017869,60001017869,John,Doe,admin\n",
+			expected: result{
+				meta:     serializers.UserImportMetaSerializer{Total: 2, Invalid: 1, Create: 1},
+				location: "/api/backoffice/users/import/" + id.String(),
+				code:     http.StatusCreated,
+			},
+		},
+		{
+			name: "Unsupported media type",
+			before: func() {
+				imports.EXPECT().Preview(gomock.Any(), gomock.Any()).Times(0)
+			},
+			contentType: "application/json",
+			body:        `{}`,
+			expected:    result{code: http.StatusUnsupportedMediaType},
+		},
+		{
+			name: "Lookup error",
+			before: func() {
+				imports.EXPECT().Preview(gomock.Any(), gomock.Any()).Return(nil, errors.ErrUnavailable)
+			},
+			contentType: "text/csv",
+			body:        "identity_number,personal_code,first_name,last_name\nPNOEE-60001017869,60001017869,John,Doe\n",
+			expected:    result{code: http.StatusServiceUnavailable},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/backoffice/users/import", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Post("/api/backoffice/users/import", controller.Preview)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+
+			if tt.expected.code == http.StatusCreated {
+				var response serializers.UserImportSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.meta, response.Meta)
+				assert.Equal(t, tt.expected.location, resp.Header.Get("Location"))
+				assert.Equal(t, []uuid.UUID{roleId}, response.Rows[0].RoleIDs)
+				assert.Equal(t, "duplicate", response.Rows[1].Errors[0].Code)
+			}
+		})
+	}
+}
+
+func Test_UserImports_Confirm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	imports := services.NewMockUserImports(ctrl)
+	controller := NewUserImportsController(imports, log)
+
+	id := uuid.MustParse("10000000-1000-1000-5000-000000000001")
+
+	tests := []struct {
+		name     string
+		before   func()
+		id       string
+		expected int
+	}{
+		{
+			name: "Success",
+			before: func() {
+				imports.EXPECT().Confirm(gomock.Any(), id).Return(&models.UserImport{ID: id, Status: models.RunningImportStatus}, nil)
+			},
+			id:       id.String(),
+			expected: http.StatusAccepted,
+		},
+		{
+			name: "Invalid rows",
+			before: func() {
+				imports.EXPECT().Confirm(gomock.Any(), id).Return(nil, fmt.Errorf("%w: import has 1 invalid rows", errors.ErrFailedPrecondition))
+			},
+			id:       id.String(),
+			expected: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Not found",
+			before: func() {
+				imports.EXPECT().Confirm(gomock.Any(), id).Return(nil, errors.ErrRecordNotFound)
+			},
+			id:       id.String(),
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "Invalid UUID",
+			before:   func() {},
+			id:       "invalid",
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/backoffice/users/import/"+tt.id+"/confirm", nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Post("/api/backoffice/users/import/{id}/confirm", controller.Confirm)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expected, resp.StatusCode)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package controllers
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 
@@ -20,18 +22,36 @@ type UsersController interface {
 	Get(w http.ResponseWriter, r *http.Request)
 	Create(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
+	Patch(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Bulk(w http.ResponseWriter, r *http.Request)
+	EffectivePermissions(w http.ResponseWriter, r *http.Request)
 }
 
 type usersController struct {
-	users services.Users
-	log   *logger.Logger
+	users                services.Users
+	effectivePermissions services.EffectivePermissions
+	index                services.Index
+	audit                services.Audit
+	paginator            services.Paginator
+	log                  *logger.Logger
 }
 
-func NewUsersController(users services.Users, log *logger.Logger) UsersController {
+func NewUsersController(
+	users services.Users,
+	effectivePermissions services.EffectivePermissions,
+	index services.Index,
+	audit services.Audit,
+	paginator services.Paginator,
+	log *logger.Logger,
+) UsersController {
 	return &usersController{
-		users: users,
-		log:   log,
+		users:                users,
+		effectivePermissions: effectivePermissions,
+		index:                index,
+		audit:                audit,
+		paginator:            paginator,
+		log:                  log,
 	}
 }
 
@@ -39,19 +59,23 @@ func NewUsersController(users services.Users, log *logger.Logger) UsersControlle
 func (c *usersController) List(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	pagination := services.NewPagination(r)
-	rows, total, err := c.users.List(r.Context(), pagination)
+	query, err := services.NewQuery(r, services.UsersQuerySchema)
 	if err != nil {
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrFailedToFetchResults):
-			w.WriteHeader(http.StatusServiceUnavailable)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+		c.log.Error().Err(err).Str("query", r.URL.RawQuery).Msg("Invalid list query")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
 
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+	pagination, err := c.paginator.Paginate(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("cursor", r.URL.Query().Get(services.CursorParam)).Msg("Invalid pagination cursor")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	rows, total, err := c.users.List(r.Context(), pagination, query)
+	if err != nil {
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -73,6 +97,8 @@ func (c *usersController) List(w http.ResponseWriter, r *http.Request) {
 			Page:  pagination.Page,
 			Per:   pagination.PerPage,
 			Total: total,
+			Next:  c.paginator.Next(r, pagination),
+			Prev:  c.paginator.Prev(r, pagination),
 		},
 	}
 
@@ -87,8 +113,7 @@ func (c *usersController) Get(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
 		return
 	}
 
@@ -97,14 +122,7 @@ func (c *usersController) Get(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get user")
 
-		switch {
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -118,6 +136,13 @@ func (c *usersController) Get(w http.ResponseWriter, r *http.Request) {
 		ScopeIDs:       record.ScopeIDs,
 	}
 
+	etag := serializers.ETag(response)
+	w.Header().Set(ETagHeader, etag)
+	if ifNoneMatch(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -129,8 +154,7 @@ func (c *usersController) Create(w http.ResponseWriter, r *http.Request) {
 	var params dto.UserRequest
 	if err := params.Validate(r.Body); err != nil {
 		c.log.Error().Err(err).Str("identity_number", params.IdentityNumber).Msg("Failed to create user")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -145,16 +169,7 @@ func (c *usersController) Create(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Msg("Failed to create user")
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrFailedToCreateRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -166,6 +181,12 @@ func (c *usersController) Create(w http.ResponseWriter, r *http.Request) {
 		LastName:       record.LastName,
 	}
 
+	after := *record
+	after.RoleIDs = params.RoleIDs
+	after.ScopeIDs = params.ScopeIDs
+	c.index.Invalidate()
+	services.RecordApplied(r.Context(), c.audit, c.log, models.CreateActionType, models.UserResourceType, record.ID, nil, after.AuditSnapshot())
+
 	w.WriteHeader(http.StatusCreated)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -177,19 +198,77 @@ func (c *usersController) Update(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
 		return
 	}
 
 	var params dto.UserRequest
 	if err = params.Validate(r.Body); err != nil {
 		c.log.Error().Err(err).Str("identity_number", params.IdentityNumber).Msg("Failed to create user")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
+	before, err := c.users.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Failed to load user state for audit")
+		before = nil
+	}
+
+	if err = ifMatch(r, userSerializer(before), err); err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Rejected conditional user write")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.update(w, r, id, &params, before)
+}
+
+// Patch applies a merge patch or JSON patch document to the user
+//
+//nolint:dupl
+func (c *usersController) Patch(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	before, err := c.users.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to find user")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	if err = ifMatch(r, userSerializer(before), nil); err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Rejected conditional user write")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	params := dto.UserRequest{
+		IdentityNumber: before.IdentityNumber,
+		PersonalCode:   before.PersonalCode,
+		FirstName:      before.FirstName,
+		LastName:       before.LastName,
+		RoleIDs:        before.RoleIDs,
+		ScopeIDs:       before.ScopeIDs,
+	}
+	if err = params.Patch(r.Header.Get("Content-Type"), http.MaxBytesReader(w, r.Body, dto.MaxPatchBodySize)); err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to patch user")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.update(w, r, id, &params, before)
+}
+
+// update stores params over the user, before is the state recorded in the audit log
+func (c *usersController) update(w http.ResponseWriter, r *http.Request, id uuid.UUID, params *dto.UserRequest, before *models.User) {
 	record, err := c.users.Update(r.Context(), &models.User{
 		ID:             id,
 		IdentityNumber: params.IdentityNumber,
@@ -202,18 +281,7 @@ func (c *usersController) Update(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to update user")
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		case errors.Is(err, errors.ErrFailedToUpdateRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
-
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -225,6 +293,12 @@ func (c *usersController) Update(w http.ResponseWriter, r *http.Request) {
 		LastName:       record.LastName,
 	}
 
+	after := *record
+	after.RoleIDs = params.RoleIDs
+	after.ScopeIDs = params.ScopeIDs
+	c.index.Invalidate()
+	services.RecordApplied(r.Context(), c.audit, c.log, models.UpdateActionType, models.UserResourceType, id, before.AuditSnapshot(), after.AuditSnapshot())
+
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -236,8 +310,19 @@ func (c *usersController) Delete(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Invalid UUID format")
-		w.WriteHeader(http.StatusBadRequest)
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrInvalidArguments.Error()})
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+		return
+	}
+
+	before, err := c.users.FindById(r.Context(), id)
+	if err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Failed to load user state for audit")
+		before = nil
+	}
+
+	if err = ifMatch(r, userSerializer(before), err); err != nil {
+		c.log.Warn().Err(err).Str("id", id.String()).Msg("Rejected conditional user write")
+		serializers.WriteProblem(w, r, err)
 		return
 	}
 
@@ -245,20 +330,240 @@ func (c *usersController) Delete(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to delete user")
 
-		switch {
-		case errors.Is(err, errors.ErrInvalidArguments):
-			w.WriteHeader(http.StatusBadRequest)
-		case errors.Is(err, errors.ErrRecordNotFound):
-			w.WriteHeader(http.StatusNotFound)
-		case errors.Is(err, errors.ErrFailedToDeleteRecord):
-			w.WriteHeader(http.StatusUnprocessableEntity)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	c.index.Invalidate()
+	services.RecordApplied(r.Context(), c.audit, c.log, models.DeleteActionType, models.UserResourceType, id, before.AuditSnapshot(), nil)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Bulk creates, updates and deletes users in one request and reports the outcome of every operation
+//
+//nolint:dupl
+func (c *usersController) Bulk(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	options, err := services.NewBulkOptions(r)
+	if err != nil {
+		c.log.Error().Err(err).Str("query", r.URL.RawQuery).Msg("Invalid bulk options")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	var params dto.BulkRequest
+	if err = params.Validate(r.Body); err != nil {
+		c.log.Error().Err(err).Msg("Invalid bulk user request")
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	operations := make([]services.BulkOperation, 0, len(params))
+	for _, item := range params {
+		operations = append(operations, c.bulkOperation(item))
+	}
+
+	results := services.RunBulk(r.Context(), operations, options)
+	c.index.Invalidate()
+
+	writeBulk(w, r, results, options)
+}
+
+// bulkOperation binds an operation of a bulk request to the users service, every applied
+// operation is audited and knows how to undo itself for atomic requests
+func (c *usersController) bulkOperation(item dto.BulkOperationRequest) services.BulkOperation {
+	operation := services.BulkOperation{Op: item.Op, ID: item.ID}
+
+	var params dto.UserRequest
+	if item.Op != models.DeleteActionType {
+		if operation.Err = params.Validate(bytes.NewReader(item.Data)); operation.Err != nil {
+			return operation
+		}
+	}
+
+	// upstream does not echo role and scope ids back, the stored state is the requested one
+	create := func(ctx context.Context, params *models.User) (*models.User, error) {
+		record, err := c.users.Create(ctx, params)
+		if err != nil {
+			c.log.Error().Err(err).Str("identity_number", params.IdentityNumber).Msg("Failed to create user")
+			return nil, err
 		}
 
-		_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+		after := *record
+		after.RoleIDs = params.RoleIDs
+		after.ScopeIDs = params.ScopeIDs
+		services.RecordApplied(ctx, c.audit, c.log, models.CreateActionType, models.UserResourceType, record.ID, nil, after.AuditSnapshot())
+		return &after, nil
+	}
+
+	update := func(ctx context.Context, params, before *models.User) (*models.User, error) {
+		record, err := c.users.Update(ctx, params)
+		if err != nil {
+			c.log.Error().Err(err).Str("id", params.ID.String()).Msg("Failed to update user")
+			return nil, err
+		}
+
+		after := *record
+		after.RoleIDs = params.RoleIDs
+		after.ScopeIDs = params.ScopeIDs
+		services.RecordApplied(ctx, c.audit, c.log, models.UpdateActionType, models.UserResourceType, params.ID, before.AuditSnapshot(), after.AuditSnapshot())
+		return &after, nil
+	}
+
+	remove := func(ctx context.Context, before *models.User) error {
+		if _, err := c.users.Delete(ctx, before.ID); err != nil {
+			c.log.Error().Err(err).Str("id", before.ID.String()).Msg("Failed to delete user")
+			return err
+		}
+
+		services.RecordApplied(ctx, c.audit, c.log, models.DeleteActionType, models.UserResourceType, before.ID, before.AuditSnapshot(), nil)
+		return nil
+	}
+
+	switch item.Op {
+	case models.CreateActionType:
+		operation.Apply = func(ctx context.Context) (*services.BulkApplied, error) {
+			record, err := create(ctx, &models.User{
+				IdentityNumber: params.IdentityNumber,
+				PersonalCode:   params.PersonalCode,
+				FirstName:      params.FirstName,
+				LastName:       params.LastName,
+				RoleIDs:        params.RoleIDs,
+				ScopeIDs:       params.ScopeIDs,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return &services.BulkApplied{
+				ID:     record.ID,
+				Result: userSerializer(record),
+				Undo: func(ctx context.Context) error {
+					return remove(ctx, record)
+				},
+			}, nil
+		}
+	case models.UpdateActionType:
+		operation.Apply = func(ctx context.Context) (*services.BulkApplied, error) {
+			before, err := c.users.FindById(ctx, item.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			record, err := update(ctx, &models.User{
+				ID:             item.ID,
+				IdentityNumber: params.IdentityNumber,
+				PersonalCode:   params.PersonalCode,
+				FirstName:      params.FirstName,
+				LastName:       params.LastName,
+				RoleIDs:        params.RoleIDs,
+				ScopeIDs:       params.ScopeIDs,
+			}, before)
+			if err != nil {
+				return nil, err
+			}
+
+			return &services.BulkApplied{
+				ID:     record.ID,
+				Result: userSerializer(record),
+				Undo: func(ctx context.Context) error {
+					_, err := update(ctx, before, record)
+					return err
+				},
+			}, nil
+		}
+	case models.DeleteActionType:
+		operation.Apply = func(ctx context.Context) (*services.BulkApplied, error) {
+			before, err := c.users.FindById(ctx, item.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			if err = remove(ctx, before); err != nil {
+				return nil, err
+			}
+
+			// upstream assigns ids on create, a restored user comes back under a new id
+			return &services.BulkApplied{
+				ID: item.ID,
+				Undo: func(ctx context.Context) error {
+					_, err := create(ctx, &models.User{
+						IdentityNumber: before.IdentityNumber,
+						PersonalCode:   before.PersonalCode,
+						FirstName:      before.FirstName,
+						LastName:       before.LastName,
+						RoleIDs:        before.RoleIDs,
+						ScopeIDs:       before.ScopeIDs,
+					})
+					return err
+				},
+			}, nil
+		}
+	}
+
+	return operation
+}
+
+// EffectivePermissions lists every permission the user holds and the roles granting it
+func (c *usersController) EffectivePermissions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		c.log.Error().Err(err).Str("id", chi.URLParam(r, "id")).Msg("Invalid UUID format")
+		serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
 		return
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	result, err := c.effectivePermissions.Resolve(r.Context(), id)
+	if err != nil {
+		c.log.Error().Err(err).Str("id", id.String()).Msg("Failed to resolve effective permissions")
+
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	response := serializers.EffectivePermissionsSerializer{
+		UserID:      result.UserID,
+		Permissions: make([]serializers.EffectivePermissionSerializer, 0, len(result.Permissions)),
+	}
+
+	for _, item := range result.Permissions {
+		grantedBy := make([]serializers.RoleReferenceSerializer, 0, len(item.GrantedBy))
+		for _, role := range item.GrantedBy {
+			grantedBy = append(grantedBy, serializers.RoleReferenceSerializer{
+				ID:   role.ID,
+				Name: role.Name,
+			})
+		}
+
+		response.Permissions = append(response.Permissions, serializers.EffectivePermissionSerializer{
+			ID:          item.Permission.ID,
+			Name:        item.Permission.Name,
+			Description: item.Permission.Description,
+			GrantedBy:   grantedBy,
+		})
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// userSerializer is the response shape of a user, nil when there is none, so that If-Match compares against the ETag of Show
+func userSerializer(record *models.User) interface{} {
+	if record == nil {
+		return nil
+	}
+
+	return serializers.UserSerializer{
+		ID:             record.ID,
+		IdentityNumber: record.IdentityNumber,
+		PersonalCode:   record.PersonalCode,
+		FirstName:      record.FirstName,
+		LastName:       record.LastName,
+		RoleIDs:        record.RoleIDs,
+		ScopeIDs:       record.ScopeIDs,
+	}
 }
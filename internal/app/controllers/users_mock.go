@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/app/controllers/users.go
+// Source: users.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/app/controllers/users.go -destination=internal/app/controllers/users_mock.go -package=controllers
+//	mockgen -source=users.go -destination=users_mock.go -package=controllers
 //
 
 // Package controllers is a generated GoMock package.
@@ -40,6 +40,18 @@ func (m *MockUsersController) EXPECT() *MockUsersControllerMockRecorder {
 	return m.recorder
 }
 
+// Bulk mocks base method.
+func (m *MockUsersController) Bulk(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Bulk", w, r)
+}
+
+// Bulk indicates an expected call of Bulk.
+func (mr *MockUsersControllerMockRecorder) Bulk(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Bulk", reflect.TypeOf((*MockUsersController)(nil).Bulk), w, r)
+}
+
 // Create mocks base method.
 func (m *MockUsersController) Create(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -64,6 +76,18 @@ func (mr *MockUsersControllerMockRecorder) Delete(w, r any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUsersController)(nil).Delete), w, r)
 }
 
+// EffectivePermissions mocks base method.
+func (m *MockUsersController) EffectivePermissions(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EffectivePermissions", w, r)
+}
+
+// EffectivePermissions indicates an expected call of EffectivePermissions.
+func (mr *MockUsersControllerMockRecorder) EffectivePermissions(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EffectivePermissions", reflect.TypeOf((*MockUsersController)(nil).EffectivePermissions), w, r)
+}
+
 // Get mocks base method.
 func (m *MockUsersController) Get(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -88,6 +112,18 @@ func (mr *MockUsersControllerMockRecorder) List(w, r any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUsersController)(nil).List), w, r)
 }
 
+// Patch mocks base method.
+func (m *MockUsersController) Patch(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Patch", w, r)
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockUsersControllerMockRecorder) Patch(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockUsersController)(nil).Patch), w, r)
+}
+
 // Update mocks base method.
 func (m *MockUsersController) Update(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
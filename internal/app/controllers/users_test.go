@@ -15,6 +15,7 @@ import (
 
 	"loki-backoffice/internal/app/errors"
 	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/models/dto"
 	"loki-backoffice/internal/app/serializers"
 	"loki-backoffice/internal/app/services"
 	"loki-backoffice/internal/config"
@@ -33,17 +34,23 @@ func Test_Users_List(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	users := services.NewMockUsers(ctrl)
-	controller := NewUsersController(users, log)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	effectivePermissions := services.NewMockEffectivePermissions(ctrl)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	controller := NewUsersController(users, effectivePermissions, index, audit, paginator, log)
 
 	type result struct {
 		response serializers.PaginationResponse[serializers.UserSerializer]
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
 
 	tests := []struct {
 		name     string
+		query    string
 		before   func()
 		expected result
 		error    bool
@@ -51,7 +58,7 @@ func Test_Users_List(t *testing.T) {
 		{
 			name: "Success",
 			before: func() {
-				users.EXPECT().List(gomock.Any(), gomock.Any()).Return([]models.User{
+				users.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.User{
 					{
 						ID:             uuid.MustParse("10000000-1000-1000-1234-000000000001"),
 						IdentityNumber: "PNOEE-60001017869",
@@ -100,7 +107,7 @@ func Test_Users_List(t *testing.T) {
 		{
 			name: "Empty",
 			before: func() {
-				users.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), nil)
+				users.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), nil)
 			},
 			expected: result{
 				response: serializers.PaginationResponse[serializers.UserSerializer]{
@@ -116,13 +123,39 @@ func Test_Users_List(t *testing.T) {
 			},
 			error: false,
 		},
+		{
+			name:  "Invalid query",
+			query: "?sort=-password",
+			before: func() {
+				users.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expected: result{
+				detail: "invalid arguments",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name:  "Invalid cursor",
+			query: "?cursor=invalid",
+			before: func() {
+				users.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			expected: result{
+				detail: "invalid cursor",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
 		{
 			name: "Invalid params",
 			before: func() {
-				users.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrInvalidArguments)
+				users.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrInvalidArguments)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -131,22 +164,22 @@ func Test_Users_List(t *testing.T) {
 		{
 			name: "Bad request",
 			before: func() {
-				users.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrFailedToFetchResults)
+				users.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), errors.ErrFailedToFetchResults)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to fetch results"},
-				status: "503 Service Unavailable",
-				code:   http.StatusServiceUnavailable,
+				detail: "failed to fetch results",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 			error: true,
 		},
 		{
 			name: "Error",
 			before: func() {
-				users.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, uint64(0), assert.AnError)
+				users.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, uint64(0), assert.AnError)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -158,7 +191,7 @@ func Test_Users_List(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.before()
 
-			req := httptest.NewRequest(http.MethodGet, "/api/backoffice/users", nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/backoffice/users"+tt.query, nil)
 			w := httptest.NewRecorder()
 
 			r := chi.NewRouter()
@@ -169,10 +202,11 @@ func Test_Users_List(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.PaginationResponse[serializers.UserSerializer]
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -198,7 +232,12 @@ func Test_Users_Get(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	users := services.NewMockUsers(ctrl)
-	controller := NewUsersController(users, log)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	effectivePermissions := services.NewMockEffectivePermissions(ctrl)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	controller := NewUsersController(users, effectivePermissions, index, audit, paginator, log)
 
 	id := uuid.MustParse("10000000-1000-1000-1234-000000000001")
 	roleIds := []uuid.UUID{
@@ -212,7 +251,7 @@ func Test_Users_Get(t *testing.T) {
 
 	type result struct {
 		response serializers.UserSerializer
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
@@ -257,7 +296,7 @@ func Test_Users_Get(t *testing.T) {
 				users.EXPECT().FindById(gomock.Any(), id).Return(&models.User{}, errors.ErrRecordNotFound)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: errors.ErrRecordNotFound.Error()},
+				detail: errors.ErrRecordNotFound.Error(),
 				status: "404 Not Found",
 				code:   http.StatusNotFound,
 			},
@@ -268,7 +307,7 @@ func Test_Users_Get(t *testing.T) {
 				users.EXPECT().FindById(gomock.Any(), id).Return(&models.User{}, assert.AnError)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -291,10 +330,11 @@ func Test_Users_Get(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.UserSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -320,13 +360,18 @@ func Test_Users_Create(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	users := services.NewMockUsers(ctrl)
-	controller := NewUsersController(users, log)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	effectivePermissions := services.NewMockEffectivePermissions(ctrl)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	controller := NewUsersController(users, effectivePermissions, index, audit, paginator, log)
 
 	id := uuid.MustParse("10000000-1000-1000-1234-000000000001")
 
 	type result struct {
 		response serializers.UserSerializer
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
@@ -353,6 +398,7 @@ func Test_Users_Create(t *testing.T) {
 					FirstName:      "EID2016",
 					LastName:       "TESTNUMBER",
 				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.CreateActionType, models.UserResourceType, id, nil, gomock.Any()).Return(nil)
 			},
 			body: strings.NewReader(`{"identity_number": "PNOEE-60001017869", "personal_code": "60001017869", "first_name": "EID2016", "last_name": "TESTNUMBER"}`),
 			expected: result{
@@ -375,7 +421,7 @@ func Test_Users_Create(t *testing.T) {
 			},
 			body: strings.NewReader(`{"identity_number": "PNOEE-60001017869"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "empty personal code"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -393,7 +439,7 @@ func Test_Users_Create(t *testing.T) {
 			},
 			body: strings.NewReader(`{"identity_number": "PNOEE-60001017869", "personal_code": "60001017869", "first_name": "EID2016", "last_name": "TESTNUMBER"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -410,9 +456,9 @@ func Test_Users_Create(t *testing.T) {
 			},
 			body: strings.NewReader(`{"identity_number": "PNOEE-60001017869", "personal_code": "60001017869", "first_name": "EID2016", "last_name": "TESTNUMBER"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to create record"},
-				status: "422 Unprocessable Entity",
-				code:   http.StatusUnprocessableEntity,
+				detail: "failed to create record",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 			error: true,
 		},
@@ -428,7 +474,7 @@ func Test_Users_Create(t *testing.T) {
 			},
 			body: strings.NewReader(`{"identity_number": "PNOEE-60001017869", "personal_code": "60001017869", "first_name": "EID2016", "last_name": "TESTNUMBER"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -451,10 +497,11 @@ func Test_Users_Create(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.UserSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -480,13 +527,18 @@ func Test_Users_Update(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	users := services.NewMockUsers(ctrl)
-	controller := NewUsersController(users, log)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	effectivePermissions := services.NewMockEffectivePermissions(ctrl)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	controller := NewUsersController(users, effectivePermissions, index, audit, paginator, log)
 
 	id := uuid.MustParse("10000000-1000-1000-1234-000000000001")
 
 	type result struct {
 		response serializers.UserSerializer
-		error    serializers.ErrorSerializer
+		detail   string
 		status   string
 		code     int
 	}
@@ -501,6 +553,7 @@ func Test_Users_Update(t *testing.T) {
 		{
 			name: "Success",
 			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(&models.User{ID: id}, nil)
 				users.EXPECT().Update(gomock.Any(), &models.User{
 					ID:             id,
 					IdentityNumber: "PNOEE-60001017869",
@@ -514,6 +567,7 @@ func Test_Users_Update(t *testing.T) {
 					FirstName:      "EID2016",
 					LastName:       "TESTNUMBER",
 				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.UserResourceType, id, gomock.Any(), gomock.Any()).Return(nil)
 			},
 			body: strings.NewReader(`{"identity_number": "PNOEE-60001017869", "personal_code": "60001017869", "first_name": "EID2016", "last_name": "TESTNUMBER"}`),
 			expected: result{
@@ -536,7 +590,7 @@ func Test_Users_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"identity_number": "PNOEE-60001017869"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "empty personal code"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -545,6 +599,7 @@ func Test_Users_Update(t *testing.T) {
 		{
 			name: "Invalid arguments",
 			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(&models.User{ID: id}, nil)
 				users.EXPECT().Update(gomock.Any(), &models.User{
 					ID:             id,
 					IdentityNumber: "PNOEE-60001017869",
@@ -555,7 +610,7 @@ func Test_Users_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"identity_number": "PNOEE-60001017869", "personal_code": "60001017869", "first_name": "EID2016", "last_name": "TESTNUMBER"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -564,6 +619,7 @@ func Test_Users_Update(t *testing.T) {
 		{
 			name: "Not found",
 			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(&models.User{ID: id}, nil)
 				users.EXPECT().Update(gomock.Any(), &models.User{
 					ID:             id,
 					IdentityNumber: "PNOEE-60001017869",
@@ -574,7 +630,7 @@ func Test_Users_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"identity_number": "PNOEE-60001017869", "personal_code": "60001017869", "first_name": "EID2016", "last_name": "TESTNUMBER"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: errors.ErrRecordNotFound.Error()},
+				detail: errors.ErrRecordNotFound.Error(),
 				status: "404 Not Found",
 				code:   http.StatusNotFound,
 			},
@@ -582,6 +638,7 @@ func Test_Users_Update(t *testing.T) {
 		{
 			name: "Bad request",
 			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(&models.User{ID: id}, nil)
 				users.EXPECT().Update(gomock.Any(), &models.User{
 					ID:             id,
 					IdentityNumber: "PNOEE-60001017869",
@@ -592,14 +649,15 @@ func Test_Users_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"identity_number": "PNOEE-60001017869", "personal_code": "60001017869", "first_name": "EID2016", "last_name": "TESTNUMBER"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to update record"},
-				status: "422 Unprocessable Entity",
-				code:   http.StatusUnprocessableEntity,
+				detail: "failed to update record",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 		},
 		{
 			name: "Error",
 			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(&models.User{ID: id}, nil)
 				users.EXPECT().Update(gomock.Any(), &models.User{
 					ID:             id,
 					IdentityNumber: "PNOEE-60001017869",
@@ -610,7 +668,7 @@ func Test_Users_Update(t *testing.T) {
 			},
 			body: strings.NewReader(`{"identity_number": "PNOEE-60001017869", "personal_code": "60001017869", "first_name": "EID2016", "last_name": "TESTNUMBER"}`),
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -633,10 +691,211 @@ func Test_Users_Update(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
+			} else {
+				var response serializers.UserSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.response, response)
+			}
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+			assert.Equal(t, tt.expected.status, resp.Status)
+		})
+	}
+}
+
+func Test_Users_Patch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	users := services.NewMockUsers(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	effectivePermissions := services.NewMockEffectivePermissions(ctrl)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	controller := NewUsersController(users, effectivePermissions, index, audit, paginator, log)
+
+	id := uuid.MustParse("10000000-1000-1000-1234-000000000001")
+	roleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	scopeId := uuid.MustParse("10000000-1000-1000-2000-000000000001")
+	otherScopeId := uuid.MustParse("10000000-1000-1000-2000-000000000002")
+
+	current := &models.User{
+		ID:             id,
+		IdentityNumber: "PNOEE-60001017869",
+		PersonalCode:   "60001017869",
+		FirstName:      "EID2016",
+		LastName:       "TESTNUMBER",
+		RoleIDs:        []uuid.UUID{roleId},
+		ScopeIDs:       []uuid.UUID{scopeId},
+	}
+
+	type result struct {
+		response serializers.UserSerializer
+		detail   string
+		status   string
+		code     int
+	}
+
+	tests := []struct {
+		name        string
+		before      func()
+		contentType string
+		body        io.Reader
+		expected    result
+		error       bool
+	}{
+		{
+			name: "Merge patch keeps assignments",
+			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(current, nil)
+				users.EXPECT().Update(gomock.Any(), &models.User{
+					ID:             id,
+					IdentityNumber: "PNOEE-60001017869",
+					PersonalCode:   "60001017869",
+					FirstName:      "John",
+					LastName:       "TESTNUMBER",
+					RoleIDs:        []uuid.UUID{roleId},
+					ScopeIDs:       []uuid.UUID{scopeId},
+				}).Return(&models.User{
+					ID:             id,
+					IdentityNumber: "PNOEE-60001017869",
+					PersonalCode:   "60001017869",
+					FirstName:      "John",
+					LastName:       "TESTNUMBER",
+				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.UserResourceType, id, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			contentType: "application/merge-patch+json",
+			body:        strings.NewReader(`{"first_name": "John"}`),
+			expected: result{
+				response: serializers.UserSerializer{
+					ID:             id,
+					IdentityNumber: "PNOEE-60001017869",
+					PersonalCode:   "60001017869",
+					FirstName:      "John",
+					LastName:       "TESTNUMBER",
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name: "JSON patch adds scope",
+			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(current, nil)
+				users.EXPECT().Update(gomock.Any(), &models.User{
+					ID:             id,
+					IdentityNumber: "PNOEE-60001017869",
+					PersonalCode:   "60001017869",
+					FirstName:      "EID2016",
+					LastName:       "TESTNUMBER",
+					RoleIDs:        []uuid.UUID{roleId},
+					ScopeIDs:       []uuid.UUID{scopeId, otherScopeId},
+				}).Return(&models.User{
+					ID:             id,
+					IdentityNumber: "PNOEE-60001017869",
+					PersonalCode:   "60001017869",
+					FirstName:      "EID2016",
+					LastName:       "TESTNUMBER",
+				}, nil)
+				audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.UserResourceType, id, gomock.Any(), gomock.Any()).Return(nil)
+			},
+			contentType: "application/json-patch+json",
+			body:        strings.NewReader(`[{"op": "add", "path": "/scope_ids/-", "value": "` + otherScopeId.String() + `"}]`),
+			expected: result{
+				response: serializers.UserSerializer{
+					ID:             id,
+					IdentityNumber: "PNOEE-60001017869",
+					PersonalCode:   "60001017869",
+					FirstName:      "EID2016",
+					LastName:       "TESTNUMBER",
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name: "Invalid type",
+			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(current, nil)
+				users.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+			},
+			contentType: "application/merge-patch+json",
+			body:        strings.NewReader(`{"first_name": 42}`),
+			expected: result{
+				detail: "invalid arguments",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name: "Unknown references",
+			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(current, nil)
+				users.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errors.ErrUnknownReferences)
+			},
+			contentType: "application/json-patch+json",
+			body:        strings.NewReader(`[{"op": "add", "path": "/role_ids/0", "value": "` + otherScopeId.String() + `"}]`),
+			expected: result{
+				detail: errors.ErrUnknownReferences.Error(),
+				status: "422 Unprocessable Entity",
+				code:   http.StatusUnprocessableEntity,
+			},
+			error: true,
+		},
+		{
+			name: "Body too large",
+			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(current, nil)
+				users.EXPECT().Update(gomock.Any(), gomock.Any()).Times(0)
+			},
+			contentType: "application/merge-patch+json",
+			body:        strings.NewReader(`{"first_name": "` + strings.Repeat("x", dto.MaxPatchBodySize) + `"}`),
+			expected: result{
+				detail: errors.ErrRequestTooLarge.Error(),
+				status: "413 Request Entity Too Large",
+				code:   http.StatusRequestEntityTooLarge,
+			},
+			error: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/backoffice/users/10000000-1000-1000-1234-000000000001", tt.body)
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Patch("/api/backoffice/users/{id}", controller.Patch)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			if tt.error {
+				var response serializers.ProblemSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			} else {
 				var response serializers.UserSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
@@ -662,12 +921,17 @@ func Test_Users_Delete(t *testing.T) {
 	log := logger.NewLogger(cfg)
 
 	users := services.NewMockUsers(ctrl)
-	controller := NewUsersController(users, log)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	effectivePermissions := services.NewMockEffectivePermissions(ctrl)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	controller := NewUsersController(users, effectivePermissions, index, audit, paginator, log)
 
 	id := uuid.MustParse("10000000-1000-1000-1234-000000000001")
 
 	type result struct {
-		error  serializers.ErrorSerializer
+		detail string
 		status string
 		code   int
 	}
@@ -681,7 +945,9 @@ func Test_Users_Delete(t *testing.T) {
 		{
 			name: "Success",
 			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(&models.User{ID: id}, nil)
 				users.EXPECT().Delete(gomock.Any(), id).Return(true, nil)
+				audit.EXPECT().Record(gomock.Any(), models.DeleteActionType, models.UserResourceType, id, gomock.Any(), nil).Return(nil)
 			},
 			expected: result{
 				status: "204 No Content",
@@ -692,10 +958,11 @@ func Test_Users_Delete(t *testing.T) {
 		{
 			name: "Invalid arguments",
 			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(&models.User{ID: id}, nil)
 				users.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(false, errors.ErrInvalidArguments)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "invalid arguments"},
+				detail: "invalid arguments",
 				status: "400 Bad Request",
 				code:   http.StatusBadRequest,
 			},
@@ -704,10 +971,11 @@ func Test_Users_Delete(t *testing.T) {
 		{
 			name: "Not found",
 			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(&models.User{ID: id}, nil)
 				users.EXPECT().Delete(gomock.Any(), id).Return(false, errors.ErrRecordNotFound)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: errors.ErrRecordNotFound.Error()},
+				detail: errors.ErrRecordNotFound.Error(),
 				status: "404 Not Found",
 				code:   http.StatusNotFound,
 			},
@@ -715,21 +983,23 @@ func Test_Users_Delete(t *testing.T) {
 		{
 			name: "Bad request",
 			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(&models.User{ID: id}, nil)
 				users.EXPECT().Delete(gomock.Any(), id).Return(false, errors.ErrFailedToDeleteRecord)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: "failed to delete record"},
-				status: "422 Unprocessable Entity",
-				code:   http.StatusUnprocessableEntity,
+				detail: "failed to delete record",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
 			},
 		},
 		{
 			name: "Error",
 			before: func() {
+				users.EXPECT().FindById(gomock.Any(), id).Return(&models.User{ID: id}, nil)
 				users.EXPECT().Delete(gomock.Any(), id).Return(false, assert.AnError)
 			},
 			expected: result{
-				error:  serializers.ErrorSerializer{Error: assert.AnError.Error()},
+				detail: assert.AnError.Error(),
 				status: "500 Internal Server Error",
 				code:   http.StatusInternalServerError,
 			},
@@ -752,10 +1022,11 @@ func Test_Users_Delete(t *testing.T) {
 			defer resp.Body.Close()
 
 			if tt.error {
-				var response serializers.ErrorSerializer
+				var response serializers.ProblemSerializer
 				err := json.NewDecoder(resp.Body).Decode(&response)
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expected.error, response)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
 			}
 
 			assert.Equal(t, tt.expected.code, resp.StatusCode)
@@ -763,3 +1034,198 @@ func Test_Users_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_Users_EffectivePermissions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	users := services.NewMockUsers(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	effectivePermissions := services.NewMockEffectivePermissions(ctrl)
+	index := services.NewMockIndex(ctrl)
+	index.EXPECT().Invalidate().AnyTimes()
+	controller := NewUsersController(users, effectivePermissions, index, audit, paginator, log)
+
+	userId := uuid.MustParse("10000000-1000-1000-1234-000000000001")
+	roleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	permissionId := uuid.MustParse("10000000-1000-1000-4000-000000000001")
+
+	type result struct {
+		response serializers.EffectivePermissionsSerializer
+		detail   string
+		status   string
+		code     int
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		before   func()
+		expected result
+		error    bool
+	}{
+		{
+			name: "Success",
+			path: "/api/backoffice/users/10000000-1000-1000-1234-000000000001/effective-permissions",
+			before: func() {
+				effectivePermissions.EXPECT().Resolve(gomock.Any(), userId).Return(&models.EffectivePermissions{
+					UserID: userId,
+					Permissions: []models.EffectivePermission{
+						{
+							Permission: models.Permission{ID: permissionId, Name: models.ReadSelfType, Description: "Read own data"},
+							GrantedBy:  []models.Role{{ID: roleId, Name: models.UserRoleType}},
+						},
+					},
+				}, nil)
+			},
+			expected: result{
+				response: serializers.EffectivePermissionsSerializer{
+					UserID: userId,
+					Permissions: []serializers.EffectivePermissionSerializer{
+						{
+							ID:          permissionId,
+							Name:        models.ReadSelfType,
+							Description: "Read own data",
+							GrantedBy:   []serializers.RoleReferenceSerializer{{ID: roleId, Name: models.UserRoleType}},
+						},
+					},
+				},
+				status: "200 OK",
+				code:   http.StatusOK,
+			},
+		},
+		{
+			name: "Invalid id",
+			path: "/api/backoffice/users/invalid/effective-permissions",
+			before: func() {
+				effectivePermissions.EXPECT().Resolve(gomock.Any(), gomock.Any()).Times(0)
+			},
+			expected: result{
+				detail: "invalid arguments",
+				status: "400 Bad Request",
+				code:   http.StatusBadRequest,
+			},
+			error: true,
+		},
+		{
+			name: "Not found",
+			path: "/api/backoffice/users/10000000-1000-1000-1234-000000000001/effective-permissions",
+			before: func() {
+				effectivePermissions.EXPECT().Resolve(gomock.Any(), userId).Return(nil, errors.ErrRecordNotFound)
+			},
+			expected: result{
+				detail: errors.ErrRecordNotFound.Error(),
+				status: "404 Not Found",
+				code:   http.StatusNotFound,
+			},
+			error: true,
+		},
+		{
+			name: "Service unavailable",
+			path: "/api/backoffice/users/10000000-1000-1000-1234-000000000001/effective-permissions",
+			before: func() {
+				effectivePermissions.EXPECT().Resolve(gomock.Any(), userId).Return(nil, errors.ErrFailedToFetchResults)
+			},
+			expected: result{
+				detail: "failed to fetch results",
+				status: "502 Bad Gateway",
+				code:   http.StatusBadGateway,
+			},
+			error: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+
+			r := chi.NewRouter()
+			r.Get("/api/backoffice/users/{id}/effective-permissions", controller.EffectivePermissions)
+			r.ServeHTTP(w, req)
+
+			resp := w.Result()
+			defer resp.Body.Close()
+
+			if tt.error {
+				var response serializers.ProblemSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.detail, response.Detail)
+				assert.Equal(t, tt.expected.code, response.Status)
+			} else {
+				var response serializers.EffectivePermissionsSerializer
+				err := json.NewDecoder(resp.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.response, response)
+			}
+
+			assert.Equal(t, tt.expected.code, resp.StatusCode)
+			assert.Equal(t, tt.expected.status, resp.Status)
+		})
+	}
+}
+
+func Test_Users_Create_UnknownReferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	users := services.NewMockUsers(ctrl)
+	index := services.NewMockIndex(ctrl)
+	audit := services.NewMockAudit(ctrl)
+	paginator := services.NewPaginator(cfg, log)
+	controller := NewUsersController(users, services.NewMockEffectivePermissions(ctrl), index, audit, paginator, log)
+
+	unknownId := uuid.MustParse("10000000-1000-1000-3000-000000000009")
+
+	users.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, &errors.ValidationError{
+		Err: errors.ErrUnknownReferences,
+		Fields: []errors.FieldError{
+			{Pointer: "/role_ids/0", Code: errors.UnknownIdCode, Detail: unknownId.String() + " does not exist"},
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/backoffice/users", strings.NewReader(`{"identity_number": "PNOEE-60001017869", "personal_code": "60001017869", "first_name": "EID2016", "last_name": "TESTNUMBER", "role_ids": ["10000000-1000-1000-3000-000000000009"]}`))
+	w := httptest.NewRecorder()
+
+	r := chi.NewRouter()
+	r.Post("/api/backoffice/users", controller.Create)
+	r.ServeHTTP(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	var response serializers.ProblemSerializer
+	err := json.NewDecoder(resp.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Equal(t, serializers.ProblemSerializer{
+		Type:     serializers.ProblemDefaultType,
+		Title:    "Unprocessable Entity",
+		Status:   http.StatusUnprocessableEntity,
+		Detail:   errors.ErrUnknownReferences.Error(),
+		Instance: "/api/backoffice/users",
+		Code:     "unknown_references",
+		Errors: []serializers.FieldErrorSerializer{
+			{Pointer: "/role_ids/0", Code: "unknown_id", Detail: unknownId.String() + " does not exist"},
+		},
+	}, response)
+	assert.Equal(t, serializers.ProblemContentType, resp.Header.Get("Content-Type"))
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
@@ -0,0 +1,20 @@
+package errors
+
+import (
+	"fmt"
+	"time"
+)
+
+// CircuitOpenError is returned instead of calling a service whose circuit breaker is open
+type CircuitOpenError struct {
+	Service    string
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Service, ErrCircuitOpen.Error())
+}
+
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
@@ -33,6 +33,12 @@ var (
 	// ErrFailedToFetchResults indicates that failed to fetch results
 	ErrFailedToFetchResults = errors.New("failed to fetch results")
 
+	// ErrInvalidCursor indicates that the pagination cursor is malformed, tampered or issued for another query
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrQueryTooBroad indicates that the collection is too large to be searched, sorted or cursor paginated in memory
+	ErrQueryTooBroad = errors.New("collection too large to query")
+
 	// ErrRecordNotFound indicates that the requested record could not be found
 	ErrRecordNotFound = errors.New("record not found")
 
@@ -45,6 +51,12 @@ var (
 	// ErrFailedToDeleteRecord indicates that failed to delete record
 	ErrFailedToDeleteRecord = errors.New("failed to delete record")
 
+	// ErrUnknownReferences indicates that the request references ids that do not exist
+	ErrUnknownReferences = errors.New("unknown references")
+
+	// ErrResourceHasDependents indicates that the record is still referenced and can only be deleted with force
+	ErrResourceHasDependents = errors.New("resource has dependents")
+
 	// ErrPermissionNotFound indicates that the requested permission could not be found
 	ErrPermissionNotFound = errors.New("permission not found")
 
@@ -57,6 +69,57 @@ var (
 	// ErrUserNotFound indicates that the requested user could not be found
 	ErrUserNotFound = errors.New("user not found")
 
+	// ErrUnavailable indicates that a dependency of the service is not ready
+	ErrUnavailable = errors.New("unavailable")
+
+	// ErrCircuitOpen indicates that calls to a dependency are refused until it has had time to recover
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+
+	// ErrMalformedBody indicates that the request body could not be decoded
+	ErrMalformedBody = errors.New("malformed request body")
+
+	// ErrCanceled indicates that the request was canceled before the upstream call completed
+	ErrCanceled = errors.New("request canceled")
+
+	// ErrDeadlineExceeded indicates that the upstream call did not complete in time
+	ErrDeadlineExceeded = errors.New("deadline exceeded")
+
+	// ErrAlreadyExists indicates that a record with the same identity already exists upstream
+	ErrAlreadyExists = errors.New("record already exists")
+
+	// ErrFailedPrecondition indicates that upstream rejected the operation in the current state
+	ErrFailedPrecondition = errors.New("failed precondition")
+
+	// ErrAborted indicates that upstream aborted the operation because of a concurrent change
+	ErrAborted = errors.New("operation aborted")
+
+	// ErrResourceExhausted indicates that upstream is rate limiting the service
+	ErrResourceExhausted = errors.New("resource exhausted")
+
+	// ErrNotImplemented indicates that upstream does not implement the operation
+	ErrNotImplemented = errors.New("not implemented")
+
+	// ErrUpstreamRejected indicates that upstream refused the credentials of the service itself
+	ErrUpstreamRejected = errors.New("upstream rejected credentials")
+
+	// ErrRequestTooLarge indicates that the request body exceeds the size the endpoint accepts
+	ErrRequestTooLarge = errors.New("request body too large")
+
+	// ErrUnsupportedMediaType indicates that the request body is in a format the endpoint does not accept
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+	// ErrPreconditionFailed indicates that the resource changed since the client last read it
+	ErrPreconditionFailed = errors.New("precondition failed")
+
+	// ErrIdempotencyKeyReused indicates that an idempotency key was sent again with a different request body
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with a different request")
+
+	// ErrIdempotencyKeyInProgress indicates that the first request under an idempotency key has not completed yet
+	ErrIdempotencyKeyInProgress = errors.New("request with the same idempotency key is in progress")
+
+	// ErrBulkOperationAborted indicates that an operation of an atomic bulk request was skipped or undone because another one failed
+	ErrBulkOperationAborted = errors.New("operation aborted because another operation of the atomic request failed")
+
 	// ErrForbidden indicates that the user is not allowed to perform the requested action
 	ErrForbidden = errors.New("access forbidden")
 
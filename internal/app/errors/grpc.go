@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"regexp"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var fieldIndexPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+// grpcErrors maps gRPC status codes onto sentinels,
+// codes missing here resolve to the fallback passed by the caller
+var grpcErrors = map[codes.Code]error{
+	codes.Canceled:           ErrCanceled,
+	codes.DeadlineExceeded:   ErrDeadlineExceeded,
+	codes.NotFound:           ErrRecordNotFound,
+	codes.AlreadyExists:      ErrAlreadyExists,
+	codes.PermissionDenied:   ErrUpstreamRejected,
+	codes.ResourceExhausted:  ErrResourceExhausted,
+	codes.FailedPrecondition: ErrFailedPrecondition,
+	codes.Aborted:            ErrAborted,
+	codes.OutOfRange:         ErrInvalidArguments,
+	codes.Unimplemented:      ErrNotImplemented,
+	codes.Unavailable:        ErrUnavailable,
+	codes.Unauthenticated:    ErrUpstreamRejected,
+}
+
+// FromGRPC translates an error returned by a gRPC client into a sentinel.
+// Unknown, Internal and DataLoss resolve to fallback, which names the failed operation and
+// is registered as an upstream failure, errors that do not carry a status are returned unchanged.
+func FromGRPC(err error, fallback error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch st.Code() {
+	case codes.OK:
+		return nil
+	case codes.InvalidArgument:
+		return invalidArguments(st)
+	}
+
+	if mapped, ok := grpcErrors[st.Code()]; ok {
+		return mapped
+	}
+
+	return fallback
+}
+
+// invalidArguments translates the BadRequest details of an InvalidArgument status
+// into field errors, statuses without details map to the plain sentinel
+func invalidArguments(st *status.Status) error {
+	fields := make([]FieldError, 0)
+
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		for _, violation := range badRequest.GetFieldViolations() {
+			code := violation.GetReason()
+			if code == "" {
+				code = InvalidCode
+			}
+
+			fields = append(fields, FieldError{
+				Pointer: fieldPointer(violation.GetField()),
+				Code:    strings.ToLower(code),
+				Detail:  violation.GetDescription(),
+			})
+		}
+	}
+
+	if len(fields) == 0 {
+		return ErrInvalidArguments
+	}
+
+	return &ValidationError{
+		Err:    ErrInvalidArguments,
+		Fields: fields,
+	}
+}
+
+// fieldPointer converts a protobuf field path such as role_ids[1] or user.first_name into a JSON pointer
+func fieldPointer(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	path = fieldIndexPattern.ReplaceAllString(path, ".$1")
+
+	return "/" + strings.ReplaceAll(path, ".", "/")
+}
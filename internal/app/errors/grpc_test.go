@@ -0,0 +1,191 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_invalidArguments(t *testing.T) {
+	withDetails, err := status.New(codes.InvalidArgument, "invalid arguments").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "first_name", Description: "must not be blank", Reason: "REQUIRED"},
+			{Field: "role_ids[1]", Description: "must be a valid uuid"},
+		},
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		status   *status.Status
+		expected error
+	}{
+		{
+			name:     "Without details",
+			status:   status.New(codes.InvalidArgument, "invalid arguments"),
+			expected: ErrInvalidArguments,
+		},
+		{
+			name:   "With bad request details",
+			status: withDetails,
+			expected: &ValidationError{
+				Err: ErrInvalidArguments,
+				Fields: []FieldError{
+					{Pointer: "/first_name", Code: "required", Detail: "must not be blank"},
+					{Pointer: "/role_ids/1", Code: InvalidCode, Detail: "must be a valid uuid"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, invalidArguments(tt.status))
+		})
+	}
+}
+
+func Test_FromGRPC(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		expected  error
+		status    int
+		retryable bool
+	}{
+		{
+			name:     "Nil",
+			err:      nil,
+			expected: nil,
+		},
+		{
+			name:     "Not a status",
+			err:      ErrUnauthorized,
+			expected: ErrUnauthorized,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			name:     "OK",
+			err:      status.Error(codes.OK, ""),
+			expected: nil,
+		},
+		{
+			name:     "Canceled",
+			err:      status.Error(codes.Canceled, "canceled"),
+			expected: ErrCanceled,
+			status:   StatusClientClosedRequest,
+		},
+		{
+			name:     "Unknown",
+			err:      status.Error(codes.Unknown, "unknown"),
+			expected: ErrFailedToUpdateRecord,
+			status:   http.StatusBadGateway,
+		},
+		{
+			name:     "InvalidArgument",
+			err:      status.Error(codes.InvalidArgument, "invalid"),
+			expected: ErrInvalidArguments,
+			status:   http.StatusBadRequest,
+		},
+		{
+			name:      "DeadlineExceeded",
+			err:       status.Error(codes.DeadlineExceeded, "deadline"),
+			expected:  ErrDeadlineExceeded,
+			status:    http.StatusGatewayTimeout,
+			retryable: true,
+		},
+		{
+			name:     "NotFound",
+			err:      status.Error(codes.NotFound, "not found"),
+			expected: ErrRecordNotFound,
+			status:   http.StatusNotFound,
+		},
+		{
+			name:     "AlreadyExists",
+			err:      status.Error(codes.AlreadyExists, "exists"),
+			expected: ErrAlreadyExists,
+			status:   http.StatusConflict,
+		},
+		{
+			name:     "PermissionDenied",
+			err:      status.Error(codes.PermissionDenied, "denied"),
+			expected: ErrUpstreamRejected,
+			status:   http.StatusBadGateway,
+		},
+		{
+			name:      "ResourceExhausted",
+			err:       status.Error(codes.ResourceExhausted, "exhausted"),
+			expected:  ErrResourceExhausted,
+			status:    http.StatusTooManyRequests,
+			retryable: true,
+		},
+		{
+			name:     "FailedPrecondition",
+			err:      status.Error(codes.FailedPrecondition, "precondition"),
+			expected: ErrFailedPrecondition,
+			status:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "Aborted",
+			err:       status.Error(codes.Aborted, "aborted"),
+			expected:  ErrAborted,
+			status:    http.StatusConflict,
+			retryable: true,
+		},
+		{
+			name:     "OutOfRange",
+			err:      status.Error(codes.OutOfRange, "out of range"),
+			expected: ErrInvalidArguments,
+			status:   http.StatusBadRequest,
+		},
+		{
+			name:     "Unimplemented",
+			err:      status.Error(codes.Unimplemented, "unimplemented"),
+			expected: ErrNotImplemented,
+			status:   http.StatusNotImplemented,
+		},
+		{
+			name:     "Internal",
+			err:      status.Error(codes.Internal, "internal"),
+			expected: ErrFailedToUpdateRecord,
+			status:   http.StatusBadGateway,
+		},
+		{
+			name:      "Unavailable",
+			err:       status.Error(codes.Unavailable, "unavailable"),
+			expected:  ErrUnavailable,
+			status:    http.StatusServiceUnavailable,
+			retryable: true,
+		},
+		{
+			name:     "DataLoss",
+			err:      status.Error(codes.DataLoss, "data loss"),
+			expected: ErrFailedToUpdateRecord,
+			status:   http.StatusBadGateway,
+		},
+		{
+			name:     "Unauthenticated",
+			err:      status.Error(codes.Unauthenticated, "unauthenticated"),
+			expected: ErrUpstreamRejected,
+			status:   http.StatusBadGateway,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FromGRPC(tt.err, ErrFailedToUpdateRecord)
+			assert.Equal(t, tt.expected, result)
+
+			if tt.expected != nil {
+				definition := Lookup(result)
+				assert.Equal(t, tt.status, definition.Status)
+				assert.Equal(t, tt.retryable, definition.Retryable)
+				assert.NotEmpty(t, definition.Code)
+			}
+		})
+	}
+}
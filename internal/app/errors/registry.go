@@ -0,0 +1,82 @@
+package errors
+
+import (
+	"net/http"
+)
+
+// StatusClientClosedRequest is the de facto status of requests canceled by the client
+const StatusClientClosedRequest = 499
+
+// Definition describes how an error is surfaced to API clients
+type Definition struct {
+	Status    int
+	Code      string
+	Retryable bool
+}
+
+// InternalDefinition applies to every error missing from the registry
+var InternalDefinition = Definition{Status: http.StatusInternalServerError, Code: "internal_error"}
+
+// registry is ordered, the first sentinel matched with Is wins
+var registry = []struct {
+	err        error
+	definition Definition
+}{
+	{ErrMalformedBody, Definition{Status: http.StatusBadRequest, Code: "malformed_body"}},
+	{ErrRequestTooLarge, Definition{Status: http.StatusRequestEntityTooLarge, Code: "request_too_large"}},
+	{ErrUnsupportedMediaType, Definition{Status: http.StatusUnsupportedMediaType, Code: "unsupported_media_type"}},
+	{ErrInvalidArguments, Definition{Status: http.StatusBadRequest, Code: "invalid_arguments"}},
+	{ErrInvalidCursor, Definition{Status: http.StatusBadRequest, Code: "invalid_cursor"}},
+	{ErrQueryTooBroad, Definition{Status: http.StatusUnprocessableEntity, Code: "query_too_broad"}},
+	{ErrInvalidToken, Definition{Status: http.StatusUnauthorized, Code: "invalid_token"}},
+	{ErrInvalidSigningMethod, Definition{Status: http.StatusUnauthorized, Code: "invalid_token"}},
+	{ErrUnauthorized, Definition{Status: http.StatusUnauthorized, Code: "unauthorized"}},
+	{ErrForbidden, Definition{Status: http.StatusForbidden, Code: "forbidden"}},
+	{ErrRecordNotFound, Definition{Status: http.StatusNotFound, Code: "record_not_found"}},
+	{ErrPermissionNotFound, Definition{Status: http.StatusNotFound, Code: "record_not_found"}},
+	{ErrRoleNotFound, Definition{Status: http.StatusNotFound, Code: "record_not_found"}},
+	{ErrScopeNotFound, Definition{Status: http.StatusNotFound, Code: "record_not_found"}},
+	{ErrUserNotFound, Definition{Status: http.StatusNotFound, Code: "record_not_found"}},
+	{ErrAlreadyExists, Definition{Status: http.StatusConflict, Code: "already_exists"}},
+	{ErrAborted, Definition{Status: http.StatusConflict, Code: "aborted", Retryable: true}},
+	{ErrResourceHasDependents, Definition{Status: http.StatusConflict, Code: "has_dependents"}},
+	{ErrIdempotencyKeyInProgress, Definition{Status: http.StatusConflict, Code: "idempotency_key_in_progress", Retryable: true}},
+	{ErrIdempotencyKeyReused, Definition{Status: http.StatusUnprocessableEntity, Code: "idempotency_key_reused"}},
+	{ErrPreconditionFailed, Definition{Status: http.StatusPreconditionFailed, Code: "precondition_failed"}},
+	{ErrBulkOperationAborted, Definition{Status: http.StatusFailedDependency, Code: "failed_dependency"}},
+	{ErrUnknownReferences, Definition{Status: http.StatusUnprocessableEntity, Code: "unknown_references"}},
+	{ErrFailedPrecondition, Definition{Status: http.StatusUnprocessableEntity, Code: "failed_precondition"}},
+	// Upstream failures answer 502 whatever the operation, like the gRPC retries
+	// only reads and deletes are retryable
+	{ErrFailedToFetchResults, Definition{Status: http.StatusBadGateway, Code: "fetch_failed", Retryable: true}},
+	{ErrFailedToCreateRecord, Definition{Status: http.StatusBadGateway, Code: "create_failed", Retryable: false}},
+	{ErrFailedToUpdateRecord, Definition{Status: http.StatusBadGateway, Code: "update_failed", Retryable: false}},
+	{ErrFailedToDeleteRecord, Definition{Status: http.StatusBadGateway, Code: "delete_failed", Retryable: true}},
+	{ErrResourceExhausted, Definition{Status: http.StatusTooManyRequests, Code: "rate_limited", Retryable: true}},
+	{ErrCanceled, Definition{Status: StatusClientClosedRequest, Code: "canceled"}},
+	{ErrNotImplemented, Definition{Status: http.StatusNotImplemented, Code: "not_implemented"}},
+	{ErrUpstreamRejected, Definition{Status: http.StatusBadGateway, Code: "upstream_rejected"}},
+	{ErrCircuitOpen, Definition{Status: http.StatusServiceUnavailable, Code: "circuit_open", Retryable: true}},
+	{ErrUnavailable, Definition{Status: http.StatusServiceUnavailable, Code: "unavailable", Retryable: true}},
+	{ErrDeadlineExceeded, Definition{Status: http.StatusGatewayTimeout, Code: "deadline_exceeded", Retryable: true}},
+}
+
+// Lookup returns the definition of the first registered sentinel err wraps
+func Lookup(err error) Definition {
+	for _, entry := range registry {
+		if Is(err, entry.err) {
+			return entry.definition
+		}
+	}
+
+	return InternalDefinition
+}
+
+// StatusText extends http.StatusText with the non standard statuses of the registry
+func StatusText(status int) string {
+	if status == StatusClientClosedRequest {
+		return "Client Closed Request"
+	}
+
+	return http.StatusText(status)
+}
@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Lookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected Definition
+	}{
+		{
+			name:     "Registered",
+			err:      ErrRecordNotFound,
+			expected: Definition{Status: http.StatusNotFound, Code: "record_not_found"},
+		},
+		{
+			name:     "Wrapped",
+			err:      fmt.Errorf("%w: unexpected EOF", ErrMalformedBody),
+			expected: Definition{Status: http.StatusBadRequest, Code: "malformed_body"},
+		},
+		{
+			name: "Validation",
+			err: &ValidationError{
+				Err:    ErrUnknownReferences,
+				Fields: []FieldError{{Pointer: "/role_ids/0", Code: UnknownIdCode}},
+			},
+			expected: Definition{Status: http.StatusUnprocessableEntity, Code: "unknown_references"},
+		},
+		{
+			name:     "Retryable",
+			err:      ErrFailedToFetchResults,
+			expected: Definition{Status: http.StatusBadGateway, Code: "fetch_failed", Retryable: true},
+		},
+		{
+			name:     "Not retryable",
+			err:      ErrFailedToCreateRecord,
+			expected: Definition{Status: http.StatusBadGateway, Code: "create_failed"},
+		},
+		{
+			name:     "Circuit open",
+			err:      &CircuitOpenError{Service: "sso.v1.UserService", RetryAfter: time.Second},
+			expected: Definition{Status: http.StatusServiceUnavailable, Code: "circuit_open", Retryable: true},
+		},
+		{
+			name:     "Unregistered",
+			err:      fmt.Errorf("boom"),
+			expected: InternalDefinition,
+		},
+		{
+			name:     "Nil",
+			err:      nil,
+			expected: InternalDefinition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Lookup(tt.err))
+		})
+	}
+}
+
+func Test_StatusText(t *testing.T) {
+	assert.Equal(t, "Client Closed Request", StatusText(StatusClientClosedRequest))
+	assert.Equal(t, "Not Found", StatusText(http.StatusNotFound))
+}
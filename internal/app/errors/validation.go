@@ -0,0 +1,39 @@
+package errors
+
+const (
+	// RequiredCode marks a missing or blank field
+	RequiredCode = "required"
+
+	// InvalidCode marks a field rejected upstream without a more specific reason
+	InvalidCode = "invalid"
+
+	// UnknownIdCode marks a reference to a record that does not exist
+	UnknownIdCode = "unknown_id"
+
+	// UnknownNameCode marks a reference by name to a record that does not exist
+	UnknownNameCode = "unknown_name"
+
+	// DuplicateCode marks a value that has to be unique within the request
+	DuplicateCode = "duplicate"
+)
+
+// FieldError describes a single violation, Pointer is a JSON pointer into the request body
+type FieldError struct {
+	Pointer string
+	Code    string
+	Detail  string
+}
+
+// ValidationError carries the field level violations behind a sentinel error
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CreateActionType       = "create"
+	UpdateActionType       = "update"
+	DeleteActionType       = "delete"
+	RevokeTokensActionType = "revoke_tokens"
+	RevealActionType       = "reveal"
+
+	PermissionResourceType = "permission"
+	RoleResourceType       = "role"
+	ScopeResourceType      = "scope"
+	TokenResourceType      = "token"
+	UserResourceType       = "user"
+)
+
+type AuditLog struct {
+	ID           uuid.UUID
+	ActorID      string
+	Action       string
+	ResourceType string
+	ResourceID   uuid.UUID
+	Before       json.RawMessage
+	After        json.RawMessage
+	RequestID    string
+	TraceID      string
+	CreatedAt    time.Time
+}
+
+type AuditLogFilter struct {
+	ActorID      string
+	Action       string
+	ResourceType string
+	ResourceID   uuid.UUID
+	From         time.Time
+	To           time.Time
+}
+
+// PermissionAuditSnapshot is the shape a permission is kept in Before and After
+type PermissionAuditSnapshot struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+// RoleAuditSnapshot is the shape a role is kept in Before and After
+type RoleAuditSnapshot struct {
+	ID            uuid.UUID   `json:"id"`
+	Name          string      `json:"name"`
+	Description   string      `json:"description"`
+	PermissionIDs []uuid.UUID `json:"permission_ids,omitempty"`
+}
+
+// ScopeAuditSnapshot is the shape a scope is kept in Before and After
+type ScopeAuditSnapshot struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+// TokenAuditSnapshot is the shape a token is kept in Before and After, the value is never audited
+type TokenAuditSnapshot struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Type        string    `json:"type"`
+	Fingerprint string    `json:"fingerprint"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// UserAuditSnapshot is the shape a user is kept in Before and After
+type UserAuditSnapshot struct {
+	ID             uuid.UUID   `json:"id"`
+	IdentityNumber string      `json:"identity_number"`
+	PersonalCode   string      `json:"personal_code"`
+	FirstName      string      `json:"first_name"`
+	LastName       string      `json:"last_name"`
+	RoleIDs        []uuid.UUID `json:"role_ids,omitempty"`
+	ScopeIDs       []uuid.UUID `json:"scope_ids,omitempty"`
+}
+
+// AuditSnapshot returns the permission as it is audited, nil when there is no permission
+func (p *Permission) AuditSnapshot() interface{} {
+	if p == nil {
+		return nil
+	}
+
+	return PermissionAuditSnapshot{ID: p.ID, Name: p.Name, Description: p.Description}
+}
+
+// AuditSnapshot returns the role as it is audited, nil when there is no role
+func (r *Role) AuditSnapshot() interface{} {
+	if r == nil {
+		return nil
+	}
+
+	return RoleAuditSnapshot{ID: r.ID, Name: r.Name, Description: r.Description, PermissionIDs: r.PermissionIDs}
+}
+
+// AuditSnapshot returns the scope as it is audited, nil when there is no scope
+func (s *Scope) AuditSnapshot() interface{} {
+	if s == nil {
+		return nil
+	}
+
+	return ScopeAuditSnapshot{ID: s.ID, Name: s.Name, Description: s.Description}
+}
+
+// AuditSnapshot returns the token as it is audited, nil when there is no token
+func (t *Token) AuditSnapshot() interface{} {
+	if t == nil {
+		return nil
+	}
+
+	return TokenAuditSnapshot{ID: t.ID, UserID: t.UserId, Type: t.Type, Fingerprint: t.Fingerprint, ExpiresAt: t.ExpiresAt}
+}
+
+// AuditSnapshot returns the user as it is audited, nil when there is no user
+func (u *User) AuditSnapshot() interface{} {
+	if u == nil {
+		return nil
+	}
+
+	return UserAuditSnapshot{
+		ID:             u.ID,
+		IdentityNumber: u.IdentityNumber,
+		PersonalCode:   u.PersonalCode,
+		FirstName:      u.FirstName,
+		LastName:       u.LastName,
+		RoleIDs:        u.RoleIDs,
+		ScopeIDs:       u.ScopeIDs,
+	}
+}
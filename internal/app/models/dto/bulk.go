@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+)
+
+// BulkMaxOperations caps the number of operations accepted in a single bulk request
+const BulkMaxOperations = 500
+
+// BulkOperationRequest is one create, update or delete of a bulk request,
+// data holds the same document the single record endpoint accepts
+type BulkOperationRequest struct {
+	Op   string          `json:"op"`
+	ID   uuid.UUID       `json:"id,omitempty"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+type BulkRequest []BulkOperationRequest
+
+// Validate checks the envelope of every operation, the data documents are validated per item
+// so that a single invalid record does not reject the whole request
+func (params *BulkRequest) Validate(body io.Reader) error {
+	if err := decode(body, params); err != nil {
+		return err
+	}
+
+	var v validator
+	if len(*params) == 0 || len(*params) > BulkMaxOperations {
+		v.fields = append(v.fields, errors.FieldError{
+			Pointer: "",
+			Code:    errors.InvalidCode,
+			Detail:  fmt.Sprintf("expected between 1 and %d operations", BulkMaxOperations),
+		})
+	}
+
+	for i, item := range *params {
+		switch item.Op {
+		case models.CreateActionType:
+			v.present(len(item.Data) > 0, fmt.Sprintf("/%d/data", i))
+		case models.UpdateActionType:
+			v.present(item.ID != uuid.Nil, fmt.Sprintf("/%d/id", i))
+			v.present(len(item.Data) > 0, fmt.Sprintf("/%d/data", i))
+		case models.DeleteActionType:
+			v.present(item.ID != uuid.Nil, fmt.Sprintf("/%d/id", i))
+		default:
+			v.fields = append(v.fields, errors.FieldError{
+				Pointer: fmt.Sprintf("/%d/op", i),
+				Code:    errors.InvalidCode,
+				Detail:  fmt.Sprintf("unsupported operation %q", item.Op),
+			})
+		}
+	}
+
+	return v.err()
+}
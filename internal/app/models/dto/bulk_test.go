@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"loki-backoffice/internal/app/errors"
+)
+
+func Test_Validate_BulkRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     io.Reader
+		expected error
+	}{
+		{
+			name: "Success",
+			body: strings.NewReader(`[
+				{"op": "create", "data": {"name": "admin"}},
+				{"op": "update", "id": "10000000-1000-1000-3000-000000000001", "data": {"name": "manager"}},
+				{"op": "delete", "id": "10000000-1000-1000-3000-000000000002"}
+			]`),
+			expected: nil,
+		},
+		{
+			name: "Missing fields",
+			body: strings.NewReader(`[
+				{"op": "create"},
+				{"op": "update", "data": {"name": "manager"}},
+				{"op": "delete"},
+				{"op": "upsert"}
+			]`),
+			expected: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/0/data", Code: errors.RequiredCode},
+					{Pointer: "/1/id", Code: errors.RequiredCode},
+					{Pointer: "/2/id", Code: errors.RequiredCode},
+					{Pointer: "/3/op", Code: errors.InvalidCode, Detail: `unsupported operation "upsert"`},
+				},
+			},
+		},
+		{
+			name: "Empty",
+			body: strings.NewReader(`[]`),
+			expected: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "", Code: errors.InvalidCode, Detail: fmt.Sprintf("expected between 1 and %d operations", BulkMaxOperations)},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params BulkRequest
+			err := params.Validate(tt.body)
+
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+
+	t.Run("Not an array", func(t *testing.T) {
+		var params BulkRequest
+		err := params.Validate(strings.NewReader(`{"op": "create"}`))
+
+		assert.ErrorIs(t, err, errors.ErrMalformedBody)
+	})
+}
@@ -0,0 +1,340 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"loki-backoffice/internal/app/errors"
+)
+
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+
+	// MaxPatchBodySize caps the patch document buffered in memory
+	MaxPatchBodySize = 1 << 20
+)
+
+const (
+	AddOperation     = "add"
+	RemoveOperation  = "remove"
+	ReplaceOperation = "replace"
+	TestOperation    = "test"
+)
+
+// operation is a single RFC 6902 JSON patch step
+type operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// patch applies the patch document in body to params, the media type selects
+// between RFC 7396 merge patch and RFC 6902 JSON patch semantics.
+// The body is expected to be limited with http.MaxBytesReader to MaxPatchBodySize.
+func patch[T any](contentType string, body io.Reader, params *T) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return errors.ErrUnsupportedMediaType
+	}
+
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return errors.ErrRequestTooLarge
+		}
+
+		return fmt.Errorf("%w: %w", errors.ErrMalformedBody, err)
+	}
+
+	current, err := document(params)
+	if err != nil {
+		return err
+	}
+
+	var result any
+	switch mediaType {
+	case MergePatchContentType:
+		var changes any
+		if err = json.Unmarshal(raw, &changes); err != nil {
+			return fmt.Errorf("%w: %w", errors.ErrMalformedBody, err)
+		}
+		result = mergePatch(current, changes)
+	case JSONPatchContentType:
+		result, err = jsonPatch(current, raw)
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.ErrUnsupportedMediaType
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	// Fields removed by the patch must not survive from the current state
+	var next T
+	if err = decode(bytes.NewReader(encoded), &next); err != nil {
+		return err
+	}
+
+	*params = next
+	return nil
+}
+
+func document(value any) (any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var result any
+	if err = json.Unmarshal(encoded, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// present turns a nil slice into an empty one, so that the member appears in the document
+// and a JSON patch can add to it
+func present[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+
+	return items
+}
+
+// mergePatch implements RFC 7396, null members remove the target member
+func mergePatch(target, changes any) any {
+	changesMap, ok := changes.(map[string]any)
+	if !ok {
+		return changes
+	}
+
+	targetMap, ok := target.(map[string]any)
+	if !ok {
+		targetMap = make(map[string]any)
+	}
+
+	for key, value := range changesMap {
+		if value == nil {
+			delete(targetMap, key)
+			continue
+		}
+
+		targetMap[key] = mergePatch(targetMap[key], value)
+	}
+
+	return targetMap
+}
+
+// jsonPatch implements the add, remove, replace and test operations of RFC 6902,
+// the first failing operation aborts the whole patch
+func jsonPatch(target any, raw []byte) (any, error) {
+	var operations []operation
+	if err := json.Unmarshal(raw, &operations); err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrMalformedBody, err)
+	}
+
+	for i, op := range operations {
+		result, err := op.apply(target)
+		if err != nil {
+			return nil, &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/" + strconv.Itoa(i), Code: errors.InvalidCode, Detail: err.Error()},
+				},
+			}
+		}
+
+		target = result
+	}
+
+	return target, nil
+}
+
+func (o operation) apply(target any) (any, error) {
+	tokens, err := parsePointer(o.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	switch o.Op {
+	case AddOperation, ReplaceOperation, TestOperation:
+		if len(o.Value) == 0 {
+			return nil, fmt.Errorf("%s requires a value", o.Op)
+		}
+		if err = json.Unmarshal(o.Value, &value); err != nil {
+			return nil, err
+		}
+	case RemoveOperation:
+	default:
+		return nil, fmt.Errorf("unsupported operation %q", o.Op)
+	}
+
+	return modify(target, tokens, func(node any, token string) (any, error) {
+		switch o.Op {
+		case AddOperation:
+			return add(node, token, value)
+		case RemoveOperation:
+			return remove(node, token)
+		case ReplaceOperation:
+			return replace(node, token, value)
+		default:
+			current, err := lookup(node, token)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, fmt.Errorf("test failed at %s", o.Path)
+			}
+			return node, nil
+		}
+	})
+}
+
+// parsePointer splits an RFC 6901 JSON pointer into unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid path %q", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+
+	return tokens, nil
+}
+
+// modify walks to the parent of the last token, applies leaf and writes the result back up the tree
+func modify(node any, tokens []string, leaf func(node any, token string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return leaf(node, tokens[0])
+	}
+
+	child, err := lookup(node, tokens[0])
+	if err != nil {
+		return nil, err
+	}
+
+	child, err = modify(child, tokens[1:], leaf)
+	if err != nil {
+		return nil, err
+	}
+
+	return set(node, tokens[0], child)
+}
+
+// lookup fails for absent members, RFC 6902 requires the target location of every operation
+// and the parent of an added value to exist
+func lookup(node any, token string) (any, error) {
+	switch container := node.(type) {
+	case map[string]any:
+		value, ok := container[token]
+		if !ok {
+			return nil, fmt.Errorf("path segment %q not found", token)
+		}
+		return value, nil
+	case []any:
+		i, err := index(container, token, false)
+		if err != nil {
+			return nil, err
+		}
+		return container[i], nil
+	default:
+		return nil, fmt.Errorf("path segment %q not found", token)
+	}
+}
+
+func add(node any, token string, value any) (any, error) {
+	switch container := node.(type) {
+	case map[string]any:
+		container[token] = value
+		return container, nil
+	case []any:
+		i, err := index(container, token, true)
+		if err != nil {
+			return nil, err
+		}
+		container = append(container, nil)
+		copy(container[i+1:], container[i:])
+		container[i] = value
+		return container, nil
+	default:
+		return nil, fmt.Errorf("path segment %q not found", token)
+	}
+}
+
+func remove(node any, token string) (any, error) {
+	switch container := node.(type) {
+	case map[string]any:
+		if _, ok := container[token]; !ok {
+			return nil, fmt.Errorf("path segment %q not found", token)
+		}
+		delete(container, token)
+		return container, nil
+	case []any:
+		i, err := index(container, token, false)
+		if err != nil {
+			return nil, err
+		}
+		return append(container[:i], container[i+1:]...), nil
+	default:
+		return nil, fmt.Errorf("path segment %q not found", token)
+	}
+}
+
+func replace(node any, token string, value any) (any, error) {
+	if container, ok := node.(map[string]any); ok {
+		if _, ok = container[token]; !ok {
+			return nil, fmt.Errorf("path segment %q not found", token)
+		}
+	}
+
+	return set(node, token, value)
+}
+
+func set(node any, token string, value any) (any, error) {
+	switch container := node.(type) {
+	case map[string]any:
+		container[token] = value
+		return container, nil
+	case []any:
+		i, err := index(container, token, false)
+		if err != nil {
+			return nil, err
+		}
+		container[i] = value
+		return container, nil
+	default:
+		return nil, fmt.Errorf("path segment %q not found", token)
+	}
+}
+
+// index resolves an array token, "-" addresses the position past the last element
+func index(items []any, token string, appending bool) (int, error) {
+	if appending && token == "-" {
+		return len(items), nil
+	}
+
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > len(items) || (!appending && i == len(items)) {
+		return 0, fmt.Errorf("index %q out of range", token)
+	}
+
+	return i, nil
+}
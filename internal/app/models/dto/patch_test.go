@@ -0,0 +1,188 @@
+package dto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"loki-backoffice/internal/app/errors"
+)
+
+func Test_Patch_RoleRequest(t *testing.T) {
+	readId := uuid.MustParse("10000000-1000-1000-4000-000000000001")
+	writeId := uuid.MustParse("10000000-1000-1000-4000-000000000002")
+
+	current := func() RoleRequest {
+		return RoleRequest{
+			Name:          "admin",
+			Description:   "Admin role",
+			PermissionIDs: []uuid.UUID{readId},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    RoleRequest
+		error       error
+	}{
+		{
+			name:        "Merge patch",
+			contentType: MergePatchContentType,
+			body:        `{"description": "Administrators"}`,
+			expected: RoleRequest{
+				Name:          "admin",
+				Description:   "Administrators",
+				PermissionIDs: []uuid.UUID{readId},
+			},
+		},
+		{
+			name:        "Merge patch with charset",
+			contentType: MergePatchContentType + "; charset=utf-8",
+			body:        `{"permission_ids": ["` + writeId.String() + `"]}`,
+			expected: RoleRequest{
+				Name:          "admin",
+				Description:   "Admin role",
+				PermissionIDs: []uuid.UUID{writeId},
+			},
+		},
+		{
+			name:        "Merge patch null removes member",
+			contentType: MergePatchContentType,
+			body:        `{"permission_ids": null}`,
+			expected: RoleRequest{
+				Name:        "admin",
+				Description: "Admin role",
+			},
+		},
+		{
+			name:        "Merge patch blank name",
+			contentType: MergePatchContentType,
+			body:        `{"name": " "}`,
+			error: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/name", Code: errors.RequiredCode, Detail: errors.ErrEmptyName.Error()},
+				},
+			},
+		},
+		{
+			name:        "JSON patch",
+			contentType: JSONPatchContentType,
+			body: `[
+				{"op": "test", "path": "/name", "value": "admin"},
+				{"op": "add", "path": "/permission_ids/0", "value": "` + writeId.String() + `"},
+				{"op": "remove", "path": "/permission_ids/1"},
+				{"op": "replace", "path": "/description", "value": "Administrators"}
+			]`,
+			expected: RoleRequest{
+				Name:          "admin",
+				Description:   "Administrators",
+				PermissionIDs: []uuid.UUID{writeId},
+			},
+		},
+		{
+			name:        "JSON patch failed test",
+			contentType: JSONPatchContentType,
+			body:        `[{"op": "test", "path": "/name", "value": "manager"}]`,
+			error: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/0", Code: errors.InvalidCode, Detail: "test failed at /name"},
+				},
+			},
+		},
+		{
+			name:        "JSON patch unsupported operation",
+			contentType: JSONPatchContentType,
+			body:        `[{"op": "add", "path": "/permission_ids/-", "value": "` + writeId.String() + `"}, {"op": "move", "from": "/name", "path": "/description"}]`,
+			error: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/1", Code: errors.InvalidCode, Detail: `unsupported operation "move"`},
+				},
+			},
+		},
+		{
+			name:        "JSON patch replace missing member",
+			contentType: JSONPatchContentType,
+			body:        `[{"op": "replace", "path": "/owner", "value": "john"}]`,
+			error: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/0", Code: errors.InvalidCode, Detail: `path segment "owner" not found`},
+				},
+			},
+		},
+		{
+			name:        "JSON patch add to missing parent",
+			contentType: JSONPatchContentType,
+			body:        `[{"op": "add", "path": "/owner_ids/-", "value": "` + writeId.String() + `"}]`,
+			error: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/0", Code: errors.InvalidCode, Detail: `path segment "owner_ids" not found`},
+				},
+			},
+		},
+		{
+			name:        "JSON patch test missing member",
+			contentType: JSONPatchContentType,
+			body:        `[{"op": "test", "path": "/owner", "value": "john"}]`,
+			error: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/0", Code: errors.InvalidCode, Detail: `path segment "owner" not found`},
+				},
+			},
+		},
+		{
+			name:        "Unsupported media type",
+			contentType: "application/json",
+			body:        `{"name": "manager"}`,
+			error:       errors.ErrUnsupportedMediaType,
+		},
+		{
+			name:        "Missing media type",
+			contentType: "",
+			body:        `{"name": "manager"}`,
+			error:       errors.ErrUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := current()
+			err := params.Patch(tt.contentType, strings.NewReader(tt.body))
+
+			if tt.error != nil {
+				assert.Equal(t, tt.error, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, params)
+			}
+		})
+	}
+}
+
+func Test_Patch_UserRequest(t *testing.T) {
+	scopeId := uuid.MustParse("10000000-1000-1000-2000-000000000001")
+
+	params := UserRequest{
+		IdentityNumber: "PNOEE-60001017869",
+		PersonalCode:   "60001017869",
+		FirstName:      "EID2016",
+		LastName:       "TESTNUMBER",
+	}
+
+	err := params.Patch(JSONPatchContentType, strings.NewReader(`[{"op": "add", "path": "/scope_ids/-", "value": "`+scopeId.String()+`"}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{scopeId}, params.ScopeIDs)
+	assert.Empty(t, params.RoleIDs)
+
+	err = params.Patch(MergePatchContentType, strings.NewReader(`{`))
+	assert.ErrorIs(t, err, errors.ErrMalformedBody)
+}
@@ -1,9 +1,7 @@
 package dto
 
 import (
-	"encoding/json"
 	"io"
-	"strings"
 
 	"loki-backoffice/internal/app/errors"
 )
@@ -14,19 +12,26 @@ type PermissionRequest struct {
 }
 
 func (params *PermissionRequest) Validate(body io.Reader) error {
-	if err := json.NewDecoder(body).Decode(params); err != nil {
+	if err := decode(body, params); err != nil {
 		return err
 	}
 
-	params.Name = strings.TrimSpace(params.Name)
-	if params.Name == "" {
-		return errors.ErrEmptyName
-	}
+	return params.validate()
+}
 
-	params.Description = strings.TrimSpace(params.Description)
-	if params.Description == "" {
-		return errors.ErrEmptyDescription
+// Patch applies a merge patch or JSON patch document on top of params and validates the result
+func (params *PermissionRequest) Patch(contentType string, body io.Reader) error {
+	if err := patch(contentType, body, params); err != nil {
+		return err
 	}
 
-	return nil
+	return params.validate()
+}
+
+func (params *PermissionRequest) validate() error {
+	var v validator
+	v.required(&params.Name, "/name", errors.ErrEmptyName)
+	v.required(&params.Description, "/description", errors.ErrEmptyDescription)
+
+	return v.err()
 }
@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+)
+
+// RBACDocument is the desired set of permissions, roles and scopes, roles refer to permissions by name.
+// A section left out of the document is not managed, an empty one is.
+// JSON documents are valid YAML, so both are read by the YAML decoder.
+type RBACDocument struct {
+	Permissions []RBACPermissionRequest `json:"permissions" yaml:"permissions"`
+	Roles       []RBACRoleRequest       `json:"roles" yaml:"roles"`
+	Scopes      []RBACScopeRequest      `json:"scopes" yaml:"scopes"`
+}
+
+type RBACPermissionRequest struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+}
+
+type RBACRoleRequest struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	Permissions []string `json:"permissions" yaml:"permissions"`
+}
+
+type RBACScopeRequest struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+}
+
+// Validate decodes the document and reports blank and duplicate names and role permissions missing from the document
+func (params *RBACDocument) Validate(body io.Reader) error {
+	decoder := yaml.NewDecoder(body)
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(params); err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrMalformedBody, err)
+	}
+
+	var v validator
+
+	permissions := make(map[string]string, len(params.Permissions))
+	for i := range params.Permissions {
+		item := &params.Permissions[i]
+		v.required(&item.Name, fmt.Sprintf("/permissions/%d/name", i), errors.ErrEmptyName)
+		v.required(&item.Description, fmt.Sprintf("/permissions/%d/description", i), errors.ErrEmptyDescription)
+		v.unique(permissions, item.Name, fmt.Sprintf("/permissions/%d/name", i))
+	}
+
+	roles := make(map[string]string, len(params.Roles))
+	for i := range params.Roles {
+		item := &params.Roles[i]
+		v.required(&item.Name, fmt.Sprintf("/roles/%d/name", i), errors.ErrEmptyName)
+		v.required(&item.Description, fmt.Sprintf("/roles/%d/description", i), errors.ErrEmptyDescription)
+		v.unique(roles, item.Name, fmt.Sprintf("/roles/%d/name", i))
+
+		// without a permissions section roles may refer to live permissions, the engine checks them
+		for j, name := range item.Permissions {
+			if _, ok := permissions[name]; !ok && params.Permissions != nil {
+				v.fields = append(v.fields, errors.FieldError{
+					Pointer: fmt.Sprintf("/roles/%d/permissions/%d", i, j),
+					Code:    errors.UnknownNameCode,
+					Detail:  fmt.Sprintf("%s is not declared in permissions", name),
+				})
+			}
+		}
+	}
+
+	scopes := make(map[string]string, len(params.Scopes))
+	for i := range params.Scopes {
+		item := &params.Scopes[i]
+		v.required(&item.Name, fmt.Sprintf("/scopes/%d/name", i), errors.ErrEmptyName)
+		v.required(&item.Description, fmt.Sprintf("/scopes/%d/description", i), errors.ErrEmptyDescription)
+		v.unique(scopes, item.Name, fmt.Sprintf("/scopes/%d/name", i))
+	}
+
+	return v.err()
+}
+
+// Config converts the document into the desired state the RBAC engine plans against
+func (params *RBACDocument) Config() *models.RBACConfig {
+	result := &models.RBACConfig{
+		Permissions: section[models.RBACResource](params.Permissions),
+		Roles:       section[models.RBACResource](params.Roles),
+		Scopes:      section[models.RBACResource](params.Scopes),
+	}
+
+	for _, item := range params.Permissions {
+		result.Permissions = append(result.Permissions, models.RBACResource{Name: item.Name, Description: item.Description})
+	}
+
+	for _, item := range params.Roles {
+		permissions := make([]string, 0, len(item.Permissions))
+		permissions = append(permissions, item.Permissions...)
+
+		result.Roles = append(result.Roles, models.RBACResource{Name: item.Name, Description: item.Description, Permissions: permissions})
+	}
+
+	for _, item := range params.Scopes {
+		result.Scopes = append(result.Scopes, models.RBACResource{Name: item.Name, Description: item.Description})
+	}
+
+	return result
+}
+
+// section keeps an absent section nil so that it stays unmanaged
+func section[T, S any](items []S) []T {
+	if items == nil {
+		return nil
+	}
+
+	return make([]T, 0, len(items))
+}
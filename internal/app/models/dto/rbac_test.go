@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+)
+
+func Test_Validate_RBACDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected *models.RBACConfig
+		error    error
+	}{
+		{
+			name: "YAML",
+			body: `
+permissions:
+  - name: read:users
+    description: Read users
+  - name: write:users
+    description: " Write users "
+roles:
+  - name: admin
+    description: Administrator
+    permissions: [read:users, write:users]
+scopes:
+  - name: sso-service
+    description: SSO service
+`,
+			expected: &models.RBACConfig{
+				Permissions: []models.RBACResource{
+					{Name: "read:users", Description: "Read users"},
+					{Name: "write:users", Description: "Write users"},
+				},
+				Roles: []models.RBACResource{
+					{Name: "admin", Description: "Administrator", Permissions: []string{"read:users", "write:users"}},
+				},
+				Scopes: []models.RBACResource{
+					{Name: "sso-service", Description: "SSO service"},
+				},
+			},
+		},
+		{
+			name: "JSON",
+			body: `{"permissions": [{"name": "read:users", "description": "Read users"}], "roles": [{"name": "user", "description": "User"}]}`,
+			expected: &models.RBACConfig{
+				Permissions: []models.RBACResource{{Name: "read:users", Description: "Read users"}},
+				Roles:       []models.RBACResource{{Name: "user", Description: "User", Permissions: []string{}}},
+			},
+		},
+		{
+			name: "Empty section",
+			body: "scopes: []\n",
+			expected: &models.RBACConfig{
+				Scopes: []models.RBACResource{},
+			},
+		},
+		{
+			name: "Roles without permissions section",
+			body: "roles:\n  - name: admin\n    description: Administrator\n    permissions: [read:users]\n",
+			expected: &models.RBACConfig{
+				Roles: []models.RBACResource{{Name: "admin", Description: "Administrator", Permissions: []string{"read:users"}}},
+			},
+		},
+		{
+			name: "Invalid",
+			body: `
+permissions:
+  - name: read:users
+    description: Read users
+  - name: read:users
+    description: Read users again
+roles:
+  - name: admin
+    permissions: [read:users, write:users]
+`,
+			error: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/permissions/1/name", Code: errors.DuplicateCode, Detail: "already declared at /permissions/0/name"},
+					{Pointer: "/roles/0/description", Code: errors.RequiredCode, Detail: errors.ErrEmptyDescription.Error()},
+					{Pointer: "/roles/0/permissions/1", Code: errors.UnknownNameCode, Detail: "write:users is not declared in permissions"},
+				},
+			},
+		},
+		{
+			name:  "Unknown field",
+			body:  "groups: []\n",
+			error: errors.ErrMalformedBody,
+		},
+		{
+			name:  "Empty document",
+			body:  "",
+			error: errors.ErrMalformedBody,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params RBACDocument
+			err := params.Validate(strings.NewReader(tt.body))
+
+			switch {
+			case tt.error == errors.ErrMalformedBody:
+				assert.ErrorIs(t, err, errors.ErrMalformedBody)
+			case tt.error != nil:
+				assert.Equal(t, tt.error, err)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, params.Config())
+			}
+		})
+	}
+}
@@ -1,9 +1,7 @@
 package dto
 
 import (
-	"encoding/json"
 	"io"
-	"strings"
 
 	"github.com/google/uuid"
 
@@ -13,23 +11,32 @@ import (
 type RoleRequest struct {
 	Name          string      `json:"name"`
 	Description   string      `json:"description"`
-	PermissionIDs []uuid.UUID `json:"permission_ids,omitempty"`
+	PermissionIDs []uuid.UUID `json:"permission_ids"`
 }
 
 func (params *RoleRequest) Validate(body io.Reader) error {
-	if err := json.NewDecoder(body).Decode(params); err != nil {
+	if err := decode(body, params); err != nil {
 		return err
 	}
 
-	params.Name = strings.TrimSpace(params.Name)
-	if params.Name == "" {
-		return errors.ErrEmptyName
-	}
+	return params.validate()
+}
 
-	params.Description = strings.TrimSpace(params.Description)
-	if params.Description == "" {
-		return errors.ErrEmptyDescription
+// Patch applies a merge patch or JSON patch document on top of params and validates the result
+func (params *RoleRequest) Patch(contentType string, body io.Reader) error {
+	params.PermissionIDs = present(params.PermissionIDs)
+
+	if err := patch(contentType, body, params); err != nil {
+		return err
 	}
 
-	return nil
+	return params.validate()
+}
+
+func (params *RoleRequest) validate() error {
+	var v validator
+	v.required(&params.Name, "/name", errors.ErrEmptyName)
+	v.required(&params.Description, "/description", errors.ErrEmptyDescription)
+
+	return v.err()
 }
@@ -22,14 +22,35 @@ func Test_Validate_ScopeRequest(t *testing.T) {
 			expected: nil,
 		},
 		{
-			name:     "Empty name",
-			body:     strings.NewReader(`{"name": "", "description": "Self-service scope"}`),
-			expected: errors.ErrEmptyName,
+			name: "Empty name",
+			body: strings.NewReader(`{"name": "", "description": "Self-service scope"}`),
+			expected: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/name", Code: errors.RequiredCode, Detail: errors.ErrEmptyName.Error()},
+				},
+			},
 		},
 		{
-			name:     "Empty description",
-			body:     strings.NewReader(`{"name": "self-service", "description": ""}`),
-			expected: errors.ErrEmptyDescription,
+			name: "Empty description",
+			body: strings.NewReader(`{"name": "self-service", "description": ""}`),
+			expected: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/description", Code: errors.RequiredCode, Detail: errors.ErrEmptyDescription.Error()},
+				},
+			},
+		},
+		{
+			name: "Every violation",
+			body: strings.NewReader(`{"name": "", "description": "  "}`),
+			expected: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/name", Code: errors.RequiredCode, Detail: errors.ErrEmptyName.Error()},
+					{Pointer: "/description", Code: errors.RequiredCode, Detail: errors.ErrEmptyDescription.Error()},
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,207 @@
+package dto
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+)
+
+// SnapshotArchive is the versioned JSON archive of a snapshot, roles and users refer to the archived records by id
+type SnapshotArchive struct {
+	Version     int                         `json:"version"`
+	CreatedBy   string                      `json:"created_by"`
+	CreatedAt   time.Time                   `json:"created_at"`
+	Permissions []SnapshotPermissionRequest `json:"permissions"`
+	Roles       []SnapshotRoleRequest       `json:"roles"`
+	Scopes      []SnapshotScopeRequest      `json:"scopes"`
+	Users       []SnapshotUserRequest       `json:"users"`
+}
+
+type SnapshotPermissionRequest struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+type SnapshotRoleRequest struct {
+	ID            uuid.UUID   `json:"id"`
+	Name          string      `json:"name"`
+	Description   string      `json:"description"`
+	PermissionIDs []uuid.UUID `json:"permission_ids,omitempty"`
+}
+
+type SnapshotScopeRequest struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+type SnapshotUserRequest struct {
+	ID             uuid.UUID   `json:"id"`
+	IdentityNumber string      `json:"identity_number"`
+	PersonalCode   string      `json:"personal_code"`
+	FirstName      string      `json:"first_name"`
+	LastName       string      `json:"last_name"`
+	RoleIDs        []uuid.UUID `json:"role_ids,omitempty"`
+	ScopeIDs       []uuid.UUID `json:"scope_ids,omitempty"`
+}
+
+// Validate decodes the archive and reports an unsupported version, blank and duplicate names
+// and references to records missing from the archive
+func (params *SnapshotArchive) Validate(body io.Reader) error {
+	if err := decode(body, params); err != nil {
+		return err
+	}
+
+	var v validator
+
+	if params.Version != models.SnapshotVersion {
+		v.fields = append(v.fields, errors.FieldError{
+			Pointer: "/version",
+			Code:    errors.InvalidCode,
+			Detail:  fmt.Sprintf("unsupported version %d, expected %d", params.Version, models.SnapshotVersion),
+		})
+	}
+
+	permissionIds := make(map[uuid.UUID]bool, len(params.Permissions))
+	permissions := make(map[string]string, len(params.Permissions))
+	for i := range params.Permissions {
+		item := &params.Permissions[i]
+		v.required(&item.Name, fmt.Sprintf("/permissions/%d/name", i), errors.ErrEmptyName)
+		v.required(&item.Description, fmt.Sprintf("/permissions/%d/description", i), errors.ErrEmptyDescription)
+		v.unique(permissions, item.Name, fmt.Sprintf("/permissions/%d/name", i))
+		permissionIds[item.ID] = true
+	}
+
+	roleIds := make(map[uuid.UUID]bool, len(params.Roles))
+	roles := make(map[string]string, len(params.Roles))
+	for i := range params.Roles {
+		item := &params.Roles[i]
+		v.required(&item.Name, fmt.Sprintf("/roles/%d/name", i), errors.ErrEmptyName)
+		v.required(&item.Description, fmt.Sprintf("/roles/%d/description", i), errors.ErrEmptyDescription)
+		v.unique(roles, item.Name, fmt.Sprintf("/roles/%d/name", i))
+		v.archived(permissionIds, item.PermissionIDs, fmt.Sprintf("/roles/%d/permission_ids", i), "permissions")
+		roleIds[item.ID] = true
+	}
+
+	scopeIds := make(map[uuid.UUID]bool, len(params.Scopes))
+	scopes := make(map[string]string, len(params.Scopes))
+	for i := range params.Scopes {
+		item := &params.Scopes[i]
+		v.required(&item.Name, fmt.Sprintf("/scopes/%d/name", i), errors.ErrEmptyName)
+		v.required(&item.Description, fmt.Sprintf("/scopes/%d/description", i), errors.ErrEmptyDescription)
+		v.unique(scopes, item.Name, fmt.Sprintf("/scopes/%d/name", i))
+		scopeIds[item.ID] = true
+	}
+
+	users := make(map[string]string, len(params.Users))
+	for i := range params.Users {
+		item := &params.Users[i]
+		v.required(&item.IdentityNumber, fmt.Sprintf("/users/%d/identity_number", i), errors.ErrEmptyIdentityNumber)
+		v.required(&item.PersonalCode, fmt.Sprintf("/users/%d/personal_code", i), errors.ErrEmptyPersonalCode)
+		v.required(&item.FirstName, fmt.Sprintf("/users/%d/first_name", i), errors.ErrEmptyFirstName)
+		v.required(&item.LastName, fmt.Sprintf("/users/%d/last_name", i), errors.ErrEmptyLastName)
+		v.unique(users, item.IdentityNumber, fmt.Sprintf("/users/%d/identity_number", i))
+		v.archived(roleIds, item.RoleIDs, fmt.Sprintf("/users/%d/role_ids", i), "roles")
+		v.archived(scopeIds, item.ScopeIDs, fmt.Sprintf("/users/%d/scope_ids", i), "scopes")
+	}
+
+	return v.err()
+}
+
+// Snapshot converts the archive into the snapshot the restore diffs against the live state
+func (params *SnapshotArchive) Snapshot() *models.Snapshot {
+	result := &models.Snapshot{
+		Version:     params.Version,
+		ActorID:     params.CreatedBy,
+		CreatedAt:   params.CreatedAt,
+		Permissions: make([]models.Permission, 0, len(params.Permissions)),
+		Roles:       make([]models.Role, 0, len(params.Roles)),
+		Scopes:      make([]models.Scope, 0, len(params.Scopes)),
+		Users:       make([]models.User, 0, len(params.Users)),
+	}
+
+	for _, item := range params.Permissions {
+		result.Permissions = append(result.Permissions, models.Permission{ID: item.ID, Name: item.Name, Description: item.Description})
+	}
+
+	for _, item := range params.Roles {
+		result.Roles = append(result.Roles, models.Role{ID: item.ID, Name: item.Name, Description: item.Description, PermissionIDs: item.PermissionIDs})
+	}
+
+	for _, item := range params.Scopes {
+		result.Scopes = append(result.Scopes, models.Scope{ID: item.ID, Name: item.Name, Description: item.Description})
+	}
+
+	for _, item := range params.Users {
+		result.Users = append(result.Users, models.User{
+			ID:             item.ID,
+			IdentityNumber: item.IdentityNumber,
+			PersonalCode:   item.PersonalCode,
+			FirstName:      item.FirstName,
+			LastName:       item.LastName,
+			RoleIDs:        item.RoleIDs,
+			ScopeIDs:       item.ScopeIDs,
+		})
+	}
+
+	return result
+}
+
+// NewSnapshotArchive converts the snapshot into the archive it is downloaded and stored as,
+// the same type reads it back so that every archive written can be restored
+func NewSnapshotArchive(snapshot *models.Snapshot) *SnapshotArchive {
+	archive := &SnapshotArchive{
+		Version:     snapshot.Version,
+		CreatedBy:   snapshot.ActorID,
+		CreatedAt:   snapshot.CreatedAt,
+		Permissions: make([]SnapshotPermissionRequest, 0, len(snapshot.Permissions)),
+		Roles:       make([]SnapshotRoleRequest, 0, len(snapshot.Roles)),
+		Scopes:      make([]SnapshotScopeRequest, 0, len(snapshot.Scopes)),
+		Users:       make([]SnapshotUserRequest, 0, len(snapshot.Users)),
+	}
+
+	for _, item := range snapshot.Permissions {
+		archive.Permissions = append(archive.Permissions, SnapshotPermissionRequest{ID: item.ID, Name: item.Name, Description: item.Description})
+	}
+
+	for _, item := range snapshot.Roles {
+		archive.Roles = append(archive.Roles, SnapshotRoleRequest{ID: item.ID, Name: item.Name, Description: item.Description, PermissionIDs: item.PermissionIDs})
+	}
+
+	for _, item := range snapshot.Scopes {
+		archive.Scopes = append(archive.Scopes, SnapshotScopeRequest{ID: item.ID, Name: item.Name, Description: item.Description})
+	}
+
+	for _, item := range snapshot.Users {
+		archive.Users = append(archive.Users, SnapshotUserRequest{
+			ID:             item.ID,
+			IdentityNumber: item.IdentityNumber,
+			PersonalCode:   item.PersonalCode,
+			FirstName:      item.FirstName,
+			LastName:       item.LastName,
+			RoleIDs:        item.RoleIDs,
+			ScopeIDs:       item.ScopeIDs,
+		})
+	}
+
+	return archive
+}
+
+// archived records an unknown id violation for every id missing from the archived collection
+func (v *validator) archived(known map[uuid.UUID]bool, ids []uuid.UUID, pointer, collection string) {
+	for i, id := range ids {
+		if !known[id] {
+			v.fields = append(v.fields, errors.FieldError{
+				Pointer: fmt.Sprintf("%s/%d", pointer, i),
+				Code:    errors.UnknownIdCode,
+				Detail:  fmt.Sprintf("%s is not archived in %s", id, collection),
+			})
+		}
+	}
+}
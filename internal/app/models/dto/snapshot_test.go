@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+)
+
+func Test_Validate_SnapshotArchive(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected *models.Snapshot
+		error    error
+	}{
+		{
+			name: "Success",
+			body: `{
+				"version": 1,
+				"created_by": "actor",
+				"created_at": "2025-06-01T12:00:00Z",
+				"permissions": [{"id": "10000000-1000-1000-4000-000000000001", "name": "read:users", "description": "Read users"}],
+				"roles": [{"id": "10000000-1000-1000-3000-000000000001", "name": "admin", "description": "Administrator", "permission_ids": ["10000000-1000-1000-4000-000000000001"]}],
+				"scopes": [],
+				"users": [{"id": "10000000-1000-1000-1000-000000000001", "identity_number": "PNOEE-60001017869", "personal_code": "60001017869", "first_name": "John", "last_name": "Doe", "role_ids": ["10000000-1000-1000-3000-000000000001"]}]
+			}`,
+			expected: &models.Snapshot{
+				Version: 1,
+				ActorID: "actor",
+				Permissions: []models.Permission{
+					{ID: uuid.MustParse("10000000-1000-1000-4000-000000000001"), Name: "read:users", Description: "Read users"},
+				},
+				Roles: []models.Role{
+					{
+						ID:            uuid.MustParse("10000000-1000-1000-3000-000000000001"),
+						Name:          "admin",
+						Description:   "Administrator",
+						PermissionIDs: []uuid.UUID{uuid.MustParse("10000000-1000-1000-4000-000000000001")},
+					},
+				},
+				Scopes: []models.Scope{},
+				Users: []models.User{
+					{
+						ID:             uuid.MustParse("10000000-1000-1000-1000-000000000001"),
+						IdentityNumber: "PNOEE-60001017869",
+						PersonalCode:   "60001017869",
+						FirstName:      "John",
+						LastName:       "Doe",
+						RoleIDs:        []uuid.UUID{uuid.MustParse("10000000-1000-1000-3000-000000000001")},
+					},
+				},
+			},
+		},
+		{
+			name: "Invalid",
+			body: `{
+				"version": 2,
+				"roles": [{"id": "10000000-1000-1000-3000-000000000001", "name": "admin", "description": "Administrator", "permission_ids": ["10000000-1000-1000-4000-000000000001"]}],
+				"users": [{"identity_number": "PNOEE-60001017869", "personal_code": "60001017869", "first_name": "John"}]
+			}`,
+			error: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/version", Code: errors.InvalidCode, Detail: "unsupported version 2, expected 1"},
+					{Pointer: "/roles/0/permission_ids/0", Code: errors.UnknownIdCode, Detail: "10000000-1000-1000-4000-000000000001 is not archived in permissions"},
+					{Pointer: "/users/0/last_name", Code: errors.RequiredCode, Detail: errors.ErrEmptyLastName.Error()},
+				},
+			},
+		},
+		{
+			name:  "Malformed",
+			body:  `{"version": 1, "roles": [{"id": "admin"}]}`,
+			error: errors.ErrMalformedBody,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params SnapshotArchive
+			err := params.Validate(strings.NewReader(tt.body))
+
+			switch {
+			case tt.error == errors.ErrMalformedBody:
+				assert.ErrorIs(t, err, errors.ErrMalformedBody)
+			case tt.error != nil:
+				assert.Equal(t, tt.error, err)
+			default:
+				assert.NoError(t, err)
+
+				result := params.Snapshot()
+				assert.Equal(t, "2025-06-01T12:00:00Z", result.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
+
+				result.CreatedAt = tt.expected.CreatedAt
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
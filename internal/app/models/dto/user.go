@@ -1,9 +1,7 @@
 package dto
 
 import (
-	"encoding/json"
 	"io"
-	"strings"
 
 	"github.com/google/uuid"
 
@@ -15,34 +13,36 @@ type UserRequest struct {
 	PersonalCode   string      `json:"personal_code"`
 	FirstName      string      `json:"first_name"`
 	LastName       string      `json:"last_name"`
-	RoleIDs        []uuid.UUID `json:"role_ids,omitempty"`
-	ScopeIDs       []uuid.UUID `json:"scope_ids,omitempty"`
+	RoleIDs        []uuid.UUID `json:"role_ids"`
+	ScopeIDs       []uuid.UUID `json:"scope_ids"`
 }
 
 func (params *UserRequest) Validate(body io.Reader) error {
-	if err := json.NewDecoder(body).Decode(params); err != nil {
+	if err := decode(body, params); err != nil {
 		return err
 	}
 
-	params.IdentityNumber = strings.TrimSpace(params.IdentityNumber)
-	if params.IdentityNumber == "" {
-		return errors.ErrEmptyIdentityNumber
-	}
+	return params.validate()
+}
 
-	params.PersonalCode = strings.TrimSpace(params.PersonalCode)
-	if params.PersonalCode == "" {
-		return errors.ErrEmptyPersonalCode
-	}
+// Patch applies a merge patch or JSON patch document on top of params and validates the result
+func (params *UserRequest) Patch(contentType string, body io.Reader) error {
+	params.RoleIDs = present(params.RoleIDs)
+	params.ScopeIDs = present(params.ScopeIDs)
 
-	params.FirstName = strings.TrimSpace(params.FirstName)
-	if params.FirstName == "" {
-		return errors.ErrEmptyFirstName
+	if err := patch(contentType, body, params); err != nil {
+		return err
 	}
 
-	params.LastName = strings.TrimSpace(params.LastName)
-	if params.LastName == "" {
-		return errors.ErrEmptyLastName
-	}
+	return params.validate()
+}
+
+func (params *UserRequest) validate() error {
+	var v validator
+	v.required(&params.IdentityNumber, "/identity_number", errors.ErrEmptyIdentityNumber)
+	v.required(&params.PersonalCode, "/personal_code", errors.ErrEmptyPersonalCode)
+	v.required(&params.FirstName, "/first_name", errors.ErrEmptyFirstName)
+	v.required(&params.LastName, "/last_name", errors.ErrEmptyLastName)
 
-	return nil
+	return v.err()
 }
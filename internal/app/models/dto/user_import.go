@@ -0,0 +1,191 @@
+package dto
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"loki-backoffice/internal/app/errors"
+)
+
+const (
+	CSVContentType = "text/csv"
+
+	// UserImportMaxRows caps the number of data rows accepted in a single import
+	UserImportMaxRows = 1000
+
+	// UserImportListSeparator separates the role and scope names within a cell
+	UserImportListSeparator = ";"
+)
+
+const (
+	IdentityNumberColumn = "identity_number"
+	PersonalCodeColumn   = "personal_code"
+	FirstNameColumn      = "first_name"
+	LastNameColumn       = "last_name"
+	RolesColumn          = "roles"
+	ScopesColumn         = "scopes"
+)
+
+var userImportRequiredColumns = []string{IdentityNumberColumn, PersonalCodeColumn, FirstNameColumn, LastNameColumn}
+
+// UserImportRow is a data row of the import, Line is the line of the row in the file
+// and Errors lists the violations of the row itself
+type UserImportRow struct {
+	Line int
+	UserRequest
+	Roles  []string
+	Scopes []string
+	Errors []errors.FieldError
+}
+
+// UserImportRequest is a CSV file with a header row, the roles and scopes columns are optional
+// and a missing column leaves the assignments of existing users untouched
+type UserImportRequest struct {
+	Rows      []UserImportRow
+	HasRoles  bool
+	HasScopes bool
+}
+
+// Parse reads the CSV body, rows that fail to parse or validate are kept with their errors
+// so that a preview can report every problem of the file at once
+func (params *UserImportRequest) Parse(contentType string, body io.Reader) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != CSVContentType {
+		return errors.ErrUnsupportedMediaType
+	}
+
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrMalformedBody, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	var v validator
+	for _, name := range userImportRequiredColumns {
+		_, ok := columns[name]
+		v.present(ok, "/"+name)
+	}
+	if err = v.err(); err != nil {
+		return err
+	}
+
+	_, params.HasRoles = columns[RolesColumn]
+	_, params.HasScopes = columns[ScopesColumn]
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if len(params.Rows) == UserImportMaxRows {
+			return &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Code: errors.InvalidCode, Detail: fmt.Sprintf("expected at most %d rows", UserImportMaxRows)},
+				},
+			}
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return fmt.Errorf("%w: %w", errors.ErrMalformedBody, err)
+			}
+
+			params.Rows = append(params.Rows, UserImportRow{
+				Line:   parseErr.StartLine,
+				Errors: []errors.FieldError{{Code: errors.InvalidCode, Detail: err.Error()}},
+			})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		params.Rows = append(params.Rows, parseUserImportRow(line, record, columns))
+	}
+
+	if len(params.Rows) == 0 {
+		return &errors.ValidationError{
+			Err:    errors.ErrInvalidArguments,
+			Fields: []errors.FieldError{{Code: errors.RequiredCode, Detail: "expected at least one row"}},
+		}
+	}
+
+	params.markDuplicates()
+
+	return nil
+}
+
+func parseUserImportRow(line int, record []string, columns map[string]int) UserImportRow {
+	cell := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	row := UserImportRow{
+		Line: line,
+		UserRequest: UserRequest{
+			IdentityNumber: cell(IdentityNumberColumn),
+			PersonalCode:   cell(PersonalCodeColumn),
+			FirstName:      cell(FirstNameColumn),
+			LastName:       cell(LastNameColumn),
+		},
+		Roles:  splitNames(cell(RolesColumn)),
+		Scopes: splitNames(cell(ScopesColumn)),
+	}
+
+	var validation *errors.ValidationError
+	if errors.As(row.validate(), &validation) {
+		row.Errors = append(row.Errors, validation.Fields...)
+	}
+
+	return row
+}
+
+// markDuplicates flags every row repeating the identity number of an earlier row
+func (params *UserImportRequest) markDuplicates() {
+	seen := make(map[string]int, len(params.Rows))
+
+	for i, row := range params.Rows {
+		key := strings.ToUpper(row.IdentityNumber)
+		if key == "" {
+			continue
+		}
+
+		if line, ok := seen[key]; ok {
+			params.Rows[i].Errors = append(params.Rows[i].Errors, errors.FieldError{
+				Pointer: "/" + IdentityNumberColumn,
+				Code:    errors.DuplicateCode,
+				Detail:  fmt.Sprintf("already used on line %d", line),
+			})
+			continue
+		}
+
+		seen[key] = row.Line
+	}
+}
+
+func splitNames(value string) []string {
+	names := make([]string, 0)
+
+	for _, name := range strings.Split(value, UserImportListSeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
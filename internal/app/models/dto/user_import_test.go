@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"loki-backoffice/internal/app/errors"
+)
+
+func Test_Parse_UserImportRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		expected    *UserImportRequest
+		error       error
+	}{
+		{
+			name:        "Success",
+			contentType: "text/csv; charset=utf-8",
+			body: "\ufeffIdentity_Number,personal_code,first_name,last_name,roles\n" +
+				"PNOEE-60001017869,60001017869,John,Doe,admin; manager\n" +
+				"PNOEE-60001017870,60001017870,Jane,Doe,\n",
+			expected: &UserImportRequest{
+				HasRoles: true,
+				Rows: []UserImportRow{
+					{
+						Line:        2,
+						UserRequest: UserRequest{IdentityNumber: "PNOEE-60001017869", PersonalCode: "60001017869", FirstName: "John", LastName: "Doe"},
+						Roles:       []string{"admin", "manager"},
+						Scopes:      []string{},
+					},
+					{
+						Line:        3,
+						UserRequest: UserRequest{IdentityNumber: "PNOEE-60001017870", PersonalCode: "60001017870", FirstName: "Jane", LastName: "Doe"},
+						Roles:       []string{},
+						Scopes:      []string{},
+					},
+				},
+			},
+		},
+		{
+			name:        "Row errors",
+			contentType: "text/csv",
+			body: "identity_number,personal_code,first_name,last_name\n" +
+				"PNOEE-60001017869,60001017869,John,Doe\n" +
+				"pnoee-60001017869,60001017869,,Doe\n" +
+				"PNOEE-60001017870,\"6000,Jane,Doe\n",
+			expected: &UserImportRequest{
+				Rows: []UserImportRow{
+					{
+						Line:        2,
+						UserRequest: UserRequest{IdentityNumber: "PNOEE-60001017869", PersonalCode: "60001017869", FirstName: "John", LastName: "Doe"},
+						Roles:       []string{},
+						Scopes:      []string{},
+					},
+					{
+						Line:        3,
+						UserRequest: UserRequest{IdentityNumber: "pnoee-60001017869", PersonalCode: "60001017869", LastName: "Doe"},
+						Roles:       []string{},
+						Scopes:      []string{},
+						Errors: []errors.FieldError{
+							{Pointer: "/first_name", Code: errors.RequiredCode, Detail: errors.ErrEmptyFirstName.Error()},
+							{Pointer: "/identity_number", Code: errors.DuplicateCode, Detail: "already used on line 2"},
+						},
+					},
+					{
+						Line: 4,
+						Errors: []errors.FieldError{
+							{Code: errors.InvalidCode, Detail: "parse error on line 4, column 34: extraneous or missing \" in quoted-field"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:        "Missing columns",
+			contentType: "text/csv",
+			body:        "identity_number,first_name\nPNOEE-60001017869,John\n",
+			error: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/personal_code", Code: errors.RequiredCode},
+					{Pointer: "/last_name", Code: errors.RequiredCode},
+				},
+			},
+		},
+		{
+			name:        "Without rows",
+			contentType: "text/csv",
+			body:        "identity_number,personal_code,first_name,last_name\n",
+			error: &errors.ValidationError{
+				Err:    errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{{Code: errors.RequiredCode, Detail: "expected at least one row"}},
+			},
+		},
+		{
+			name:        "Unsupported media type",
+			contentType: "application/json",
+			body:        "{}",
+			error:       errors.ErrUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params UserImportRequest
+			err := params.Parse(tt.contentType, strings.NewReader(tt.body))
+
+			if tt.error != nil {
+				assert.Equal(t, tt.error, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, &params)
+			}
+		})
+	}
+}
@@ -22,24 +22,67 @@ func Test_Validate_UserRequest(t *testing.T) {
 			expected: nil,
 		},
 		{
-			name:     "Empty Identity Number",
-			body:     strings.NewReader(`{"identity_number": "", "personal_code": "123123123", "first_name": "John", "last_name": "Doe"}`),
-			expected: errors.ErrEmptyIdentityNumber,
+			name: "Empty Identity Number",
+			body: strings.NewReader(`{"identity_number": "", "personal_code": "123123123", "first_name": "John", "last_name": "Doe"}`),
+			expected: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/identity_number", Code: errors.RequiredCode, Detail: errors.ErrEmptyIdentityNumber.Error()},
+				},
+			},
 		},
 		{
-			name:     "Empty Personal Code",
-			body:     strings.NewReader(`{"identity_number": "PNOEE-123123123", "personal_code": "", "first_name": "John", "last_name": "Doe"}`),
-			expected: errors.ErrEmptyPersonalCode,
+			name: "Empty Personal Code",
+			body: strings.NewReader(`{"identity_number": "PNOEE-123123123", "personal_code": "", "first_name": "John", "last_name": "Doe"}`),
+			expected: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/personal_code", Code: errors.RequiredCode, Detail: errors.ErrEmptyPersonalCode.Error()},
+				},
+			},
 		},
 		{
-			name:     "Empty First Name",
-			body:     strings.NewReader(`{"identity_number": "PNOEE-123123123", "personal_code": "123123123", "first_name": "", "last_name": "Doe"}`),
-			expected: errors.ErrEmptyFirstName,
+			name: "Empty First Name",
+			body: strings.NewReader(`{"identity_number": "PNOEE-123123123", "personal_code": "123123123", "first_name": "", "last_name": "Doe"}`),
+			expected: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/first_name", Code: errors.RequiredCode, Detail: errors.ErrEmptyFirstName.Error()},
+				},
+			},
 		},
 		{
-			name:     "Empty Last Name",
-			body:     strings.NewReader(`{"identity_number": "PNOEE-123123123", "personal_code": "123123123", "first_name": "John", "last_name": ""}`),
-			expected: errors.ErrEmptyLastName,
+			name: "Empty Last Name",
+			body: strings.NewReader(`{"identity_number": "PNOEE-123123123", "personal_code": "123123123", "first_name": "John", "last_name": ""}`),
+			expected: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/last_name", Code: errors.RequiredCode, Detail: errors.ErrEmptyLastName.Error()},
+				},
+			},
+		},
+		{
+			name: "Every violation",
+			body: strings.NewReader(`{"identity_number": " ", "personal_code": "", "first_name": "", "last_name": ""}`),
+			expected: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/identity_number", Code: errors.RequiredCode, Detail: errors.ErrEmptyIdentityNumber.Error()},
+					{Pointer: "/personal_code", Code: errors.RequiredCode, Detail: errors.ErrEmptyPersonalCode.Error()},
+					{Pointer: "/first_name", Code: errors.RequiredCode, Detail: errors.ErrEmptyFirstName.Error()},
+					{Pointer: "/last_name", Code: errors.RequiredCode, Detail: errors.ErrEmptyLastName.Error()},
+				},
+			},
+		},
+		{
+			name: "Invalid type",
+			body: strings.NewReader(`{"identity_number": 1}`),
+			expected: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/identity_number", Code: errors.InvalidCode, Detail: "expected string"},
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"loki-backoffice/internal/app/errors"
+)
+
+// validator collects every violation of a request instead of stopping at the first one
+type validator struct {
+	fields []errors.FieldError
+}
+
+// decode reads the request body, type mismatches are reported as field errors
+// and anything else the decoder rejects as a malformed body
+func decode(body io.Reader, params any) error {
+	err := json.NewDecoder(body).Decode(params)
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return &errors.ValidationError{
+			Err: errors.ErrInvalidArguments,
+			Fields: []errors.FieldError{
+				{
+					Pointer: "/" + strings.ReplaceAll(typeErr.Field, ".", "/"),
+					Code:    errors.InvalidCode,
+					Detail:  "expected " + typeErr.Type.String(),
+				},
+			},
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrMalformedBody, err)
+	}
+
+	return nil
+}
+
+// required trims the value in place and records err when it is blank
+func (v *validator) required(value *string, pointer string, err error) {
+	*value = strings.TrimSpace(*value)
+	if *value == "" {
+		v.fields = append(v.fields, errors.FieldError{
+			Pointer: pointer,
+			Code:    errors.RequiredCode,
+			Detail:  err.Error(),
+		})
+	}
+}
+
+// present records a required violation at pointer unless ok
+func (v *validator) present(ok bool, pointer string) {
+	if !ok {
+		v.fields = append(v.fields, errors.FieldError{
+			Pointer: pointer,
+			Code:    errors.RequiredCode,
+		})
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+
+	return &errors.ValidationError{
+		Err:    errors.ErrInvalidArguments,
+		Fields: v.fields,
+	}
+}
+
+// unique records a duplicate violation when a non-blank value was already seen at another pointer
+func (v *validator) unique(seen map[string]string, value string, pointer string) {
+	if value == "" {
+		return
+	}
+
+	if first, ok := seen[value]; ok {
+		v.fields = append(v.fields, errors.FieldError{
+			Pointer: pointer,
+			Code:    errors.DuplicateCode,
+			Detail:  fmt.Sprintf("already declared at %s", first),
+		})
+		return
+	}
+
+	seen[value] = pointer
+}
@@ -0,0 +1,13 @@
+package models
+
+import "github.com/google/uuid"
+
+type EffectivePermission struct {
+	Permission Permission
+	GrantedBy  []Role
+}
+
+type EffectivePermissions struct {
+	UserID      uuid.UUID
+	Permissions []EffectivePermission
+}
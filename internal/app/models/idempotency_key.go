@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKey is the stored outcome of a create request, StatusCode stays zero while it is in flight
+type IdempotencyKey struct {
+	Key         string
+	ActorID     string
+	Route       string
+	RequestHash string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	LockedUntil time.Time
+	// Owner tells the request holding the reservation apart from a retry that reclaimed it
+	Owner uuid.UUID
+}
+
+// IsPending reports whether the first request under the key has not completed yet
+func (k *IdempotencyKey) IsPending() bool {
+	return k.StatusCode == 0
+}
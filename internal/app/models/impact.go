@@ -0,0 +1,15 @@
+package models
+
+import "github.com/google/uuid"
+
+// Impact lists the records referencing a resource that would be affected by its deletion
+type Impact struct {
+	ResourceType string
+	ResourceId   uuid.UUID
+	Roles        []Role
+	Users        []User
+}
+
+func (i *Impact) IsEmpty() bool {
+	return len(i.Roles) == 0 && len(i.Users) == 0
+}
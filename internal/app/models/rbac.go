@@ -0,0 +1,50 @@
+package models
+
+import "github.com/google/uuid"
+
+// RBACConfig is the desired set of permissions, roles and scopes. A nil section is not managed,
+// records missing from a managed section are planned for deletion only when pruning
+type RBACConfig struct {
+	Permissions []RBACResource
+	Roles       []RBACResource
+	Scopes      []RBACResource
+}
+
+// RBACResource is a permission, role or scope identified by name, only roles have permissions
+type RBACResource struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// RBACChange is a single create, update or delete of a plan, before is the live state and after the desired one
+type RBACChange struct {
+	Action       string
+	ResourceType string
+	ID           uuid.UUID
+	Name         string
+	Fields       []string
+	Before       *RBACResource
+	After        *RBACResource
+	Dependents   *Impact
+
+	State string
+	Err   error
+}
+
+// RBACPlan lists the changes in the order they are applied
+type RBACPlan struct {
+	Changes []RBACChange
+}
+
+// Count returns the number of changes with the given action
+func (p *RBACPlan) Count(action string) int {
+	count := 0
+	for _, change := range p.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Seek positions a newest first list right after the record with the given created_at and id,
+// or right before it when Reverse is set
+type Seek struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+	Reverse   bool
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SnapshotVersion is the archive format version written by this release
+const SnapshotVersion = 1
+
+// Snapshot is a point-in-time copy of the permissions, roles, scopes and users,
+// ID is only set once the snapshot is stored
+type Snapshot struct {
+	ID        uuid.UUID
+	Version   int
+	ActorID   string
+	CreatedAt time.Time
+
+	Permissions []Permission
+	Roles       []Role
+	Scopes      []Scope
+	Users       []User
+}
+
+// SnapshotRecord is a stored snapshot, Archive holds the JSON archive
+type SnapshotRecord struct {
+	ID        uuid.UUID
+	Version   int
+	ActorID   string
+	Archive   []byte
+	CreatedAt time.Time
+}
+
+// SnapshotUser is a user with its roles and scopes referred to by name
+type SnapshotUser struct {
+	IdentityNumber string
+	PersonalCode   string
+	FirstName      string
+	LastName       string
+	Roles          []string
+	Scopes         []string
+}
+
+// SnapshotUserChange is a single user change of a restore, users are matched by identity number
+type SnapshotUserChange struct {
+	Action         string
+	ID             uuid.UUID
+	IdentityNumber string
+	Fields         []string
+	Before         *SnapshotUser
+	After          *SnapshotUser
+	State          string
+	Err            error
+}
+
+// SnapshotRestore is the outcome of restoring a snapshot, permissions, roles and scopes are restored first.
+// Retained lists the identity numbers of live users missing from the snapshot that were kept
+type SnapshotRestore struct {
+	RBAC        *RBACPlan
+	Users       []SnapshotUserChange
+	DeleteUsers bool
+	Retained    []string
+}
@@ -15,9 +15,28 @@ const (
 )
 
 type Token struct {
-	ID        uuid.UUID
-	UserId    uuid.UUID
-	Type      string
-	Value     string
-	ExpiresAt time.Time
+	ID          uuid.UUID
+	UserId      uuid.UUID
+	Type        string
+	Value       string
+	Fingerprint string
+	Metadata    *TokenMetadata
+	ExpiresAt   time.Time
+}
+
+type TokenMetadata struct {
+	Issuer   string
+	IssuedAt time.Time
+	Scope    []string
+}
+
+type TokenRevocation struct {
+	UserId  uuid.UUID
+	Revoked []Token
+	Failed  []TokenRevocationFailure
+}
+
+type TokenRevocationFailure struct {
+	Token Token
+	Error error
 }
@@ -12,3 +12,11 @@ type User struct {
 	RoleIDs  []uuid.UUID
 	ScopeIDs []uuid.UUID
 }
+
+// UserExport is a user with the names of its roles and scopes resolved
+type UserExport struct {
+	User
+
+	Roles  []string
+	Scopes []string
+}
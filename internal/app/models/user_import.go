@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+)
+
+const (
+	PendingImportStatus   = "pending"
+	RunningImportStatus   = "running"
+	CompletedImportStatus = "completed"
+)
+
+// UserImport is a previewed CSV import of users, it is applied once confirmed
+type UserImport struct {
+	ID        uuid.UUID
+	ActorID   string
+	Status    string
+	Rows      []UserImportRow
+	HasRoles  bool
+	HasScopes bool
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// UserImportRow is a row of the file resolved against upstream, Action tells whether it creates
+// a user or updates the one holding the identity number. State and Err hold the outcome once the import ran
+type UserImportRow struct {
+	Line   int
+	Action string
+	User   User
+	Roles  []string
+	Scopes []string
+	Errors []errors.FieldError
+	State  string
+	Err    error
+}
+
+func (i *UserImport) Invalid() int {
+	invalid := 0
+	for _, row := range i.Rows {
+		if len(row.Errors) > 0 {
+			invalid++
+		}
+	}
+
+	return invalid
+}
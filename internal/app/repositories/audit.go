@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/repositories/db"
+	"loki-backoffice/internal/app/repositories/postgres"
+)
+
+// AuditRepository is an interface for audit log persistence
+type AuditRepository interface {
+	Create(ctx context.Context, params *models.AuditLog) (*models.AuditLog, error)
+	List(ctx context.Context, filter *models.AuditLogFilter, seek *models.Seek, limit, offset uint64) ([]models.AuditLog, uint64, error)
+}
+
+type audit struct {
+	client postgres.Postgres
+}
+
+// NewAuditRepository creates a new audit repository instance
+func NewAuditRepository(client postgres.Postgres) AuditRepository {
+	return &audit{client: client}
+}
+
+// Create stores a new audit log record
+func (a *audit) Create(ctx context.Context, params *models.AuditLog) (*models.AuditLog, error) {
+	record, err := a.client.Queries().CreateAuditLog(ctx, db.CreateAuditLogParams{
+		ActorID:      params.ActorID,
+		Action:       params.Action,
+		ResourceType: params.ResourceType,
+		ResourceID:   params.ResourceID,
+		BeforeState:  params.Before,
+		AfterState:   params.After,
+		RequestID:    params.RequestID,
+		TraceID:      params.TraceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toAuditLog(record), nil
+}
+
+// List returns audit log records matching the filter, newest first, together with the total count.
+// With a seek the records are taken right after or before the seek position instead of at the offset.
+func (a *audit) List(ctx context.Context, filter *models.AuditLogFilter, seek *models.Seek, limit, offset uint64) ([]models.AuditLog, uint64, error) {
+	rows, err := a.client.Queries().FindAuditLogs(ctx, db.FindAuditLogsParams{
+		ActorID:       filter.ActorID,
+		Action:        filter.Action,
+		ResourceType:  filter.ResourceType,
+		ResourceID:    filter.ResourceID,
+		CreatedFrom:   toTimestamp(filter.From),
+		CreatedTo:     toTimestamp(filter.To),
+		SeekCreatedAt: seekTimestamp(seek),
+		Reverse:       seek != nil && seek.Reverse,
+		SeekID:        seekId(seek),
+		QueryLimit:    int32(limit),  //nolint:gosec
+		QueryOffset:   int32(offset), //nolint:gosec
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := a.client.Queries().CountAuditLogs(ctx, db.CountAuditLogsParams{
+		ActorID:      filter.ActorID,
+		Action:       filter.Action,
+		ResourceType: filter.ResourceType,
+		ResourceID:   filter.ResourceID,
+		CreatedFrom:  toTimestamp(filter.From),
+		CreatedTo:    toTimestamp(filter.To),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	collection := make([]models.AuditLog, 0, len(rows))
+	for _, row := range rows {
+		collection = append(collection, *toAuditLog(row))
+	}
+
+	// A reverse seek reads towards newer records, the page is still returned newest first
+	if seek != nil && seek.Reverse {
+		slices.Reverse(collection)
+	}
+
+	return collection, uint64(total), nil //nolint:gosec
+}
+
+func seekTimestamp(seek *models.Seek) pgtype.Timestamp {
+	if seek == nil {
+		return pgtype.Timestamp{}
+	}
+
+	return toTimestamp(seek.CreatedAt)
+}
+
+func seekId(seek *models.Seek) uuid.UUID {
+	if seek == nil {
+		return uuid.Nil
+	}
+
+	return seek.ID
+}
+
+func toTimestamp(value time.Time) pgtype.Timestamp {
+	if value.IsZero() {
+		return pgtype.Timestamp{}
+	}
+
+	return pgtype.Timestamp{Time: value.UTC(), Valid: true}
+}
+
+func toAuditLog(record db.AuditLog) *models.AuditLog {
+	return &models.AuditLog{
+		ID:           record.ID,
+		ActorID:      record.ActorID,
+		Action:       record.Action,
+		ResourceType: record.ResourceType,
+		ResourceID:   record.ResourceID,
+		Before:       record.BeforeState,
+		After:        record.AfterState,
+		RequestID:    record.RequestID,
+		TraceID:      record.TraceID,
+		CreatedAt:    record.CreatedAt.Time,
+	}
+}
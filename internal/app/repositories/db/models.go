@@ -3,3 +3,43 @@
 //   sqlc v1.27.0
 
 package db
+
+import (
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type AuditLog struct {
+	ID           uuid.UUID
+	ActorID      string
+	Action       string
+	ResourceType string
+	ResourceID   uuid.UUID
+	BeforeState  []byte
+	AfterState   []byte
+	RequestID    string
+	TraceID      string
+	CreatedAt    pgtype.Timestamp
+}
+
+type IdempotencyKey struct {
+	Key          string
+	ActorID      string
+	Route        string
+	RequestHash  string
+	StatusCode   int32
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    pgtype.Timestamp
+	ExpiresAt    pgtype.Timestamp
+	LockedUntil  pgtype.Timestamp
+	Owner        uuid.UUID
+}
+
+type Snapshot struct {
+	ID        uuid.UUID
+	Version   int32
+	ActorID   string
+	Archive   []byte
+	CreatedAt pgtype.Timestamp
+}
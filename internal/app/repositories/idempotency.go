@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/repositories/db"
+	"loki-backoffice/internal/app/repositories/postgres"
+)
+
+// IdempotencyRepository is an interface for idempotency key persistence
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, params *models.IdempotencyKey, ttl, lease time.Duration) (bool, error)
+	Find(ctx context.Context, key, actorId, route string) (*models.IdempotencyKey, error)
+	Renew(ctx context.Context, params *models.IdempotencyKey, lease time.Duration) (bool, error)
+	Complete(ctx context.Context, params *models.IdempotencyKey) error
+	Release(ctx context.Context, params *models.IdempotencyKey) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type idempotency struct {
+	client postgres.Postgres
+}
+
+// NewIdempotencyRepository creates a new idempotency repository instance
+func NewIdempotencyRepository(client postgres.Postgres) IdempotencyRepository {
+	return &idempotency{client: client}
+}
+
+// Reserve claims the key for the owner of params, it reports false while an unexpired record holds the key.
+// A pending reservation is only held for the lease, so a request abandoned mid-flight can be claimed again.
+func (i *idempotency) Reserve(ctx context.Context, params *models.IdempotencyKey, ttl, lease time.Duration) (bool, error) {
+	rows, err := i.client.Queries().ReserveIdempotencyKey(ctx, db.ReserveIdempotencyKeyParams{
+		Key:         params.Key,
+		ActorID:     params.ActorID,
+		Route:       params.Route,
+		RequestHash: params.RequestHash,
+		Ttl:         pgtype.Interval{Microseconds: ttl.Microseconds(), Valid: true},
+		Lease:       pgtype.Interval{Microseconds: lease.Microseconds(), Valid: true},
+		Owner:       params.Owner,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// Renew extends the lease of a pending reservation, it reports false once the owner no longer holds the key
+func (i *idempotency) Renew(ctx context.Context, params *models.IdempotencyKey, lease time.Duration) (bool, error) {
+	rows, err := i.client.Queries().RenewIdempotencyKey(ctx, db.RenewIdempotencyKeyParams{
+		Lease:   pgtype.Interval{Microseconds: lease.Microseconds(), Valid: true},
+		Key:     params.Key,
+		ActorID: params.ActorID,
+		Route:   params.Route,
+		Owner:   params.Owner,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// Find returns the record stored under the key
+func (i *idempotency) Find(ctx context.Context, key, actorId, route string) (*models.IdempotencyKey, error) {
+	record, err := i.client.Queries().FindIdempotencyKey(ctx, db.FindIdempotencyKeyParams{
+		Key:     key,
+		ActorID: actorId,
+		Route:   route,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	return &models.IdempotencyKey{
+		Key:         record.Key,
+		ActorID:     record.ActorID,
+		Route:       record.Route,
+		RequestHash: record.RequestHash,
+		StatusCode:  int(record.StatusCode),
+		ContentType: record.ContentType,
+		Body:        record.ResponseBody,
+		CreatedAt:   record.CreatedAt.Time,
+		ExpiresAt:   record.ExpiresAt.Time,
+		LockedUntil: record.LockedUntil.Time,
+		Owner:       record.Owner,
+	}, nil
+}
+
+// Complete stores the response replayed to retries of the request, unless a retry has reclaimed the key
+func (i *idempotency) Complete(ctx context.Context, params *models.IdempotencyKey) error {
+	return i.client.Queries().CompleteIdempotencyKey(ctx, db.CompleteIdempotencyKeyParams{
+		Key:          params.Key,
+		ActorID:      params.ActorID,
+		Route:        params.Route,
+		Owner:        params.Owner,
+		StatusCode:   int32(params.StatusCode), //nolint:gosec
+		ContentType:  params.ContentType,
+		ResponseBody: params.Body,
+	})
+}
+
+// Release drops a reservation so that the request can be retried, a reservation of another owner is kept
+func (i *idempotency) Release(ctx context.Context, params *models.IdempotencyKey) error {
+	return i.client.Queries().DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{
+		Key:     params.Key,
+		ActorID: params.ActorID,
+		Route:   params.Route,
+		Owner:   params.Owner,
+	})
+}
+
+// DeleteExpired removes every record past its TTL and returns how many were removed
+func (i *idempotency) DeleteExpired(ctx context.Context) (int64, error) {
+	return i.client.Queries().DeleteExpiredIdempotencyKeys(ctx)
+}
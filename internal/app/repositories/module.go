@@ -8,5 +8,8 @@ import (
 
 var Module = fx.Options(
 	fx.Provide(postgres.NewPostgresClient),
+	fx.Provide(NewAuditRepository),
 	fx.Provide(NewHealthRepository),
+	fx.Provide(NewIdempotencyRepository),
+	fx.Provide(NewSnapshotRepository),
 )
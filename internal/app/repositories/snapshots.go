@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/repositories/db"
+	"loki-backoffice/internal/app/repositories/postgres"
+)
+
+// SnapshotRepository is an interface for snapshot persistence
+type SnapshotRepository interface {
+	Create(ctx context.Context, params *models.SnapshotRecord) (*models.SnapshotRecord, error)
+	List(ctx context.Context, seek *models.Seek, limit, offset uint64) ([]models.SnapshotRecord, uint64, error)
+	FindById(ctx context.Context, id uuid.UUID) (*models.SnapshotRecord, error)
+	DeleteBeyondRetention(ctx context.Context, keep int) (int64, error)
+}
+
+type snapshots struct {
+	client postgres.Postgres
+}
+
+// NewSnapshotRepository creates a new snapshot repository instance
+func NewSnapshotRepository(client postgres.Postgres) SnapshotRepository {
+	return &snapshots{client: client}
+}
+
+// Create stores a new snapshot archive
+func (s *snapshots) Create(ctx context.Context, params *models.SnapshotRecord) (*models.SnapshotRecord, error) {
+	record, err := s.client.Queries().CreateSnapshot(ctx, db.CreateSnapshotParams{
+		Version: int32(params.Version), //nolint:gosec
+		ActorID: params.ActorID,
+		Archive: params.Archive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSnapshotRecord(record), nil
+}
+
+// List returns the stored snapshots without their archives, newest first, together with the total count.
+// With a seek the snapshots are taken right after or before the seek position instead of at the offset.
+func (s *snapshots) List(ctx context.Context, seek *models.Seek, limit, offset uint64) ([]models.SnapshotRecord, uint64, error) {
+	rows, err := s.client.Queries().FindSnapshots(ctx, db.FindSnapshotsParams{
+		SeekCreatedAt: seekTimestamp(seek),
+		Reverse:       seek != nil && seek.Reverse,
+		SeekID:        seekId(seek),
+		QueryLimit:    int32(limit),  //nolint:gosec
+		QueryOffset:   int32(offset), //nolint:gosec
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.client.Queries().CountSnapshots(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	collection := make([]models.SnapshotRecord, 0, len(rows))
+	for _, row := range rows {
+		collection = append(collection, models.SnapshotRecord{
+			ID:        row.ID,
+			Version:   int(row.Version),
+			ActorID:   row.ActorID,
+			CreatedAt: row.CreatedAt.Time,
+		})
+	}
+
+	if seek != nil && seek.Reverse {
+		slices.Reverse(collection)
+	}
+
+	return collection, uint64(total), nil //nolint:gosec
+}
+
+// FindById returns the stored snapshot with its archive
+func (s *snapshots) FindById(ctx context.Context, id uuid.UUID) (*models.SnapshotRecord, error) {
+	record, err := s.client.Queries().FindSnapshotById(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.ErrRecordNotFound
+		}
+		return nil, err
+	}
+
+	return toSnapshotRecord(record), nil
+}
+
+// DeleteBeyondRetention keeps the newest snapshots and returns how many older ones were removed
+func (s *snapshots) DeleteBeyondRetention(ctx context.Context, keep int) (int64, error) {
+	return s.client.Queries().DeleteSnapshotsBeyondRetention(ctx, int32(keep)) //nolint:gosec
+}
+
+func toSnapshotRecord(record db.Snapshot) *models.SnapshotRecord {
+	return &models.SnapshotRecord{
+		ID:        record.ID,
+		Version:   int(record.Version),
+		ActorID:   record.ActorID,
+		Archive:   record.Archive,
+		CreatedAt: record.CreatedAt.Time,
+	}
+}
@@ -40,6 +40,8 @@ func NewClient(
 	cfg *config.Config,
 	authInterceptor interceptors.AuthenticationInterceptor,
 	traceInterceptor interceptors.TraceInterceptor,
+	breakerInterceptor interceptors.BreakerInterceptor,
+	retryInterceptor interceptors.RetryInterceptor,
 	logInterceptor interceptors.LoggerInterceptor,
 	log *logger.Logger,
 ) (Client, error) {
@@ -61,6 +63,8 @@ func NewClient(
 		grpc.WithChainUnaryInterceptor(
 			authInterceptor.Authenticate(),
 			traceInterceptor.Trace(),
+			breakerInterceptor.Break(),
+			retryInterceptor.Retry(),
 			logInterceptor.Log(),
 		),
 		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
@@ -18,6 +18,8 @@ func Test_NewClient(t *testing.T) {
 
 	mockAuthInterceptor := interceptors.NewMockAuthenticationInterceptor(ctrl)
 	mockTraceInterceptor := interceptors.NewMockTraceInterceptor(ctrl)
+	mockBreakerInterceptor := interceptors.NewMockBreakerInterceptor(ctrl)
+	mockRetryInterceptor := interceptors.NewMockRetryInterceptor(ctrl)
 	mockLogInterceptor := interceptors.NewMockLoggerInterceptor(ctrl)
 
 	cfg := &config.Config{
@@ -33,6 +35,8 @@ func Test_NewClient(t *testing.T) {
 		cfg,
 		mockAuthInterceptor,
 		mockTraceInterceptor,
+		mockBreakerInterceptor,
+		mockRetryInterceptor,
 		mockLogInterceptor,
 		log)
 	assert.Error(t, err)
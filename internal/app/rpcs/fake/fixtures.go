@@ -0,0 +1,247 @@
+package fake
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+)
+
+// TokenTTL is the lifetime of fixture tokens without expires_at
+const TokenTTL = 24 * time.Hour
+
+// fixtureNamespace derives the ids of fixtures without one, so that ids survive restarts
+var fixtureNamespace = uuid.MustParse("6f2c1a8e-4b1d-4c55-9a0e-3d8f5b7c2e10")
+
+// Fixtures describe the seed records, references are made by name and users are referenced by identity number
+type Fixtures struct {
+	Permissions []PermissionFixture `yaml:"permissions"`
+	Scopes      []ScopeFixture      `yaml:"scopes"`
+	Roles       []RoleFixture       `yaml:"roles"`
+	Users       []UserFixture       `yaml:"users"`
+	Tokens      []TokenFixture      `yaml:"tokens"`
+}
+
+type PermissionFixture struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+type ScopeFixture struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+type RoleFixture struct {
+	ID          string   `yaml:"id"`
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description"`
+	Permissions []string `yaml:"permissions"`
+}
+
+type UserFixture struct {
+	ID             string   `yaml:"id"`
+	IdentityNumber string   `yaml:"identity_number"`
+	PersonalCode   string   `yaml:"personal_code"`
+	FirstName      string   `yaml:"first_name"`
+	LastName       string   `yaml:"last_name"`
+	Roles          []string `yaml:"roles"`
+	Scopes         []string `yaml:"scopes"`
+}
+
+type TokenFixture struct {
+	ID        string    `yaml:"id"`
+	User      string    `yaml:"user"`
+	Type      string    `yaml:"type"`
+	Value     string    `yaml:"value"`
+	ExpiresAt time.Time `yaml:"expires_at"`
+}
+
+// Grants are the names a user is granted through its roles and scopes
+type Grants struct {
+	Roles       []string
+	Permissions []string
+	Scopes      []string
+}
+
+// LoadFixtures decodes YAML fixtures, unknown fields are rejected
+func LoadFixtures(r io.Reader) (*Fixtures, error) {
+	var fixtures Fixtures
+
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&fixtures); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to decode fixtures: %w", err)
+	}
+
+	return &fixtures, nil
+}
+
+// Seed adds the fixtures to the store, nothing is added when a reference is unknown
+func (s *Store) Seed(fixtures *Fixtures) error {
+	permissionIds := make(map[string]string, len(fixtures.Permissions))
+	for _, item := range fixtures.Permissions {
+		permissionIds[item.Name] = fixtureId(item.ID, "permission", item.Name)
+	}
+
+	scopeIds := make(map[string]string, len(fixtures.Scopes))
+	for _, item := range fixtures.Scopes {
+		scopeIds[item.Name] = fixtureId(item.ID, "scope", item.Name)
+	}
+
+	roles := make([]*proto.Role, 0, len(fixtures.Roles))
+	roleIds := make(map[string]string, len(fixtures.Roles))
+	for _, item := range fixtures.Roles {
+		ids, err := resolve(permissionIds, item.Permissions, "role "+item.Name, "permission")
+		if err != nil {
+			return err
+		}
+
+		role := &proto.Role{Id: fixtureId(item.ID, "role", item.Name), Name: item.Name, Description: item.Description, PermissionIds: ids}
+		roleIds[item.Name] = role.Id
+		roles = append(roles, role)
+	}
+
+	users := make([]*proto.User, 0, len(fixtures.Users))
+	userIds := make(map[string]string, len(fixtures.Users))
+	for _, item := range fixtures.Users {
+		owner := "user " + item.IdentityNumber
+
+		roleIdList, err := resolve(roleIds, item.Roles, owner, "role")
+		if err != nil {
+			return err
+		}
+
+		scopeIdList, err := resolve(scopeIds, item.Scopes, owner, "scope")
+		if err != nil {
+			return err
+		}
+
+		user := &proto.User{
+			Id:             fixtureId(item.ID, "user", strings.ToUpper(item.IdentityNumber)),
+			IdentityNumber: item.IdentityNumber,
+			PersonalCode:   item.PersonalCode,
+			FirstName:      item.FirstName,
+			LastName:       item.LastName,
+			RoleIds:        roleIdList,
+			ScopeIds:       scopeIdList,
+		}
+		userIds[strings.ToUpper(item.IdentityNumber)] = user.Id
+		users = append(users, user)
+	}
+
+	tokens := make([]*proto.Token, 0, len(fixtures.Tokens))
+	for i, item := range fixtures.Tokens {
+		userId, ok := userIds[strings.ToUpper(item.User)]
+		if !ok {
+			return fmt.Errorf("token %d references unknown user %s", i, item.User)
+		}
+
+		token := &proto.Token{
+			Id:        fixtureId(item.ID, "token", fmt.Sprintf("%s/%s/%d", item.User, item.Type, i)),
+			UserId:    userId,
+			Type:      item.Type,
+			Value:     item.Value,
+			ExpiresAt: timestamppb.New(item.ExpiresAt),
+		}
+		if token.Value == "" {
+			token.Value = uuid.New().String()
+		}
+		if item.ExpiresAt.IsZero() {
+			token.ExpiresAt = timestamppb.New(time.Now().Add(TokenTTL))
+		}
+		tokens = append(tokens, token)
+	}
+
+	for _, item := range fixtures.Permissions {
+		s.AddPermission(&proto.Permission{Id: permissionIds[item.Name], Name: item.Name, Description: item.Description})
+	}
+	for _, item := range fixtures.Scopes {
+		s.AddScope(&proto.Scope{Id: scopeIds[item.Name], Name: item.Name, Description: item.Description})
+	}
+	for _, role := range roles {
+		s.AddRole(role)
+	}
+	for _, user := range users {
+		s.AddUser(user)
+	}
+	for _, token := range tokens {
+		s.AddToken(token)
+	}
+
+	return nil
+}
+
+// Grants returns the role, permission and scope names of the user with the identity number
+func (s *Store) Grants(identityNumber string) (*Grants, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	user, ok := s.users.find(func(u *proto.User) bool { return strings.EqualFold(u.IdentityNumber, identityNumber) })
+	if !ok {
+		return nil, false
+	}
+
+	grants := &Grants{
+		Roles:       make([]string, 0, len(user.RoleIds)),
+		Permissions: make([]string, 0),
+		Scopes:      make([]string, 0, len(user.ScopeIds)),
+	}
+
+	seen := make(map[string]bool)
+	for _, roleId := range user.RoleIds {
+		role, ok := s.roles.get(roleId)
+		if !ok {
+			continue
+		}
+		grants.Roles = append(grants.Roles, role.Name)
+
+		for _, permissionId := range role.PermissionIds {
+			permission, ok := s.permissions.get(permissionId)
+			if ok && !seen[permission.Name] {
+				seen[permission.Name] = true
+				grants.Permissions = append(grants.Permissions, permission.Name)
+			}
+		}
+	}
+
+	for _, scopeId := range user.ScopeIds {
+		if scope, ok := s.scopes.get(scopeId); ok {
+			grants.Scopes = append(grants.Scopes, scope.Name)
+		}
+	}
+
+	sort.Strings(grants.Permissions)
+
+	return grants, true
+}
+
+func fixtureId(id, kind, name string) string {
+	if id != "" {
+		return id
+	}
+
+	return uuid.NewSHA1(fixtureNamespace, []byte(kind+":"+name)).String()
+}
+
+func resolve(ids map[string]string, names []string, owner, kind string) ([]string, error) {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		id, ok := ids[name]
+		if !ok {
+			return nil, fmt.Errorf("%s references unknown %s %s", owner, kind, name)
+		}
+		result = append(result, id)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,133 @@
+package fake
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+)
+
+const fixturesYAML = `
+permissions:
+  - name: read:users
+    description: Read users
+  - name: write:users
+    description: Write users
+scopes:
+  - id: 10000000-1000-1000-4000-000000000001
+    name: sso-service
+    description: SSO service
+roles:
+  - name: admin
+    description: Administrator
+    permissions: [read:users, write:users]
+  - name: viewer
+    description: Viewer
+    permissions: [read:users]
+users:
+  - identity_number: PNOEE-60001017869
+    personal_code: "60001017869"
+    first_name: John
+    last_name: Doe
+    roles: [admin, viewer]
+    scopes: [sso-service]
+tokens:
+  - user: pnoee-60001017869
+    type: refresh_token
+    value: refresh
+    expires_at: 2030-01-01T00:00:00Z
+  - user: PNOEE-60001017869
+    type: access_token
+`
+
+func Test_Store_Seed(t *testing.T) {
+	fixtures, err := LoadFixtures(strings.NewReader(fixturesYAML))
+	require.NoError(t, err)
+
+	store := NewStore()
+	require.NoError(t, store.Seed(fixtures))
+
+	assert.Len(t, store.permissions.ids, 2)
+	assert.Len(t, store.roles.ids, 2)
+	assert.Len(t, store.tokens.ids, 2)
+
+	scope, ok := store.scopes.find(func(_ *proto.Scope) bool { return true })
+	require.True(t, ok)
+	assert.Equal(t, "10000000-1000-1000-4000-000000000001", scope.Id)
+
+	user, ok := store.users.find(func(_ *proto.User) bool { return true })
+	require.True(t, ok)
+	assert.Len(t, user.RoleIds, 2)
+	assert.Equal(t, []string{scope.Id}, user.ScopeIds)
+
+	store.tokens.each(func(token *proto.Token) {
+		assert.Equal(t, user.Id, token.UserId)
+		assert.NotEmpty(t, token.Value)
+		assert.NotNil(t, token.ExpiresAt)
+	})
+
+	again := NewStore()
+	require.NoError(t, again.Seed(fixtures))
+	assert.Equal(t, store.users.ids, again.users.ids)
+	assert.Equal(t, store.roles.ids, again.roles.ids)
+
+	grants, ok := store.Grants("pnoee-60001017869")
+	require.True(t, ok)
+	assert.Equal(t, &Grants{
+		Roles:       []string{"admin", "viewer"},
+		Permissions: []string{"read:users", "write:users"},
+		Scopes:      []string{"sso-service"},
+	}, grants)
+
+	_, ok = store.Grants("PNOEE-00000000000")
+	assert.False(t, ok)
+}
+
+func Test_Store_Seed_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "Unknown field",
+			input:    "groups: []",
+			expected: "failed to decode fixtures",
+		},
+		{
+			name:     "Unknown permission",
+			input:    "roles:\n  - name: admin\n    permissions: [read:users]",
+			expected: "role admin references unknown permission read:users",
+		},
+		{
+			name:     "Unknown role",
+			input:    "users:\n  - identity_number: PNOEE-60001017869\n    roles: [admin]",
+			expected: "user PNOEE-60001017869 references unknown role admin",
+		},
+		{
+			name:     "Unknown user",
+			input:    "tokens:\n  - user: PNOEE-60001017869\n    type: refresh_token",
+			expected: "token 0 references unknown user PNOEE-60001017869",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewStore()
+
+			fixtures, err := LoadFixtures(strings.NewReader(tt.input))
+			if err == nil {
+				err = store.Seed(fixtures)
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+			assert.Empty(t, store.permissions.ids)
+			assert.Empty(t, store.roles.ids)
+			assert.Empty(t, store.users.ids)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package fake
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+)
+
+const permissionResource = "permission"
+
+type permissionService struct {
+	proto.UnimplementedPermissionServiceServer
+	store *Store
+}
+
+func (s *permissionService) List(_ context.Context, req *proto.PaginatedListRequest) (*proto.ListPermissionsResponse, error) {
+	s.store.mu.RLock()
+	defer s.store.mu.RUnlock()
+
+	data, meta := s.store.permissions.page(req)
+	return &proto.ListPermissionsResponse{Data: data, Meta: meta}, nil
+}
+
+func (s *permissionService) Get(_ context.Context, req *proto.GetPermissionRequest) (*proto.GetPermissionResponse, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.RLock()
+	defer s.store.mu.RUnlock()
+
+	permission, ok := s.store.permissions.get(req.GetId())
+	if !ok {
+		return nil, notFound(permissionResource, req.GetId())
+	}
+
+	return &proto.GetPermissionResponse{Data: permission}, nil
+}
+
+func (s *permissionService) Create(_ context.Context, req *proto.CreatePermissionRequest) (*proto.CreatePermissionResponse, error) {
+	var v violations
+	v.required("name", req.GetName())
+	v.required("description", req.GetDescription())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if _, ok := s.store.permissions.find(func(p *proto.Permission) bool { return p.Name == req.GetName() }); ok {
+		return nil, alreadyExists(permissionResource, req.GetName())
+	}
+
+	permission := s.store.permissions.put(withId(&proto.Permission{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+	}))
+
+	return &proto.CreatePermissionResponse{Data: permission}, nil
+}
+
+func (s *permissionService) Update(_ context.Context, req *proto.UpdatePermissionRequest) (*proto.UpdatePermissionResponse, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	var v violations
+	v.required("name", req.GetName())
+	v.required("description", req.GetDescription())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if !s.store.permissions.has(req.GetId()) {
+		return nil, notFound(permissionResource, req.GetId())
+	}
+
+	if _, ok := s.store.permissions.find(func(p *proto.Permission) bool {
+		return p.Name == req.GetName() && p.Id != req.GetId()
+	}); ok {
+		return nil, alreadyExists(permissionResource, req.GetName())
+	}
+
+	permission := s.store.permissions.put(&proto.Permission{
+		Id:          req.GetId(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+	})
+
+	return &proto.UpdatePermissionResponse{Data: permission}, nil
+}
+
+// Delete removes the permission from the roles that reference it
+func (s *permissionService) Delete(_ context.Context, req *proto.DeletePermissionRequest) (*emptypb.Empty, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if !s.store.permissions.remove(req.GetId()) {
+		return nil, notFound(permissionResource, req.GetId())
+	}
+
+	s.store.roles.each(func(role *proto.Role) {
+		role.PermissionIds = without(role.PermissionIds, req.GetId())
+	})
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,122 @@
+package fake
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+)
+
+const roleResource = "role"
+
+type roleService struct {
+	proto.UnimplementedRoleServiceServer
+	store *Store
+}
+
+func (s *roleService) List(_ context.Context, req *proto.PaginatedListRequest) (*proto.ListRolesResponse, error) {
+	s.store.mu.RLock()
+	defer s.store.mu.RUnlock()
+
+	data, meta := s.store.roles.page(req)
+	return &proto.ListRolesResponse{Data: data, Meta: meta}, nil
+}
+
+func (s *roleService) Get(_ context.Context, req *proto.GetRoleRequest) (*proto.GetRoleResponse, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.RLock()
+	defer s.store.mu.RUnlock()
+
+	role, ok := s.store.roles.get(req.GetId())
+	if !ok {
+		return nil, notFound(roleResource, req.GetId())
+	}
+
+	return &proto.GetRoleResponse{Data: role}, nil
+}
+
+func (s *roleService) Create(_ context.Context, req *proto.CreateRoleRequest) (*proto.CreateRoleResponse, error) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if err := s.validate(req.GetName(), req.GetDescription(), req.GetPermissionIds()); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.store.roles.find(func(r *proto.Role) bool { return r.Name == req.GetName() }); ok {
+		return nil, alreadyExists(roleResource, req.GetName())
+	}
+
+	role := s.store.roles.put(withId(&proto.Role{
+		Name:          req.GetName(),
+		Description:   req.GetDescription(),
+		PermissionIds: req.GetPermissionIds(),
+	}))
+
+	return &proto.CreateRoleResponse{Data: role}, nil
+}
+
+func (s *roleService) Update(_ context.Context, req *proto.UpdateRoleRequest) (*proto.UpdateRoleResponse, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if err := s.validate(req.GetName(), req.GetDescription(), req.GetPermissionIds()); err != nil {
+		return nil, err
+	}
+
+	if !s.store.roles.has(req.GetId()) {
+		return nil, notFound(roleResource, req.GetId())
+	}
+
+	if _, ok := s.store.roles.find(func(r *proto.Role) bool {
+		return r.Name == req.GetName() && r.Id != req.GetId()
+	}); ok {
+		return nil, alreadyExists(roleResource, req.GetName())
+	}
+
+	role := s.store.roles.put(&proto.Role{
+		Id:            req.GetId(),
+		Name:          req.GetName(),
+		Description:   req.GetDescription(),
+		PermissionIds: req.GetPermissionIds(),
+	})
+
+	return &proto.UpdateRoleResponse{Data: role}, nil
+}
+
+// Delete removes the role from the users that reference it
+func (s *roleService) Delete(_ context.Context, req *proto.DeleteRoleRequest) (*emptypb.Empty, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if !s.store.roles.remove(req.GetId()) {
+		return nil, notFound(roleResource, req.GetId())
+	}
+
+	s.store.users.each(func(user *proto.User) {
+		user.RoleIds = without(user.RoleIds, req.GetId())
+	})
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *roleService) validate(name, description string, permissionIds []string) error {
+	var v violations
+	v.required("name", name)
+	v.required("description", description)
+	known(&v, "permission_ids", permissionIds, s.store.permissions)
+
+	return v.err()
+}
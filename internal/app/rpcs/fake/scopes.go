@@ -0,0 +1,117 @@
+package fake
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+)
+
+const scopeResource = "scope"
+
+type scopeService struct {
+	proto.UnimplementedScopeServiceServer
+	store *Store
+}
+
+func (s *scopeService) List(_ context.Context, req *proto.PaginatedListRequest) (*proto.ListScopesResponse, error) {
+	s.store.mu.RLock()
+	defer s.store.mu.RUnlock()
+
+	data, meta := s.store.scopes.page(req)
+	return &proto.ListScopesResponse{Data: data, Meta: meta}, nil
+}
+
+func (s *scopeService) Get(_ context.Context, req *proto.GetScopeRequest) (*proto.GetScopeResponse, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.RLock()
+	defer s.store.mu.RUnlock()
+
+	scope, ok := s.store.scopes.get(req.GetId())
+	if !ok {
+		return nil, notFound(scopeResource, req.GetId())
+	}
+
+	return &proto.GetScopeResponse{Data: scope}, nil
+}
+
+func (s *scopeService) Create(_ context.Context, req *proto.CreateScopeRequest) (*proto.CreateScopeResponse, error) {
+	var v violations
+	v.required("name", req.GetName())
+	v.required("description", req.GetDescription())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if _, ok := s.store.scopes.find(func(sc *proto.Scope) bool { return sc.Name == req.GetName() }); ok {
+		return nil, alreadyExists(scopeResource, req.GetName())
+	}
+
+	scope := s.store.scopes.put(withId(&proto.Scope{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+	}))
+
+	return &proto.CreateScopeResponse{Data: scope}, nil
+}
+
+func (s *scopeService) Update(_ context.Context, req *proto.UpdateScopeRequest) (*proto.UpdateScopeResponse, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	var v violations
+	v.required("name", req.GetName())
+	v.required("description", req.GetDescription())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if !s.store.scopes.has(req.GetId()) {
+		return nil, notFound(scopeResource, req.GetId())
+	}
+
+	if _, ok := s.store.scopes.find(func(sc *proto.Scope) bool {
+		return sc.Name == req.GetName() && sc.Id != req.GetId()
+	}); ok {
+		return nil, alreadyExists(scopeResource, req.GetName())
+	}
+
+	scope := s.store.scopes.put(&proto.Scope{
+		Id:          req.GetId(),
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+	})
+
+	return &proto.UpdateScopeResponse{Data: scope}, nil
+}
+
+// Delete removes the scope from the users that reference it
+func (s *scopeService) Delete(_ context.Context, req *proto.DeleteScopeRequest) (*emptypb.Empty, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if !s.store.scopes.remove(req.GetId()) {
+		return nil, notFound(scopeResource, req.GetId())
+	}
+
+	s.store.users.each(func(user *proto.User) {
+		user.ScopeIds = without(user.ScopeIds, req.GetId())
+	})
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,166 @@
+package fake
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+)
+
+const (
+	CaFile   = "ca.pem"
+	CertFile = "server.pem"
+	KeyFile  = "server.key"
+
+	// AnyMethod injects a fault into every method without a fault of its own
+	AnyMethod = "*"
+)
+
+// Fault runs before the handler of the method it is injected into, a non nil error is returned to the client
+type Fault func(ctx context.Context, method string) error
+
+// Server serves the SSO services from an in-memory store
+type Server struct {
+	*Store
+
+	server *grpc.Server
+	mu     sync.Mutex
+	faults map[string]Fault
+	calls  map[string]int
+}
+
+func NewServer(options ...grpc.ServerOption) *Server {
+	s := &Server{
+		Store:  NewStore(),
+		faults: make(map[string]Fault),
+		calls:  make(map[string]int),
+	}
+
+	s.server = grpc.NewServer(append(options, grpc.ChainUnaryInterceptor(s.intercept))...)
+
+	proto.RegisterPermissionServiceServer(s.server, &permissionService{store: s.Store})
+	proto.RegisterRoleServiceServer(s.server, &roleService{store: s.Store})
+	proto.RegisterScopeServiceServer(s.server, &scopeService{store: s.Store})
+	proto.RegisterTokenServiceServer(s.server, &tokenService{store: s.Store})
+	proto.RegisterUserServiceServer(s.server, &userService{store: s.Store})
+
+	return s
+}
+
+func (s *Server) Serve(listener net.Listener) error {
+	return s.server.Serve(listener)
+}
+
+func (s *Server) Stop() {
+	s.server.Stop()
+}
+
+// Inject runs the fault on calls to the full method name, e.g. proto.UserService_List_FullMethodName
+func (s *Server) Inject(method string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults[method] = fault
+}
+
+// Calls returns the number of calls made to the full method name, including failed ones
+func (s *Server) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[method]
+}
+
+// Reset removes the injected faults and the call counts
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = make(map[string]Fault)
+	s.calls = make(map[string]int)
+}
+
+func (s *Server) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	s.mu.Lock()
+	s.calls[info.FullMethod]++
+	fault, ok := s.faults[info.FullMethod]
+	if !ok {
+		fault = s.faults[AnyMethod]
+	}
+	s.mu.Unlock()
+
+	if fault != nil {
+		if err := fault(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+	}
+
+	return handler(ctx, req)
+}
+
+// FailTimes fails the first n calls with err and lets the following ones through
+func FailTimes(n int, err error) Fault {
+	var (
+		mu    sync.Mutex
+		count int
+	)
+
+	return func(_ context.Context, _ string) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if count >= n {
+			return nil
+		}
+		count++
+
+		return err
+	}
+}
+
+// Delay holds every call for d or until its deadline
+func Delay(d time.Duration) Fault {
+	return func(ctx context.Context, _ string) error {
+		select {
+		case <-time.After(d):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// NewCredentials loads the server certificate from certPath and requires clients to present one signed by the same CA
+func NewCredentials(certPath string) (credentials.TransportCredentials, error) {
+	caCert, err := os.ReadFile(filepath.Join(certPath, CaFile))
+	if err != nil {
+		return nil, err
+	}
+
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse CA certificate %s", filepath.Join(certPath, CaFile))
+	}
+
+	cert, err := tls.LoadX509KeyPair(filepath.Join(certPath, CertFile), filepath.Join(certPath, KeyFile))
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}), nil
+}
@@ -0,0 +1,323 @@
+package fake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/rpcs"
+	"loki-backoffice/internal/app/rpcs/interceptors"
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/pkg/spec"
+)
+
+func Test_Server_List(t *testing.T) {
+	server, connection := StartBufconn(t)
+	client := proto.NewScopeServiceClient(connection)
+
+	ids := make([]string, 0, 5)
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		ids = append(ids, server.AddScope(&proto.Scope{Name: name, Description: name}).Id)
+	}
+
+	tests := []struct {
+		name     string
+		limit    uint64
+		offset   uint64
+		expected []string
+		meta     *proto.PaginationMeta
+	}{
+		{
+			name:     "First page",
+			limit:    2,
+			expected: ids[0:2],
+			meta:     &proto.PaginationMeta{Page: 1, Per: 2, Total: 5},
+		},
+		{
+			name:     "Last page",
+			limit:    2,
+			offset:   4,
+			expected: ids[4:5],
+			meta:     &proto.PaginationMeta{Page: 3, Per: 2, Total: 5},
+		},
+		{
+			name:     "Beyond last page",
+			limit:    2,
+			offset:   10,
+			expected: []string{},
+			meta:     &proto.PaginationMeta{Page: 6, Per: 2, Total: 5},
+		},
+		{
+			name:     "Without limit",
+			expected: ids,
+			meta:     &proto.PaginationMeta{Page: 1, Total: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := client.List(context.Background(), &proto.PaginatedListRequest{Limit: tt.limit, Offset: tt.offset})
+			require.NoError(t, err)
+
+			result := make([]string, 0, len(response.Data))
+			for _, scope := range response.Data {
+				result = append(result, scope.Id)
+			}
+
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.meta.Page, response.Meta.Page)
+			assert.Equal(t, tt.meta.Per, response.Meta.Per)
+			assert.Equal(t, tt.meta.Total, response.Meta.Total)
+		})
+	}
+}
+
+func Test_Server_Users(t *testing.T) {
+	server, connection := StartBufconn(t)
+	client := proto.NewUserServiceClient(connection)
+	ctx := context.Background()
+
+	role := server.AddRole(&proto.Role{Name: "admin", Description: "Administrator"})
+	john := server.AddUser(&proto.User{
+		IdentityNumber: "PNOEE-60001017869",
+		PersonalCode:   "60001017869",
+		FirstName:      "John",
+		LastName:       "Doe",
+	})
+
+	tests := []struct {
+		name     string
+		call     func() error
+		expected error
+	}{
+		{
+			name: "Create",
+			call: func() error {
+				response, err := client.Create(ctx, &proto.CreateUserRequest{
+					IdentityNumber: "PNOEE-60001018800",
+					PersonalCode:   "60001018800",
+					FirstName:      "Jane",
+					LastName:       "Doe",
+					RoleIds:        []string{role.Id},
+				})
+				if err == nil {
+					assert.NotEmpty(t, response.Data.Id)
+					assert.Equal(t, []string{role.Id}, response.Data.RoleIds)
+				}
+				return err
+			},
+		},
+		{
+			name: "Create with invalid arguments",
+			call: func() error {
+				_, err := client.Create(ctx, &proto.CreateUserRequest{
+					IdentityNumber: "PNOEE-60001018811",
+					PersonalCode:   "60001018811",
+					FirstName:      "Max",
+					RoleIds:        []string{"10000000-1000-1000-3000-000000000009"},
+				})
+				return err
+			},
+			expected: &errors.ValidationError{
+				Err: errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{
+					{Pointer: "/last_name", Code: errors.RequiredCode, Detail: "last_name is required"},
+					{Pointer: "/role_ids/0", Code: errors.UnknownIdCode, Detail: "10000000-1000-1000-3000-000000000009 does not exist"},
+				},
+			},
+		},
+		{
+			name: "Create duplicate",
+			call: func() error {
+				_, err := client.Create(ctx, &proto.CreateUserRequest{
+					IdentityNumber: "pnoee-60001017869",
+					PersonalCode:   "60001017869",
+					FirstName:      "John",
+					LastName:       "Doe",
+				})
+				return err
+			},
+			expected: errors.ErrAlreadyExists,
+		},
+		{
+			name: "Update",
+			call: func() error {
+				response, err := client.Update(ctx, &proto.UpdateUserRequest{
+					Id:             john.Id,
+					IdentityNumber: john.IdentityNumber,
+					PersonalCode:   john.PersonalCode,
+					FirstName:      "Johnny",
+					LastName:       john.LastName,
+				})
+				if err == nil {
+					assert.Equal(t, "Johnny", response.Data.FirstName)
+				}
+				return err
+			},
+		},
+		{
+			name: "Get not found",
+			call: func() error {
+				_, err := client.Get(ctx, &proto.GetUserRequest{Id: "10000000-1000-1000-1000-000000000009"})
+				return err
+			},
+			expected: errors.ErrRecordNotFound,
+		},
+		{
+			name: "Get with invalid id",
+			call: func() error {
+				_, err := client.Get(ctx, &proto.GetUserRequest{Id: "invalid"})
+				return err
+			},
+			expected: &errors.ValidationError{
+				Err:    errors.ErrInvalidArguments,
+				Fields: []errors.FieldError{{Pointer: "/id", Code: errors.InvalidCode, Detail: "invalid is not a valid UUID"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errors.FromGRPC(tt.call(), errors.ErrFailedToFetchResults)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
+
+func Test_Server_Delete(t *testing.T) {
+	server, connection := StartBufconn(t)
+	ctx := context.Background()
+
+	permission := server.AddPermission(&proto.Permission{Name: "read:users", Description: "Read users"})
+	role := server.AddRole(&proto.Role{Name: "admin", Description: "Administrator", PermissionIds: []string{permission.Id}})
+	user := server.AddUser(&proto.User{IdentityNumber: "PNOEE-60001017869", PersonalCode: "60001017869", FirstName: "John", LastName: "Doe", RoleIds: []string{role.Id}})
+	server.AddToken(&proto.Token{UserId: user.Id, Type: "refresh_token", Value: "token"})
+
+	_, err := proto.NewPermissionServiceClient(connection).Delete(ctx, &proto.DeletePermissionRequest{Id: permission.Id})
+	require.NoError(t, err)
+
+	roleResponse, err := proto.NewRoleServiceClient(connection).Get(ctx, &proto.GetRoleRequest{Id: role.Id})
+	require.NoError(t, err)
+	assert.Empty(t, roleResponse.Data.PermissionIds)
+
+	_, err = proto.NewRoleServiceClient(connection).Delete(ctx, &proto.DeleteRoleRequest{Id: role.Id})
+	require.NoError(t, err)
+
+	userResponse, err := proto.NewUserServiceClient(connection).Get(ctx, &proto.GetUserRequest{Id: user.Id})
+	require.NoError(t, err)
+	assert.Empty(t, userResponse.Data.RoleIds)
+
+	_, err = proto.NewUserServiceClient(connection).Delete(ctx, &proto.DeleteUserRequest{Id: user.Id})
+	require.NoError(t, err)
+
+	tokens, err := proto.NewTokenServiceClient(connection).List(ctx, &proto.PaginatedListRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, tokens.Data)
+
+	_, err = proto.NewUserServiceClient(connection).Delete(ctx, &proto.DeleteUserRequest{Id: user.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_Server_Inject(t *testing.T) {
+	server, connection := StartBufconn(t)
+	client := proto.NewUserServiceClient(connection)
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	tests := []struct {
+		name     string
+		method   string
+		fault    Fault
+		timeout  time.Duration
+		expected []codes.Code
+	}{
+		{
+			name:     "Fail times",
+			method:   proto.UserService_List_FullMethodName,
+			fault:    FailTimes(2, unavailable),
+			expected: []codes.Code{codes.Unavailable, codes.Unavailable, codes.OK},
+		},
+		{
+			name:     "Any method",
+			method:   AnyMethod,
+			fault:    FailTimes(1, status.Error(codes.ResourceExhausted, "exhausted")),
+			expected: []codes.Code{codes.ResourceExhausted, codes.OK},
+		},
+		{
+			name:     "Other method",
+			method:   proto.UserService_Get_FullMethodName,
+			fault:    FailTimes(1, unavailable),
+			expected: []codes.Code{codes.OK},
+		},
+		{
+			name:     "Delay beyond deadline",
+			method:   proto.UserService_List_FullMethodName,
+			fault:    Delay(time.Second),
+			timeout:  50 * time.Millisecond,
+			expected: []codes.Code{codes.DeadlineExceeded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.Reset()
+			server.Inject(tt.method, tt.fault)
+
+			for _, expected := range tt.expected {
+				ctx := context.Background()
+				if tt.timeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+					defer cancel()
+				}
+
+				_, err := client.List(ctx, &proto.PaginatedListRequest{})
+				assert.Equal(t, expected, status.Code(err))
+			}
+
+			assert.Equal(t, len(tt.expected), server.Calls(proto.UserService_List_FullMethodName))
+		})
+	}
+}
+
+func Test_StartTLS(t *testing.T) {
+	certPath := spec.GenerateCertificates(t)
+	server, addr := StartTLS(t, certPath)
+	server.AddPermission(&proto.Permission{Name: "read:users", Description: "Read users"})
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+		GrpcAddr: addr,
+		CertPath: certPath,
+	}
+	log := logger.NewLogger(cfg)
+
+	breaker, err := interceptors.NewBreakerInterceptor(log)
+	require.NoError(t, err)
+
+	client, err := rpcs.NewClient(
+		cfg,
+		interceptors.NewAuthenticationInterceptor(log),
+		interceptors.NewTraceInterceptor(),
+		breaker,
+		interceptors.NewRetryInterceptor(interceptors.NewRetryPolicies(), log),
+		interceptors.NewLoggerInterceptor(log),
+		log,
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	response, err := rpcs.NewRegistry(client).GetPermissionClient().List(context.Background(), &proto.PaginatedListRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, "read:users", response.Data[0].Name)
+}
@@ -0,0 +1,287 @@
+package fake
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+)
+
+// Violation reasons, the client lower-cases them into field error codes
+const (
+	RequiredReason  = "REQUIRED"
+	InvalidReason   = "INVALID"
+	UnknownIdReason = "UNKNOWN_ID"
+)
+
+// Store keeps the records served by the fake SSO services
+type Store struct {
+	mu          sync.RWMutex
+	permissions *collection[*proto.Permission]
+	roles       *collection[*proto.Role]
+	scopes      *collection[*proto.Scope]
+	tokens      *collection[*proto.Token]
+	users       *collection[*proto.User]
+}
+
+func NewStore() *Store {
+	return &Store{
+		permissions: newCollection[*proto.Permission](),
+		roles:       newCollection[*proto.Role](),
+		scopes:      newCollection[*proto.Scope](),
+		tokens:      newCollection[*proto.Token](),
+		users:       newCollection[*proto.User](),
+	}
+}
+
+// AddPermission seeds a permission, an empty id is generated
+func (s *Store) AddPermission(permission *proto.Permission) *proto.Permission {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.permissions.put(withId(permission))
+}
+
+// AddRole seeds a role, an empty id is generated
+func (s *Store) AddRole(role *proto.Role) *proto.Role {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.roles.put(withId(role))
+}
+
+// AddScope seeds a scope, an empty id is generated
+func (s *Store) AddScope(scope *proto.Scope) *proto.Scope {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.scopes.put(withId(scope))
+}
+
+// AddToken seeds a token, an empty id is generated
+func (s *Store) AddToken(token *proto.Token) *proto.Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tokens.put(withId(token))
+}
+
+// AddUser seeds a user, an empty id is generated
+func (s *Store) AddUser(user *proto.User) *proto.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.users.put(withId(user))
+}
+
+// Clear removes all records
+func (s *Store) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.permissions = newCollection[*proto.Permission]()
+	s.roles = newCollection[*proto.Role]()
+	s.scopes = newCollection[*proto.Scope]()
+	s.tokens = newCollection[*proto.Token]()
+	s.users = newCollection[*proto.User]()
+}
+
+func withId[T protobuf.Message](item T) T {
+	item = clone(item)
+
+	field := item.ProtoReflect().Descriptor().Fields().ByName("id")
+	if item.ProtoReflect().Get(field).String() == "" {
+		item.ProtoReflect().Set(field, protoreflect.ValueOfString(uuid.New().String()))
+	}
+
+	return item
+}
+
+// collection keeps records in insertion order so that pages are stable,
+// records are cloned on the way in and out like they would be on the wire
+type collection[T protobuf.Message] struct {
+	ids   []string
+	items map[string]T
+}
+
+func newCollection[T protobuf.Message]() *collection[T] {
+	return &collection[T]{
+		items: make(map[string]T),
+	}
+}
+
+func (c *collection[T]) put(item T) T {
+	id := idOf(item)
+	if _, ok := c.items[id]; !ok {
+		c.ids = append(c.ids, id)
+	}
+	c.items[id] = clone(item)
+
+	return clone(item)
+}
+
+func (c *collection[T]) get(id string) (T, bool) {
+	item, ok := c.items[id]
+	if !ok {
+		return item, false
+	}
+
+	return clone(item), true
+}
+
+func (c *collection[T]) has(id string) bool {
+	_, ok := c.items[id]
+	return ok
+}
+
+func (c *collection[T]) remove(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+
+	delete(c.items, id)
+	for i, existing := range c.ids {
+		if existing == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+
+	return true
+}
+
+// find returns the first record matching the predicate
+func (c *collection[T]) find(match func(T) bool) (T, bool) {
+	for _, id := range c.ids {
+		if match(c.items[id]) {
+			return clone(c.items[id]), true
+		}
+	}
+
+	var empty T
+	return empty, false
+}
+
+// each visits the stored records, changes made by fn are kept
+func (c *collection[T]) each(fn func(T)) {
+	for _, id := range c.ids {
+		fn(c.items[id])
+	}
+}
+
+// page returns the records after offset, a zero limit returns all of them
+func (c *collection[T]) page(request *proto.PaginatedListRequest) ([]T, *proto.PaginationMeta) {
+	total := uint64(len(c.ids))
+	limit, offset := request.GetLimit(), request.GetOffset()
+
+	meta := &proto.PaginationMeta{Page: 1, Per: limit, Total: total}
+	if limit > 0 {
+		meta.Page = offset/limit + 1
+	}
+
+	start := min(offset, total)
+	end := total
+	if limit > 0 {
+		end = min(start+limit, total)
+	}
+
+	items := make([]T, 0, end-start)
+	for _, id := range c.ids[start:end] {
+		items = append(items, clone(c.items[id]))
+	}
+
+	return items, meta
+}
+
+func idOf(item protobuf.Message) string {
+	return item.ProtoReflect().Get(item.ProtoReflect().Descriptor().Fields().ByName("id")).String()
+}
+
+func clone[T protobuf.Message](item T) T {
+	return protobuf.Clone(item).(T)
+}
+
+// violations collects field violations into an InvalidArgument status
+type violations []*errdetails.BadRequest_FieldViolation
+
+func (v *violations) add(field, reason, description string) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{
+		Field:       field,
+		Reason:      reason,
+		Description: description,
+	})
+}
+
+func (v *violations) required(field, value string) {
+	if value == "" {
+		v.add(field, RequiredReason, field+" is required")
+	}
+}
+
+// known checks that every id is a UUID stored in the collection
+func known[T protobuf.Message](v *violations, field string, ids []string, c *collection[T]) {
+	for i, id := range ids {
+		name := fmt.Sprintf("%s[%d]", field, i)
+
+		if _, err := uuid.Parse(id); err != nil {
+			v.add(name, InvalidReason, fmt.Sprintf("%s is not a valid UUID", id))
+			continue
+		}
+
+		if !c.has(id) {
+			v.add(name, UnknownIdReason, fmt.Sprintf("%s does not exist", id))
+		}
+	}
+}
+
+func (v *violations) err() error {
+	if len(*v) == 0 {
+		return nil
+	}
+
+	st, err := status.New(codes.InvalidArgument, "invalid arguments").
+		WithDetails(&errdetails.BadRequest{FieldViolations: *v})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid arguments")
+	}
+
+	return st.Err()
+}
+
+// validId rejects ids that are not UUIDs the way the SSO service does
+func validId(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		var v violations
+		v.add("id", InvalidReason, fmt.Sprintf("%s is not a valid UUID", id))
+		return v.err()
+	}
+
+	return nil
+}
+
+func notFound(resource, id string) error {
+	return status.Errorf(codes.NotFound, "%s %s not found", resource, id)
+}
+
+func alreadyExists(resource, value string) error {
+	return status.Errorf(codes.AlreadyExists, "%s %s already exists", resource, value)
+}
+
+// without returns ids without the removed one
+func without(ids []string, removed string) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != removed {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,53 @@
+package fake
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufferSize = 1024 * 1024
+
+// StartBufconn serves a fake over an in-memory listener and returns a plaintext connection to it
+func StartBufconn(t *testing.T, options ...grpc.DialOption) (*Server, *grpc.ClientConn) {
+	listener := bufconn.Listen(bufferSize)
+	server := NewServer()
+
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	options = append(options,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+
+	connection, err := grpc.NewClient("passthrough:///bufnet", options...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = connection.Close() })
+
+	return server, connection
+}
+
+// StartTLS serves a fake with mutual TLS on a random local port, certPath is
+// usually created by spec.GenerateCertificates and also holds the client certificate
+func StartTLS(t *testing.T, certPath string) (*Server, string) {
+	creds, err := NewCredentials(certPath)
+	require.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := NewServer(grpc.Creds(creds))
+
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	return server, listener.Addr().String()
+}
@@ -0,0 +1,39 @@
+package fake
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+)
+
+const tokenResource = "token"
+
+type tokenService struct {
+	proto.UnimplementedTokenServiceServer
+	store *Store
+}
+
+func (s *tokenService) List(_ context.Context, req *proto.PaginatedListRequest) (*proto.ListTokensResponse, error) {
+	s.store.mu.RLock()
+	defer s.store.mu.RUnlock()
+
+	data, meta := s.store.tokens.page(req)
+	return &proto.ListTokensResponse{Data: data, Meta: meta}, nil
+}
+
+func (s *tokenService) Delete(_ context.Context, req *proto.DeleteTokenRequest) (*emptypb.Empty, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if !s.store.tokens.remove(req.GetId()) {
+		return nil, notFound(tokenResource, req.GetId())
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,138 @@
+package fake
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+)
+
+const userResource = "user"
+
+type userService struct {
+	proto.UnimplementedUserServiceServer
+	store *Store
+}
+
+func (s *userService) List(_ context.Context, req *proto.PaginatedListRequest) (*proto.ListUsersResponse, error) {
+	s.store.mu.RLock()
+	defer s.store.mu.RUnlock()
+
+	data, meta := s.store.users.page(req)
+	return &proto.ListUsersResponse{Data: data, Meta: meta}, nil
+}
+
+func (s *userService) Get(_ context.Context, req *proto.GetUserRequest) (*proto.GetUserResponse, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.RLock()
+	defer s.store.mu.RUnlock()
+
+	user, ok := s.store.users.get(req.GetId())
+	if !ok {
+		return nil, notFound(userResource, req.GetId())
+	}
+
+	return &proto.GetUserResponse{Data: user}, nil
+}
+
+func (s *userService) Create(_ context.Context, req *proto.CreateUserRequest) (*proto.CreateUserResponse, error) {
+	user := &proto.User{
+		IdentityNumber: req.GetIdentityNumber(),
+		PersonalCode:   req.GetPersonalCode(),
+		FirstName:      req.GetFirstName(),
+		LastName:       req.GetLastName(),
+		RoleIds:        req.GetRoleIds(),
+		ScopeIds:       req.GetScopeIds(),
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if err := s.validate(user); err != nil {
+		return nil, err
+	}
+
+	return &proto.CreateUserResponse{Data: s.store.users.put(withId(user))}, nil
+}
+
+func (s *userService) Update(_ context.Context, req *proto.UpdateUserRequest) (*proto.UpdateUserResponse, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	user := &proto.User{
+		Id:             req.GetId(),
+		IdentityNumber: req.GetIdentityNumber(),
+		PersonalCode:   req.GetPersonalCode(),
+		FirstName:      req.GetFirstName(),
+		LastName:       req.GetLastName(),
+		RoleIds:        req.GetRoleIds(),
+		ScopeIds:       req.GetScopeIds(),
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if !s.store.users.has(req.GetId()) {
+		return nil, notFound(userResource, req.GetId())
+	}
+
+	if err := s.validate(user); err != nil {
+		return nil, err
+	}
+
+	return &proto.UpdateUserResponse{Data: s.store.users.put(user)}, nil
+}
+
+// Delete removes the user together with its tokens
+func (s *userService) Delete(_ context.Context, req *proto.DeleteUserRequest) (*emptypb.Empty, error) {
+	if err := validId(req.GetId()); err != nil {
+		return nil, err
+	}
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	if !s.store.users.remove(req.GetId()) {
+		return nil, notFound(userResource, req.GetId())
+	}
+
+	var tokenIds []string
+	s.store.tokens.each(func(token *proto.Token) {
+		if token.UserId == req.GetId() {
+			tokenIds = append(tokenIds, token.Id)
+		}
+	})
+	for _, id := range tokenIds {
+		s.store.tokens.remove(id)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// validate checks the fields and references, identity numbers are unique regardless of case
+func (s *userService) validate(user *proto.User) error {
+	var v violations
+	v.required("identity_number", user.IdentityNumber)
+	v.required("personal_code", user.PersonalCode)
+	v.required("first_name", user.FirstName)
+	v.required("last_name", user.LastName)
+	known(&v, "role_ids", user.RoleIds, s.store.roles)
+	known(&v, "scope_ids", user.ScopeIds, s.store.scopes)
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	if _, ok := s.store.users.find(func(u *proto.User) bool {
+		return strings.EqualFold(u.IdentityNumber, user.IdentityNumber) && u.Id != user.Id
+	}); ok {
+		return alreadyExists(userResource, user.IdentityNumber)
+	}
+
+	return nil
+}
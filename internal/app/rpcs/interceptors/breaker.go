@@ -0,0 +1,231 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"loki-backoffice/internal/app/errors"
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+	"loki-backoffice/internal/config/logger"
+)
+
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half-open"
+
+	// BreakerFailureThreshold is the number of consecutive failures that opens a circuit
+	BreakerFailureThreshold = 5
+
+	// BreakerOpenTimeout is how long an open circuit refuses calls before letting a probe through
+	BreakerOpenTimeout = 10 * time.Second
+
+	BreakerMeterName = "loki-backoffice/rpcs"
+)
+
+// breakerValues are reported by the state gauge
+var breakerValues = map[string]int64{
+	BreakerClosed:   0,
+	BreakerHalfOpen: 1,
+	BreakerOpen:     2,
+}
+
+type BreakerInterceptor interface {
+	Break() grpc.UnaryClientInterceptor
+	States() map[string]string
+}
+
+type circuit struct {
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+type breakerInterceptor struct {
+	mu        sync.Mutex
+	circuits  map[string]*circuit
+	threshold int
+	timeout   time.Duration
+	now       func() time.Time
+	rejected  metric.Int64Counter
+	log       *logger.Logger
+}
+
+// NewBreakerInterceptor keeps a circuit per SSO service, calls to a service are refused
+// while its circuit is open so that requests fail fast instead of waiting for timeouts
+func NewBreakerInterceptor(log *logger.Logger) (BreakerInterceptor, error) {
+	return newBreakerInterceptor(otel.GetMeterProvider(), log)
+}
+
+func newBreakerInterceptor(provider metric.MeterProvider, log *logger.Logger) (*breakerInterceptor, error) {
+	b := &breakerInterceptor{
+		circuits:  make(map[string]*circuit),
+		threshold: BreakerFailureThreshold,
+		timeout:   BreakerOpenTimeout,
+		now:       time.Now,
+		log:       log.WithComponent("gRPC"),
+	}
+
+	for _, service := range []string{
+		proto.PermissionService_ServiceDesc.ServiceName,
+		proto.RoleService_ServiceDesc.ServiceName,
+		proto.ScopeService_ServiceDesc.ServiceName,
+		proto.TokenService_ServiceDesc.ServiceName,
+		proto.UserService_ServiceDesc.ServiceName,
+	} {
+		b.circuits[service] = &circuit{state: BreakerClosed}
+	}
+
+	meter := provider.Meter(BreakerMeterName)
+
+	rejected, err := meter.Int64Counter("rpc.client.circuit_breaker.rejected",
+		metric.WithDescription("Calls refused by an open circuit breaker"))
+	if err != nil {
+		return nil, err
+	}
+	b.rejected = rejected
+
+	_, err = meter.Int64ObservableGauge("rpc.client.circuit_breaker.state",
+		metric.WithDescription("Circuit breaker state per service, 0 closed, 1 half-open, 2 open"),
+		metric.WithInt64Callback(func(_ context.Context, observer metric.Int64Observer) error {
+			for service, state := range b.States() {
+				observer.Observe(breakerValues[state], metric.WithAttributes(attribute.String("rpc.service", service)))
+			}
+			return nil
+		}))
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (b *breakerInterceptor) Break() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		service := serviceName(method)
+
+		if err := b.allow(service); err != nil {
+			b.rejected.Add(ctx, 1, metric.WithAttributes(attribute.String("rpc.service", service)))
+			return err
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(service, err)
+
+		return err
+	}
+}
+
+// States returns the state of every circuit by service name
+func (b *breakerInterceptor) States() map[string]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states := make(map[string]string, len(b.circuits))
+	for service, c := range b.circuits {
+		states[service] = c.state
+	}
+
+	return states
+}
+
+func (b *breakerInterceptor) allow(service string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(service)
+
+	switch c.state {
+	case BreakerOpen:
+		elapsed := b.now().Sub(c.openedAt)
+		if elapsed < b.timeout {
+			return &errors.CircuitOpenError{Service: service, RetryAfter: max(b.timeout-elapsed, time.Second)}
+		}
+		b.transition(service, c, BreakerHalfOpen)
+	case BreakerHalfOpen:
+		if c.probing {
+			return &errors.CircuitOpenError{Service: service, RetryAfter: time.Second}
+		}
+	default:
+		return nil
+	}
+
+	c.probing = true
+	return nil
+}
+
+// record counts calls failing with Unavailable or DeadlineExceeded, any other outcome means
+// the service answered, canceled calls say nothing about the service and are ignored
+func (b *breakerInterceptor) record(service string, err error) {
+	code := status.Code(err)
+	failed := code == codes.Unavailable || code == codes.DeadlineExceeded
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuit(service)
+
+	switch c.state {
+	case BreakerHalfOpen:
+		c.probing = false
+		if code == codes.Canceled {
+			return
+		}
+		if failed {
+			c.openedAt = b.now()
+			b.transition(service, c, BreakerOpen)
+			return
+		}
+		c.failures = 0
+		b.transition(service, c, BreakerClosed)
+	case BreakerClosed:
+		if code == codes.Canceled {
+			return
+		}
+		if !failed {
+			c.failures = 0
+			return
+		}
+		c.failures++
+		if c.failures >= b.threshold {
+			c.openedAt = b.now()
+			b.transition(service, c, BreakerOpen)
+		}
+	}
+}
+
+func (b *breakerInterceptor) circuit(service string) *circuit {
+	c, ok := b.circuits[service]
+	if !ok {
+		c = &circuit{state: BreakerClosed}
+		b.circuits[service] = c
+	}
+
+	return c
+}
+
+func (b *breakerInterceptor) transition(service string, c *circuit, state string) {
+	b.log.Warn().
+		Str("service", service).
+		Str("from", c.state).
+		Str("to", state).
+		Msgf("Circuit breaker for %s is %s", service, state)
+
+	c.state = state
+}
+
+// serviceName extracts sso.v1.UserService from /sso.v1.UserService/List
+func serviceName(method string) string {
+	service, _, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return service
+}
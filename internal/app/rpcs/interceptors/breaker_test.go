@@ -0,0 +1,242 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"loki-backoffice/internal/app/errors"
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func TestBreakerInterceptor_Break(t *testing.T) {
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: logger.ErrorLevel,
+	}
+	log := logger.NewLogger(cfg)
+
+	instance, err := NewBreakerInterceptor(log)
+	require.NoError(t, err)
+
+	now := time.Now()
+	breaker := instance.(*breakerInterceptor)
+	breaker.threshold = 2
+	breaker.now = func() time.Time { return now }
+
+	users := proto.UserService_ServiceDesc.ServiceName
+	roles := proto.RoleService_ServiceDesc.ServiceName
+
+	steps := []struct {
+		name     string
+		method   string
+		advance  time.Duration
+		result   codes.Code
+		called   bool
+		expected error
+		state    string
+	}{
+		{
+			name:     "Failure below threshold",
+			method:   proto.UserService_List_FullMethodName,
+			result:   codes.Unavailable,
+			called:   true,
+			expected: status.Error(codes.Unavailable, "Unavailable"),
+			state:    BreakerClosed,
+		},
+		{
+			name:     "Answer resets failures",
+			method:   proto.UserService_Get_FullMethodName,
+			result:   codes.NotFound,
+			called:   true,
+			expected: status.Error(codes.NotFound, "NotFound"),
+			state:    BreakerClosed,
+		},
+		{
+			name:     "Canceled is ignored",
+			method:   proto.UserService_List_FullMethodName,
+			result:   codes.Canceled,
+			called:   true,
+			expected: status.Error(codes.Canceled, "Canceled"),
+			state:    BreakerClosed,
+		},
+		{
+			name:     "Deadline exceeded",
+			method:   proto.UserService_List_FullMethodName,
+			result:   codes.DeadlineExceeded,
+			called:   true,
+			expected: status.Error(codes.DeadlineExceeded, "DeadlineExceeded"),
+			state:    BreakerClosed,
+		},
+		{
+			name:     "Failure at threshold opens",
+			method:   proto.UserService_Delete_FullMethodName,
+			result:   codes.Unavailable,
+			called:   true,
+			expected: status.Error(codes.Unavailable, "Unavailable"),
+			state:    BreakerOpen,
+		},
+		{
+			name:     "Open refuses calls",
+			method:   proto.UserService_List_FullMethodName,
+			advance:  4 * time.Second,
+			expected: &errors.CircuitOpenError{Service: users, RetryAfter: 6 * time.Second},
+			state:    BreakerOpen,
+		},
+		{
+			name:   "Other services are not affected",
+			method: proto.RoleService_List_FullMethodName,
+			result: codes.OK,
+			called: true,
+			state:  BreakerOpen,
+		},
+		{
+			name:     "Failed probe opens again",
+			method:   proto.UserService_List_FullMethodName,
+			advance:  6 * time.Second,
+			result:   codes.Unavailable,
+			called:   true,
+			expected: status.Error(codes.Unavailable, "Unavailable"),
+			state:    BreakerOpen,
+		},
+		{
+			name:     "Open again refuses calls",
+			method:   proto.UserService_Create_FullMethodName,
+			advance:  9500 * time.Millisecond,
+			expected: &errors.CircuitOpenError{Service: users, RetryAfter: time.Second},
+			state:    BreakerOpen,
+		},
+		{
+			name:    "Successful probe closes",
+			method:  proto.UserService_Get_FullMethodName,
+			advance: time.Second,
+			result:  codes.OK,
+			called:  true,
+			state:   BreakerClosed,
+		},
+	}
+
+	for _, step := range steps {
+		now = now.Add(step.advance)
+
+		called := false
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			called = true
+			if step.result == codes.OK {
+				return nil
+			}
+			return status.Error(step.result, step.result.String())
+		}
+
+		err := instance.Break()(context.Background(), step.method, nil, nil, nil, invoker)
+
+		assert.Equal(t, step.expected, err, step.name)
+		assert.Equal(t, step.called, called, step.name)
+		assert.Equal(t, step.state, instance.States()[users], step.name)
+		assert.Equal(t, BreakerClosed, instance.States()[roles], step.name)
+	}
+}
+
+func TestBreakerInterceptor_HalfOpen(t *testing.T) {
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: logger.ErrorLevel,
+	}
+	log := logger.NewLogger(cfg)
+
+	instance, err := NewBreakerInterceptor(log)
+	require.NoError(t, err)
+
+	now := time.Now()
+	breaker := instance.(*breakerInterceptor)
+	breaker.now = func() time.Time { return now }
+
+	service := proto.ScopeService_ServiceDesc.ServiceName
+	for range BreakerFailureThreshold {
+		breaker.record(service, status.Error(codes.Unavailable, "unavailable"))
+	}
+	assert.Equal(t, BreakerOpen, instance.States()[service])
+
+	now = now.Add(BreakerOpenTimeout)
+
+	assert.NoError(t, breaker.allow(service))
+	assert.Equal(t, BreakerHalfOpen, instance.States()[service])
+	assert.Equal(t, &errors.CircuitOpenError{Service: service, RetryAfter: time.Second}, breaker.allow(service))
+
+	breaker.record(service, status.Error(codes.Canceled, "canceled"))
+	assert.Equal(t, BreakerHalfOpen, instance.States()[service])
+
+	assert.NoError(t, breaker.allow(service))
+	breaker.record(service, nil)
+	assert.Equal(t, BreakerClosed, instance.States()[service])
+}
+
+func TestBreakerInterceptor_Metrics(t *testing.T) {
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: logger.ErrorLevel,
+	}
+	log := logger.NewLogger(cfg)
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	breaker, err := newBreakerInterceptor(provider, log)
+	require.NoError(t, err)
+	breaker.threshold = 1
+
+	users := proto.UserService_ServiceDesc.ServiceName
+	roles := proto.RoleService_ServiceDesc.ServiceName
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+
+	interceptor := breaker.Break()
+	_ = interceptor(context.Background(), proto.UserService_List_FullMethodName, nil, nil, nil, invoker)
+	_ = interceptor(context.Background(), proto.UserService_List_FullMethodName, nil, nil, nil, invoker)
+
+	var metrics metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &metrics))
+	require.Len(t, metrics.ScopeMetrics, 1)
+	assert.Equal(t, BreakerMeterName, metrics.ScopeMetrics[0].Scope.Name)
+
+	values := make(map[string]map[string]int64)
+	for _, m := range metrics.ScopeMetrics[0].Metrics {
+		values[m.Name] = make(map[string]int64)
+
+		var points []metricdata.DataPoint[int64]
+		switch data := m.Data.(type) {
+		case metricdata.Gauge[int64]:
+			points = data.DataPoints
+		case metricdata.Sum[int64]:
+			points = data.DataPoints
+		}
+
+		for _, point := range points {
+			service, _ := point.Attributes.Value(attribute.Key("rpc.service"))
+			values[m.Name][service.AsString()] = point.Value
+		}
+	}
+
+	assert.Equal(t, breakerValues[BreakerOpen], values["rpc.client.circuit_breaker.state"][users])
+	assert.Equal(t, breakerValues[BreakerClosed], values["rpc.client.circuit_breaker.state"][roles])
+	assert.Len(t, values["rpc.client.circuit_breaker.state"], 5)
+	assert.Equal(t, map[string]int64{users: 1}, values["rpc.client.circuit_breaker.rejected"])
+}
+
+func Test_serviceName(t *testing.T) {
+	assert.Equal(t, "sso.v1.UserService", serviceName(proto.UserService_List_FullMethodName))
+	assert.Equal(t, "", serviceName(""))
+}
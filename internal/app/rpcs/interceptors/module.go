@@ -4,6 +4,9 @@ import "go.uber.org/fx"
 
 var Module = fx.Options(
 	fx.Provide(NewAuthenticationInterceptor),
+	fx.Provide(NewBreakerInterceptor),
 	fx.Provide(NewLoggerInterceptor),
+	fx.Provide(NewRetryPolicies),
+	fx.Provide(NewRetryInterceptor),
 	fx.Provide(NewTraceInterceptor),
 )
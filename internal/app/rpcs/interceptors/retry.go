@@ -0,0 +1,152 @@
+package interceptors
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+	"loki-backoffice/internal/config/logger"
+)
+
+const (
+	RetryMaxAttempts    = 3
+	RetryInitialBackoff = 100 * time.Millisecond
+	RetryMaxBackoff     = time.Second
+	RetryMultiplier     = 2
+)
+
+// RetryPolicy describes how a method is retried, attempts include the first call
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	Codes          []codes.Code
+}
+
+// RetryPolicies are keyed by full method name, methods without a policy are never retried
+type RetryPolicies map[string]RetryPolicy
+
+// DefaultRetryPolicy retries transient failures a few times within about a second
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    RetryMaxAttempts,
+	InitialBackoff: RetryInitialBackoff,
+	MaxBackoff:     RetryMaxBackoff,
+	Multiplier:     RetryMultiplier,
+	Codes:          []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+}
+
+// NewRetryPolicies applies DefaultRetryPolicy to the idempotent List, Get and Delete methods,
+// Create and Update are left out since a retry may apply them twice
+func NewRetryPolicies() RetryPolicies {
+	policies := make(RetryPolicies)
+
+	for _, method := range []string{
+		proto.PermissionService_List_FullMethodName,
+		proto.PermissionService_Get_FullMethodName,
+		proto.PermissionService_Delete_FullMethodName,
+		proto.RoleService_List_FullMethodName,
+		proto.RoleService_Get_FullMethodName,
+		proto.RoleService_Delete_FullMethodName,
+		proto.ScopeService_List_FullMethodName,
+		proto.ScopeService_Get_FullMethodName,
+		proto.ScopeService_Delete_FullMethodName,
+		proto.TokenService_List_FullMethodName,
+		proto.TokenService_Delete_FullMethodName,
+		proto.UserService_List_FullMethodName,
+		proto.UserService_Get_FullMethodName,
+		proto.UserService_Delete_FullMethodName,
+	} {
+		policies[method] = DefaultRetryPolicy
+	}
+
+	return policies
+}
+
+type RetryInterceptor interface {
+	Retry() grpc.UnaryClientInterceptor
+}
+
+type retryInterceptor struct {
+	policies RetryPolicies
+	log      *logger.Logger
+}
+
+func NewRetryInterceptor(policies RetryPolicies, log *logger.Logger) RetryInterceptor {
+	return &retryInterceptor{
+		policies: policies,
+		log:      log,
+	}
+}
+
+func (i *retryInterceptor) Retry() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		policy, ok := i.policies[method]
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= policy.MaxAttempts || !policy.retryable(err) {
+				return err
+			}
+
+			delay := policy.backoff(attempt)
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+				return err
+			}
+
+			i.log.WithComponent("gRPC").Warn().
+				Str("method", method).
+				Str("status", status.Code(err).String()).
+				Int("attempt", attempt).
+				Dur("backoff", delay).
+				Msgf("%s - retrying after %s", method, delay)
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	code := status.Code(err)
+	for _, c := range p.Codes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff grows exponentially up to MaxBackoff and keeps a random half of it,
+// so that clients failing together do not retry together
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.InitialBackoff)
+	for n := 1; n < attempt; n++ {
+		delay *= p.Multiplier
+	}
+	if maximum := float64(p.MaxBackoff); delay > maximum {
+		delay = maximum
+	}
+
+	half := time.Duration(delay / 2)
+	if half <= 0 {
+		return time.Duration(delay)
+	}
+
+	return half + rand.N(half)
+}
@@ -0,0 +1,153 @@
+package interceptors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func TestRetryInterceptor_Retry(t *testing.T) {
+	cfg := &config.Config{
+		AppEnv:   "test",
+		LogLevel: logger.ErrorLevel,
+	}
+	log := logger.NewLogger(cfg)
+
+	fast := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+		Multiplier:     2,
+		Codes:          []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+	}
+	slow := fast
+	slow.InitialBackoff = time.Second
+	slow.MaxBackoff = time.Second
+
+	policies := RetryPolicies{
+		proto.UserService_List_FullMethodName:   fast,
+		proto.UserService_Delete_FullMethodName: fast,
+		proto.UserService_Get_FullMethodName:    slow,
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		timeout  time.Duration
+		results  []codes.Code
+		expected codes.Code
+		calls    int
+	}{
+		{
+			name:     "Success",
+			method:   proto.UserService_List_FullMethodName,
+			results:  []codes.Code{codes.OK},
+			expected: codes.OK,
+			calls:    1,
+		},
+		{
+			name:     "Recovers from unavailable",
+			method:   proto.UserService_List_FullMethodName,
+			results:  []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.OK},
+			expected: codes.OK,
+			calls:    3,
+		},
+		{
+			name:     "Gives up after max attempts",
+			method:   proto.UserService_Delete_FullMethodName,
+			results:  []codes.Code{codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.OK},
+			expected: codes.Unavailable,
+			calls:    3,
+		},
+		{
+			name:     "Does not retry other codes",
+			method:   proto.UserService_List_FullMethodName,
+			results:  []codes.Code{codes.NotFound, codes.OK},
+			expected: codes.NotFound,
+			calls:    1,
+		},
+		{
+			name:     "Does not retry methods without policy",
+			method:   proto.UserService_Create_FullMethodName,
+			results:  []codes.Code{codes.Unavailable, codes.OK},
+			expected: codes.Unavailable,
+			calls:    1,
+		},
+		{
+			name:     "Does not wait beyond deadline",
+			method:   proto.UserService_Get_FullMethodName,
+			timeout:  50 * time.Millisecond,
+			results:  []codes.Code{codes.Unavailable, codes.OK},
+			expected: codes.Unavailable,
+			calls:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			calls := 0
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				code := tt.results[calls]
+				calls++
+				if code == codes.OK {
+					return nil
+				}
+				return status.Error(code, code.String())
+			}
+
+			interceptor := NewRetryInterceptor(policies, log).Retry()
+			err := interceptor(ctx, tt.method, nil, nil, nil, invoker)
+
+			assert.Equal(t, tt.expected, status.Code(err))
+			assert.Equal(t, tt.calls, calls)
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := DefaultRetryPolicy
+
+	tests := []struct {
+		attempt int
+		minimum time.Duration
+		maximum time.Duration
+	}{
+		{attempt: 1, minimum: 50 * time.Millisecond, maximum: 100 * time.Millisecond},
+		{attempt: 2, minimum: 100 * time.Millisecond, maximum: 200 * time.Millisecond},
+		{attempt: 3, minimum: 200 * time.Millisecond, maximum: 400 * time.Millisecond},
+		{attempt: 10, minimum: 500 * time.Millisecond, maximum: time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 20 {
+			delay := policy.backoff(tt.attempt)
+			assert.GreaterOrEqual(t, delay, tt.minimum)
+			assert.Less(t, delay, tt.maximum)
+		}
+	}
+}
+
+func TestNewRetryPolicies(t *testing.T) {
+	policies := NewRetryPolicies()
+
+	assert.Contains(t, policies, proto.RoleService_List_FullMethodName)
+	assert.Contains(t, policies, proto.TokenService_Delete_FullMethodName)
+	assert.NotContains(t, policies, proto.RoleService_Create_FullMethodName)
+	assert.NotContains(t, policies, proto.UserService_Update_FullMethodName)
+}
@@ -0,0 +1,21 @@
+package serializers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditLogSerializer struct {
+	ID           uuid.UUID       `json:"id"`
+	ActorID      string          `json:"actor_id"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resource_type"`
+	ResourceID   uuid.UUID       `json:"resource_id"`
+	Before       json.RawMessage `json:"before,omitempty"`
+	After        json.RawMessage `json:"after,omitempty"`
+	RequestID    string          `json:"request_id"`
+	TraceID      string          `json:"trace_id"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package serializers
+
+import "github.com/google/uuid"
+
+type BulkResultSerializer struct {
+	Index  int                `json:"index"`
+	Op     string             `json:"op"`
+	ID     *uuid.UUID         `json:"id,omitempty"`
+	Status int                `json:"status"`
+	State  string             `json:"state"`
+	Data   interface{}        `json:"data,omitempty"`
+	Error  *ProblemSerializer `json:"error,omitempty"`
+}
+
+type BulkMetaSerializer struct {
+	Total     int  `json:"total"`
+	Succeeded int  `json:"succeeded"`
+	Failed    int  `json:"failed"`
+	Atomic    bool `json:"atomic"`
+}
+
+// BulkResponseSerializer is the 207 Multi-Status body of a bulk request
+type BulkResponseSerializer struct {
+	Data []BulkResultSerializer `json:"data"`
+	Meta BulkMetaSerializer     `json:"meta"`
+}
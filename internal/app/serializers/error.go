@@ -1,5 +1,82 @@
 package serializers
 
-type ErrorSerializer struct {
-	Error string `json:"error"`
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+
+	"loki-backoffice/internal/app/errors"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	ProblemDefaultType = "about:blank"
+
+	RetryAfterHeader = "Retry-After"
+)
+
+// ProblemSerializer is an RFC 7807 problem details document
+type ProblemSerializer struct {
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Status    int                    `json:"status"`
+	Detail    string                 `json:"detail,omitempty"`
+	Instance  string                 `json:"instance,omitempty"`
+	Code      string                 `json:"code"`
+	Retryable bool                   `json:"retryable"`
+	Errors    []FieldErrorSerializer `json:"errors,omitempty"`
+}
+
+type FieldErrorSerializer struct {
+	Pointer string `json:"pointer"`
+	Code    string `json:"code"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// NewProblem describes err with the status, code and retryability registered for it
+func NewProblem(r *http.Request, err error) ProblemSerializer {
+	definition := errors.Lookup(err)
+
+	problem := ProblemSerializer{
+		Type:      ProblemDefaultType,
+		Title:     errors.StatusText(definition.Status),
+		Status:    definition.Status,
+		Instance:  r.URL.Path,
+		Code:      definition.Code,
+		Retryable: definition.Retryable,
+	}
+
+	if err != nil {
+		problem.Detail = err.Error()
+	}
+
+	var validation *errors.ValidationError
+	if errors.As(err, &validation) {
+		problem.Errors = make([]FieldErrorSerializer, 0, len(validation.Fields))
+		for _, field := range validation.Fields {
+			problem.Errors = append(problem.Errors, FieldErrorSerializer{
+				Pointer: field.Pointer,
+				Code:    field.Code,
+				Detail:  field.Detail,
+			})
+		}
+	}
+
+	return problem
+}
+
+// WriteProblem renders err as a problem details response,
+// an open circuit breaker also tells the client when to retry
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	problem := NewProblem(r, err)
+
+	var circuitOpen *errors.CircuitOpenError
+	if errors.As(err, &circuitOpen) {
+		w.Header().Set(RetryAfterHeader, strconv.Itoa(int(math.Ceil(circuitOpen.RetryAfter.Seconds()))))
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
 }
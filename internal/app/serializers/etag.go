@@ -0,0 +1,18 @@
+package serializers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// ETag is a strong validator derived from the JSON representation of a resource
+func ETag(resource interface{}) string {
+	bytes, err := json.Marshal(resource)
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(bytes)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
@@ -1,5 +1,6 @@
 package serializers
 
 type HealthSerializer struct {
-	Result string `json:"result"`
+	Result   string            `json:"result"`
+	Circuits map[string]string `json:"circuits,omitempty"`
 }
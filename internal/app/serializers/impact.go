@@ -0,0 +1,23 @@
+package serializers
+
+import "github.com/google/uuid"
+
+type ImpactSerializer struct {
+	ResourceType string                    `json:"resource_type"`
+	ResourceID   uuid.UUID                 `json:"resource_id"`
+	Roles        []RoleReferenceSerializer `json:"roles"`
+	Users        []UserReferenceSerializer `json:"users"`
+}
+
+type UserReferenceSerializer struct {
+	ID             uuid.UUID `json:"id"`
+	IdentityNumber string    `json:"identity_number"`
+	FirstName      string    `json:"first_name"`
+	LastName       string    `json:"last_name"`
+}
+
+// DeleteConflictSerializer is a problem document extended with the dependents blocking a delete
+type DeleteConflictSerializer struct {
+	ProblemSerializer
+	Dependents ImpactSerializer `json:"dependents"`
+}
@@ -1,9 +1,11 @@
 package serializers
 
 type PaginationMeta struct {
-	Page  uint64 `json:"page"`
+	Page  uint64 `json:"page,omitempty"`
 	Per   uint64 `json:"per"`
 	Total uint64 `json:"total"`
+	Next  string `json:"next,omitempty"`
+	Prev  string `json:"prev,omitempty"`
 }
 
 type PaginationResponse[T interface{}] struct {
@@ -0,0 +1,36 @@
+package serializers
+
+import "github.com/google/uuid"
+
+type RBACPlanSerializer struct {
+	Data []RBACChangeSerializer `json:"data"`
+	Meta RBACPlanMetaSerializer `json:"meta"`
+}
+
+type RBACChangeSerializer struct {
+	Action       string                  `json:"action"`
+	ResourceType string                  `json:"resource_type"`
+	ID           *uuid.UUID              `json:"id,omitempty"`
+	Name         string                  `json:"name"`
+	Fields       []string                `json:"fields,omitempty"`
+	Before       *RBACResourceSerializer `json:"before,omitempty"`
+	After        *RBACResourceSerializer `json:"after,omitempty"`
+	Dependents   *ImpactSerializer       `json:"dependents,omitempty"`
+	State        string                  `json:"state,omitempty"`
+	Error        *ProblemSerializer      `json:"error,omitempty"`
+}
+
+type RBACResourceSerializer struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+type RBACPlanMetaSerializer struct {
+	Create  int `json:"create"`
+	Update  int `json:"update"`
+	Delete  int `json:"delete"`
+	Applied int `json:"applied"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+}
@@ -0,0 +1,46 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SnapshotSerializer struct {
+	ID        uuid.UUID `json:"id"`
+	Version   int       `json:"version"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SnapshotRestoreSerializer struct {
+	RBAC  RBACPlanSerializer         `json:"rbac"`
+	Users SnapshotUserPlanSerializer `json:"users"`
+}
+
+type SnapshotUserPlanSerializer struct {
+	Data        []SnapshotUserChangeSerializer `json:"data"`
+	DeleteUsers bool                           `json:"delete_users"`
+	Retained    []string                       `json:"retained"`
+	Meta        RBACPlanMetaSerializer         `json:"meta"`
+}
+
+type SnapshotUserChangeSerializer struct {
+	Action         string                  `json:"action"`
+	ID             *uuid.UUID              `json:"id,omitempty"`
+	IdentityNumber string                  `json:"identity_number"`
+	Fields         []string                `json:"fields,omitempty"`
+	Before         *SnapshotUserSerializer `json:"before,omitempty"`
+	After          *SnapshotUserSerializer `json:"after,omitempty"`
+	State          string                  `json:"state,omitempty"`
+	Error          *ProblemSerializer      `json:"error,omitempty"`
+}
+
+type SnapshotUserSerializer struct {
+	IdentityNumber string   `json:"identity_number"`
+	PersonalCode   string   `json:"personal_code"`
+	FirstName      string   `json:"first_name"`
+	LastName       string   `json:"last_name"`
+	Roles          []string `json:"roles,omitempty"`
+	Scopes         []string `json:"scopes,omitempty"`
+}
@@ -7,9 +7,39 @@ import (
 )
 
 type TokenSerializer struct {
+	ID          uuid.UUID                `json:"id"`
+	UserId      uuid.UUID                `json:"user_id"`
+	Type        string                   `json:"type"`
+	Fingerprint string                   `json:"fingerprint"`
+	Metadata    *TokenMetadataSerializer `json:"metadata,omitempty"`
+	ExpiresAt   time.Time                `json:"expires_at"`
+}
+
+type TokenMetadataSerializer struct {
+	Issuer   string    `json:"issuer,omitempty"`
+	IssuedAt time.Time `json:"issued_at,omitzero"`
+	Scope    []string  `json:"scope,omitempty"`
+}
+
+type TokenValueSerializer struct {
+	ID    uuid.UUID `json:"id"`
+	Value string    `json:"value"`
+}
+
+type TokenRevocationSerializer struct {
+	UserId  uuid.UUID                    `json:"user_id"`
+	Revoked []RevokedTokenSerializer     `json:"revoked"`
+	Failed  []FailedRevocationSerializer `json:"failed"`
+}
+
+type RevokedTokenSerializer struct {
 	ID        uuid.UUID `json:"id"`
-	UserId    uuid.UUID `json:"user_id"`
 	Type      string    `json:"type"`
-	Value     string    `json:"value"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
+
+type FailedRevocationSerializer struct {
+	ID    uuid.UUID `json:"id"`
+	Type  string    `json:"type"`
+	Error string    `json:"error"`
+}
@@ -12,3 +12,32 @@ type UserSerializer struct {
 	RoleIDs  []uuid.UUID `json:"role_ids,omitempty"`
 	ScopeIDs []uuid.UUID `json:"scope_ids,omitempty"`
 }
+
+type EffectivePermissionsSerializer struct {
+	UserID      uuid.UUID                       `json:"user_id"`
+	Permissions []EffectivePermissionSerializer `json:"permissions"`
+}
+
+type EffectivePermissionSerializer struct {
+	ID          uuid.UUID                 `json:"id"`
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	GrantedBy   []RoleReferenceSerializer `json:"granted_by"`
+}
+
+type RoleReferenceSerializer struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+type UserExportSerializer struct {
+	ID             uuid.UUID   `json:"id"`
+	IdentityNumber string      `json:"identity_number"`
+	PersonalCode   string      `json:"personal_code"`
+	FirstName      string      `json:"first_name"`
+	LastName       string      `json:"last_name"`
+	RoleIDs        []uuid.UUID `json:"role_ids"`
+	ScopeIDs       []uuid.UUID `json:"scope_ids"`
+	Roles          []string    `json:"roles"`
+	Scopes         []string    `json:"scopes"`
+}
@@ -0,0 +1,42 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserImportSerializer struct {
+	ID        uuid.UUID                 `json:"id"`
+	Status    string                    `json:"status"`
+	Meta      UserImportMetaSerializer  `json:"meta"`
+	Rows      []UserImportRowSerializer `json:"rows"`
+	CreatedAt time.Time                 `json:"created_at"`
+	ExpiresAt time.Time                 `json:"expires_at"`
+}
+
+type UserImportMetaSerializer struct {
+	Total     int `json:"total"`
+	Invalid   int `json:"invalid"`
+	Create    int `json:"create"`
+	Update    int `json:"update"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+type UserImportRowSerializer struct {
+	Line           int                    `json:"line"`
+	Action         string                 `json:"action"`
+	UserID         *uuid.UUID             `json:"user_id,omitempty"`
+	IdentityNumber string                 `json:"identity_number"`
+	PersonalCode   string                 `json:"personal_code"`
+	FirstName      string                 `json:"first_name"`
+	LastName       string                 `json:"last_name"`
+	Roles          []string               `json:"roles"`
+	Scopes         []string               `json:"scopes"`
+	RoleIDs        []uuid.UUID            `json:"role_ids"`
+	ScopeIDs       []uuid.UUID            `json:"scope_ids"`
+	Errors         []FieldErrorSerializer `json:"errors,omitempty"`
+	State          string                 `json:"state,omitempty"`
+	Error          *ProblemSerializer     `json:"error,omitempty"`
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/repositories"
+	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/internal/config/middlewares"
+)
+
+type Audit interface {
+	Record(ctx context.Context, action, resourceType string, resourceId uuid.UUID, before, after interface{}) error
+	List(ctx context.Context, pagination *Pagination, filter *models.AuditLogFilter) ([]models.AuditLog, uint64, error)
+}
+
+type audit struct {
+	repository repositories.AuditRepository
+	log        *logger.Logger
+}
+
+func NewAudit(repository repositories.AuditRepository, log *logger.Logger) Audit {
+	return &audit{
+		repository: repository,
+		log:        log,
+	}
+}
+
+// Record persists a mutation together with the actor, request and trace identifiers taken from the context
+func (a *audit) Record(ctx context.Context, action, resourceType string, resourceId uuid.UUID, before, after interface{}) error {
+	params := &models.AuditLog{
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceId,
+		RequestID:    middleware.GetReqID(ctx),
+	}
+
+	if claim, ok := middlewares.CurrentClaimFromContext(ctx); ok {
+		params.ActorID = claim.ID
+	}
+
+	if traceId, ok := middlewares.CurrentTraceIdFromContext(ctx); ok {
+		params.TraceID = traceId
+	}
+
+	var err error
+
+	params.Before, err = snapshot(before)
+	if err != nil {
+		a.log.Error().Err(err).Str("resource_id", resourceId.String()).Msg("Failed to serialize audit snapshot")
+		return err
+	}
+
+	params.After, err = snapshot(after)
+	if err != nil {
+		a.log.Error().Err(err).Str("resource_id", resourceId.String()).Msg("Failed to serialize audit snapshot")
+		return err
+	}
+
+	_, err = a.repository.Create(ctx, params)
+	if err != nil {
+		a.log.Error().Err(err).
+			Str("action", action).
+			Str("resource_type", resourceType).
+			Str("resource_id", resourceId.String()).
+			Msg("Failed to record audit log")
+		return err
+	}
+
+	return nil
+}
+
+// RecordApplied audits a mutation that has already been applied, a failure cannot undo it
+// so it is logged and the caller goes on
+func RecordApplied(ctx context.Context, audit Audit, log *logger.Logger, action, resourceType string, resourceId uuid.UUID, before, after interface{}) {
+	if err := audit.Record(ctx, action, resourceType, resourceId, before, after); err != nil {
+		log.Warn().Err(err).
+			Str("action", action).
+			Str("resource_type", resourceType).
+			Str("resource_id", resourceId.String()).
+			Msg("Mutation applied without audit log")
+	}
+}
+
+// List returns audit log records matching the filter
+func (a *audit) List(ctx context.Context, pagination *Pagination, filter *models.AuditLogFilter) ([]models.AuditLog, uint64, error) {
+	seek, err := pagination.Seek()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, total, err := a.repository.List(ctx, filter, seek, pagination.SeekLimit(), pagination.Offset())
+	if err != nil {
+		a.log.Error().Err(err).Msg("Failed to fetch audit logs")
+		return nil, 0, errors.ErrFailedToFetchResults
+	}
+
+	return seekOf(rows, pagination, seek, auditLogKey), total, nil
+}
+
+func auditLogKey(record models.AuditLog) (time.Time, uuid.UUID) {
+	return record.CreatedAt, record.ID
+}
+
+func snapshot(value interface{}) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(value)
+}
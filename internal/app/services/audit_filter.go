@@ -0,0 +1,82 @@
+package services
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+)
+
+var (
+	auditActions = []string{
+		models.CreateActionType,
+		models.UpdateActionType,
+		models.DeleteActionType,
+		models.RevokeTokensActionType,
+		models.RevealActionType,
+	}
+	auditResourceTypes = []string{
+		models.PermissionResourceType,
+		models.RoleResourceType,
+		models.ScopeResourceType,
+		models.TokenResourceType,
+		models.UserResourceType,
+	}
+)
+
+// NewAuditLogFilter builds an audit log filter from the actor_id, action,
+// resource_type, resource_id, from and to query parameters
+func NewAuditLogFilter(r *http.Request) (*models.AuditLogFilter, error) {
+	query := r.URL.Query()
+
+	filter := &models.AuditLogFilter{
+		ActorID:      strings.TrimSpace(query.Get("actor_id")),
+		Action:       strings.TrimSpace(query.Get("action")),
+		ResourceType: strings.TrimSpace(query.Get("resource_type")),
+	}
+
+	if filter.Action != "" && !slices.Contains(auditActions, filter.Action) {
+		return nil, errors.ErrInvalidArguments
+	}
+
+	if filter.ResourceType != "" && !slices.Contains(auditResourceTypes, filter.ResourceType) {
+		return nil, errors.ErrInvalidArguments
+	}
+
+	if value := query.Get("resource_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, errors.ErrInvalidArguments
+		}
+		filter.ResourceID = id
+	}
+
+	var err error
+
+	if filter.From, err = parseTimeParam(query.Get("from")); err != nil {
+		return nil, errors.ErrInvalidArguments
+	}
+
+	if filter.To, err = parseTimeParam(query.Get("to")); err != nil {
+		return nil, errors.ErrInvalidArguments
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return nil, errors.ErrInvalidArguments
+	}
+
+	return filter, nil
+}
+
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
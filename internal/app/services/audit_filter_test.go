@@ -0,0 +1,82 @@
+package services
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+)
+
+func Test_NewAuditLogFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected *models.AuditLogFilter
+		error    error
+	}{
+		{
+			name:     "Empty params",
+			path:     "/",
+			expected: &models.AuditLogFilter{},
+			error:    nil,
+		},
+		{
+			name: "All params",
+			path: "/?actor_id=10000000-1000-1000-1234-000000000001&action=update&resource_type=role&resource_id=10000000-1000-1000-1000-000000000001&from=2025-03-01T00:00:00Z&to=2025-03-31T23:59:59Z",
+			expected: &models.AuditLogFilter{
+				ActorID:      "10000000-1000-1000-1234-000000000001",
+				Action:       models.UpdateActionType,
+				ResourceType: models.RoleResourceType,
+				ResourceID:   uuid.MustParse("10000000-1000-1000-1000-000000000001"),
+				From:         time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+				To:           time.Date(2025, 3, 31, 23, 59, 59, 0, time.UTC),
+			},
+			error: nil,
+		},
+		{
+			name:     "Unknown action",
+			path:     "/?action=rename",
+			expected: nil,
+			error:    errors.ErrInvalidArguments,
+		},
+		{
+			name:     "Unknown resource type",
+			path:     "/?resource_type=session",
+			expected: nil,
+			error:    errors.ErrInvalidArguments,
+		},
+		{
+			name:     "Invalid resource id",
+			path:     "/?resource_id=invalid",
+			expected: nil,
+			error:    errors.ErrInvalidArguments,
+		},
+		{
+			name:     "Invalid time format",
+			path:     "/?from=yesterday",
+			expected: nil,
+			error:    errors.ErrInvalidArguments,
+		},
+		{
+			name:     "Inverted time range",
+			path:     "/?from=2025-03-31T00:00:00Z&to=2025-03-01T00:00:00Z",
+			expected: nil,
+			error:    errors.ErrInvalidArguments,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", tt.path, nil)
+			result, err := NewAuditLogFilter(request)
+
+			assert.Equal(t, tt.error, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/repositories"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/internal/config/middlewares"
+	"loki-backoffice/pkg/jwt"
+)
+
+func Test_Audit_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	repository := repositories.NewMockAuditRepository(ctrl)
+	service := NewAudit(repository, log)
+
+	id := uuid.MustParse("10000000-1000-1000-1000-000000000001")
+
+	ctx := middlewares.NewContextModifier(context.Background()).
+		WithClaim(&jwt.Payload{ID: "10000000-1000-1000-1234-000000000001"}).
+		WithTraceId("10000000-1000-1000-9000-000000000001").
+		Context()
+	ctx = context.WithValue(ctx, middleware.RequestIDKey, "request-id")
+
+	before := models.RoleAuditSnapshot{ID: id, Name: "admin", Description: "Admin role"}
+	after := models.RoleAuditSnapshot{ID: id, Name: "admin", Description: "Administrator role"}
+
+	beforeJSON, _ := json.Marshal(before)
+	afterJSON, _ := json.Marshal(after)
+
+	type params struct {
+		ctx    context.Context
+		action string
+		before interface{}
+		after  interface{}
+	}
+
+	tests := []struct {
+		name     string
+		before   func()
+		params   params
+		expected error
+	}{
+		{
+			name: "Success",
+			before: func() {
+				repository.EXPECT().Create(ctx, &models.AuditLog{
+					ActorID:      "10000000-1000-1000-1234-000000000001",
+					Action:       models.UpdateActionType,
+					ResourceType: models.RoleResourceType,
+					ResourceID:   id,
+					Before:       beforeJSON,
+					After:        afterJSON,
+					RequestID:    "request-id",
+					TraceID:      "10000000-1000-1000-9000-000000000001",
+				}).Return(&models.AuditLog{ID: uuid.New()}, nil)
+			},
+			params: params{
+				ctx:    ctx,
+				action: models.UpdateActionType,
+				before: before,
+				after:  after,
+			},
+			expected: nil,
+		},
+		{
+			name: "Without snapshots and context values",
+			before: func() {
+				repository.EXPECT().Create(context.Background(), &models.AuditLog{
+					Action:       models.DeleteActionType,
+					ResourceType: models.RoleResourceType,
+					ResourceID:   id,
+				}).Return(&models.AuditLog{ID: uuid.New()}, nil)
+			},
+			params: params{
+				ctx:    context.Background(),
+				action: models.DeleteActionType,
+			},
+			expected: nil,
+		},
+		{
+			name:   "Invalid snapshot",
+			before: func() {},
+			params: params{
+				ctx:    ctx,
+				action: models.CreateActionType,
+				after:  make(chan int),
+			},
+			expected: &json.UnsupportedTypeError{},
+		},
+		{
+			name: "Error",
+			before: func() {
+				repository.EXPECT().Create(ctx, gomock.Any()).Return(nil, assert.AnError)
+			},
+			params: params{
+				ctx:    ctx,
+				action: models.CreateActionType,
+				after:  after,
+			},
+			expected: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			err := service.Record(tt.params.ctx, tt.params.action, models.RoleResourceType, id, tt.params.before, tt.params.after)
+
+			if tt.expected != nil {
+				assert.Error(t, err)
+				assert.IsType(t, tt.expected, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_Audit_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.Background()
+	repository := repositories.NewMockAuditRepository(ctrl)
+	service := NewAudit(repository, log)
+
+	filter := &models.AuditLogFilter{
+		ResourceType: models.UserResourceType,
+		ResourceID:   uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+	}
+	pagination := &Pagination{Page: 2, PerPage: 10}
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected []models.AuditLog
+		total    uint64
+		error    error
+	}{
+		{
+			name: "Success",
+			before: func() {
+				repository.EXPECT().List(ctx, filter, nil, uint64(10), uint64(10)).Return([]models.AuditLog{
+					{
+						ID:           uuid.MustParse("10000000-1000-1000-8000-000000000001"),
+						ActorID:      "10000000-1000-1000-1234-000000000002",
+						Action:       models.UpdateActionType,
+						ResourceType: models.UserResourceType,
+						ResourceID:   uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+					},
+				}, uint64(11), nil)
+			},
+			expected: []models.AuditLog{
+				{
+					ID:           uuid.MustParse("10000000-1000-1000-8000-000000000001"),
+					ActorID:      "10000000-1000-1000-1234-000000000002",
+					Action:       models.UpdateActionType,
+					ResourceType: models.UserResourceType,
+					ResourceID:   uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+				},
+			},
+			total: 11,
+			error: nil,
+		},
+		{
+			name: "Error",
+			before: func() {
+				repository.EXPECT().List(ctx, filter, nil, uint64(10), uint64(10)).Return(nil, uint64(0), assert.AnError)
+			},
+			expected: nil,
+			total:    0,
+			error:    errors.ErrFailedToFetchResults,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			result, total, err := service.List(ctx, pagination, filter)
+
+			assert.Equal(t, tt.error, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.total, total)
+		})
+	}
+}
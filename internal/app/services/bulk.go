@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"loki-backoffice/internal/app/errors"
+)
+
+const (
+	AtomicParam = "atomic"
+
+	// BulkConcurrency bounds the operations of a bulk request running against upstream at once
+	BulkConcurrency = 8
+)
+
+const (
+	BulkAppliedState        = "applied"
+	BulkFailedState         = "failed"
+	BulkSkippedState        = "skipped"
+	BulkRolledBackState     = "rolled_back"
+	BulkRollbackFailedState = "rollback_failed"
+)
+
+// BulkOptions controls how a bulk request is executed
+type BulkOptions struct {
+	// Atomic undoes the applied operations once any operation fails
+	Atomic bool
+	// Force skips the dependents check of deletes
+	Force bool
+}
+
+func NewBulkOptions(r *http.Request) (*BulkOptions, error) {
+	isAtomic, err := parseBoolParam(r, AtomicParam)
+	if err != nil {
+		return nil, err
+	}
+
+	force, err := parseBoolParam(r, ForceParam)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BulkOptions{
+		Atomic: isAtomic,
+		Force:  force,
+	}, nil
+}
+
+// BulkOperation is a single step of a bulk request, Err is set when the operation
+// was rejected before execution and Apply is not called then
+type BulkOperation struct {
+	Op    string
+	ID    uuid.UUID
+	Err   error
+	Apply func(ctx context.Context) (*BulkApplied, error)
+}
+
+// BulkApplied is the outcome of an applied operation, Undo reverts it
+type BulkApplied struct {
+	ID     uuid.UUID
+	Result interface{}
+	Undo   func(ctx context.Context) error
+}
+
+type BulkResult struct {
+	Index  int
+	Op     string
+	ID     uuid.UUID
+	State  string
+	Result interface{}
+	Err    error
+}
+
+// RunBulk applies the operations with bounded concurrency, results keep the order of operations.
+// In atomic mode nothing runs when any operation was rejected upfront, no further operation starts
+// after the first failure and the applied ones are undone in reverse order
+func RunBulk(ctx context.Context, operations []BulkOperation, options *BulkOptions) []BulkResult {
+	results := make([]BulkResult, len(operations))
+	undo := make([]func(ctx context.Context) error, len(operations))
+
+	var failed atomic.Bool
+	for i, operation := range operations {
+		results[i] = BulkResult{Index: i, Op: operation.Op, ID: operation.ID}
+		if operation.Err != nil {
+			results[i].State = BulkFailedState
+			results[i].Err = operation.Err
+			failed.Store(true)
+		}
+	}
+
+	group := new(errgroup.Group)
+	group.SetLimit(BulkConcurrency)
+
+	for i, operation := range operations {
+		if operation.Err != nil {
+			continue
+		}
+
+		group.Go(func() error {
+			if options.Atomic && failed.Load() {
+				results[i].State = BulkSkippedState
+				results[i].Err = errors.ErrBulkOperationAborted
+				return nil
+			}
+
+			applied, err := operation.Apply(ctx)
+			if err != nil {
+				results[i].State = BulkFailedState
+				results[i].Err = err
+				failed.Store(true)
+				return nil
+			}
+
+			results[i].State = BulkAppliedState
+			results[i].ID = applied.ID
+			results[i].Result = applied.Result
+			undo[i] = applied.Undo
+			return nil
+		})
+	}
+
+	_ = group.Wait()
+
+	if options.Atomic && failed.Load() {
+		rollback(ctx, results, undo)
+	}
+
+	return results
+}
+
+// rollback runs after the request may have been canceled, compensation must still reach upstream
+func rollback(ctx context.Context, results []BulkResult, undo []func(ctx context.Context) error) {
+	ctx = context.WithoutCancel(ctx)
+
+	applied := make([]int, 0, len(results))
+	for i, result := range results {
+		if result.State == BulkAppliedState {
+			applied = append(applied, i)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(applied)))
+
+	for _, i := range applied {
+		if err := undo[i](ctx); err != nil {
+			results[i].State = BulkRollbackFailedState
+			results[i].Err = err
+			continue
+		}
+
+		results[i].State = BulkRolledBackState
+		results[i].Result = nil
+		results[i].Err = errors.ErrBulkOperationAborted
+	}
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+)
+
+func Test_NewBulkOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected *BulkOptions
+		error    error
+	}{
+		{
+			name:     "Defaults",
+			path:     "/",
+			expected: &BulkOptions{},
+		},
+		{
+			name:     "Atomic and force",
+			path:     "/?atomic=true&force=1",
+			expected: &BulkOptions{Atomic: true, Force: true},
+		},
+		{
+			name:  "Invalid atomic",
+			path:  "/?atomic=yes",
+			error: errors.ErrInvalidArguments,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", tt.path, nil)
+			options, err := NewBulkOptions(request)
+
+			assert.Equal(t, tt.error, err)
+			assert.Equal(t, tt.expected, options)
+		})
+	}
+}
+
+func Test_RunBulk(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+
+	var undone atomic.Int32
+	succeed := func(ctx context.Context) (*BulkApplied, error) {
+		return &BulkApplied{
+			ID:     id,
+			Result: "ok",
+			Undo: func(ctx context.Context) error {
+				undone.Add(1)
+				return nil
+			},
+		}, nil
+	}
+	fail := func(ctx context.Context) (*BulkApplied, error) {
+		return nil, errors.ErrFailedToCreateRecord
+	}
+
+	tests := []struct {
+		name       string
+		operations []BulkOperation
+		options    *BulkOptions
+		expected   []string
+		undone     int32
+	}{
+		{
+			name: "Partial success",
+			operations: []BulkOperation{
+				{Op: models.CreateActionType, Apply: succeed},
+				{Op: models.CreateActionType, Apply: fail},
+				{Op: models.CreateActionType, Err: errors.ErrInvalidArguments},
+			},
+			options:  &BulkOptions{},
+			expected: []string{BulkAppliedState, BulkFailedState, BulkFailedState},
+		},
+		{
+			name: "Atomic success",
+			operations: []BulkOperation{
+				{Op: models.CreateActionType, Apply: succeed},
+				{Op: models.UpdateActionType, ID: id, Apply: succeed},
+			},
+			options:  &BulkOptions{Atomic: true},
+			expected: []string{BulkAppliedState, BulkAppliedState},
+		},
+		{
+			name: "Atomic rejected upfront",
+			operations: []BulkOperation{
+				{Op: models.CreateActionType, Apply: succeed},
+				{Op: models.CreateActionType, Err: errors.ErrInvalidArguments},
+				{Op: models.DeleteActionType, ID: id, Apply: succeed},
+			},
+			options:  &BulkOptions{Atomic: true},
+			expected: []string{BulkSkippedState, BulkFailedState, BulkSkippedState},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			undone.Store(0)
+
+			results := RunBulk(ctx, tt.operations, tt.options)
+
+			states := make([]string, 0, len(results))
+			for i, result := range results {
+				assert.Equal(t, i, result.Index)
+				assert.Equal(t, tt.operations[i].Op, result.Op)
+				states = append(states, result.State)
+			}
+			assert.Equal(t, tt.expected, states)
+			assert.Equal(t, tt.undone, undone.Load())
+		})
+	}
+}
+
+func Test_RunBulk_AtomicRollback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	id := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	started := make(chan struct{})
+	var undone atomic.Int32
+
+	operations := []BulkOperation{
+		{
+			Op: models.CreateActionType,
+			Apply: func(ctx context.Context) (*BulkApplied, error) {
+				close(started)
+				return &BulkApplied{
+					ID: id,
+					Undo: func(ctx context.Context) error {
+						assert.NoError(t, ctx.Err())
+						undone.Add(1)
+						return nil
+					},
+				}, nil
+			},
+		},
+		{
+			Op: models.UpdateActionType,
+			ID: id,
+			Apply: func(ctx context.Context) (*BulkApplied, error) {
+				<-started
+				cancel()
+				return nil, errors.ErrFailedToUpdateRecord
+			},
+		},
+	}
+
+	results := RunBulk(ctx, operations, &BulkOptions{Atomic: true})
+
+	assert.Equal(t, BulkRolledBackState, results[0].State)
+	assert.ErrorIs(t, results[0].Err, errors.ErrBulkOperationAborted)
+	assert.Nil(t, results[0].Result)
+	assert.Equal(t, BulkFailedState, results[1].State)
+	assert.ErrorIs(t, results[1].Err, errors.ErrFailedToUpdateRecord)
+	assert.Equal(t, int32(1), undone.Load())
+}
+
+func Test_RunBulk_RollbackFailure(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	started := make(chan struct{})
+
+	operations := []BulkOperation{
+		{
+			Op: models.DeleteActionType,
+			ID: id,
+			Apply: func(ctx context.Context) (*BulkApplied, error) {
+				close(started)
+				return &BulkApplied{
+					ID: id,
+					Undo: func(ctx context.Context) error {
+						return errors.ErrFailedToCreateRecord
+					},
+				}, nil
+			},
+		},
+		{
+			Op: models.CreateActionType,
+			Apply: func(ctx context.Context) (*BulkApplied, error) {
+				<-started
+				return nil, errors.ErrFailedToCreateRecord
+			},
+		},
+	}
+
+	results := RunBulk(ctx, operations, &BulkOptions{Atomic: true})
+
+	assert.Equal(t, BulkRollbackFailedState, results[0].State)
+	assert.ErrorIs(t, results[0].Err, errors.ErrFailedToCreateRecord)
+	assert.Equal(t, BulkFailedState, results[1].State)
+}
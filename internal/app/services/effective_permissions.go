@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/config/logger"
+)
+
+const ResolveConcurrency = 8
+
+type EffectivePermissions interface {
+	Resolve(ctx context.Context, userId uuid.UUID) (*models.EffectivePermissions, error)
+}
+
+type effectivePermissions struct {
+	users       Users
+	roles       Roles
+	permissions Permissions
+	log         *logger.Logger
+}
+
+func NewEffectivePermissions(users Users, roles Roles, permissions Permissions, log *logger.Logger) EffectivePermissions {
+	return &effectivePermissions{
+		users:       users,
+		roles:       roles,
+		permissions: permissions,
+		log:         log,
+	}
+}
+
+// Resolve returns the union of the permissions granted to the user by its roles.
+// Roles and permissions deleted upstream while still referenced are skipped.
+func (s *effectivePermissions) Resolve(ctx context.Context, userId uuid.UUID) (*models.EffectivePermissions, error) {
+	user, err := s.users.FindById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := fanOut(ctx, user.RoleIDs, s.roles.FindById)
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make(map[uuid.UUID][]models.Role)
+	permissionIds := make([]uuid.UUID, 0)
+
+	for _, role := range roles {
+		for _, permissionId := range role.PermissionIDs {
+			if _, ok := grants[permissionId]; !ok {
+				permissionIds = append(permissionIds, permissionId)
+			}
+
+			grants[permissionId] = append(grants[permissionId], models.Role{
+				ID:   role.ID,
+				Name: role.Name,
+			})
+		}
+	}
+
+	permissions, err := fanOut(ctx, permissionIds, s.permissions.FindById)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.EffectivePermissions{
+		UserID:      userId,
+		Permissions: make([]models.EffectivePermission, 0, len(permissions)),
+	}
+
+	for _, permission := range permissions {
+		result.Permissions = append(result.Permissions, models.EffectivePermission{
+			Permission: *permission,
+			GrantedBy:  grants[permission.ID],
+		})
+	}
+
+	slices.SortFunc(result.Permissions, func(a, b models.EffectivePermission) int {
+		return strings.Compare(a.Permission.Name, b.Permission.Name)
+	})
+
+	return result, nil
+}
+
+// fanOut loads the records concurrently with a bounded worker pool, keeping the order of ids
+func fanOut[T any](ctx context.Context, ids []uuid.UUID, find func(ctx context.Context, id uuid.UUID) (*T, error)) ([]*T, error) {
+	records := make([]*T, len(ids))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(ResolveConcurrency)
+
+	for i, id := range ids {
+		group.Go(func() error {
+			record, err := find(groupCtx, id)
+			if err != nil {
+				if errors.Is(err, errors.ErrRecordNotFound) {
+					return nil
+				}
+
+				return err
+			}
+
+			records[i] = record
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(records, func(record *T) bool {
+		return record == nil
+	}), nil
+}
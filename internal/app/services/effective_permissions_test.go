@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func Test_EffectivePermissions_Resolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.Background()
+	users := NewMockUsers(ctrl)
+	roles := NewMockRoles(ctrl)
+	permissions := NewMockPermissions(ctrl)
+	service := NewEffectivePermissions(users, roles, permissions, log)
+
+	userId := uuid.MustParse("10000000-1000-1000-1234-000000000001")
+	adminRoleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	managerRoleId := uuid.MustParse("10000000-1000-1000-3000-000000000002")
+	deletedRoleId := uuid.MustParse("10000000-1000-1000-3000-000000000003")
+	readSelfId := uuid.MustParse("10000000-1000-1000-4000-000000000001")
+	writeSelfId := uuid.MustParse("10000000-1000-1000-4000-000000000002")
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected *models.EffectivePermissions
+		error    error
+	}{
+		{
+			name: "Success",
+			before: func() {
+				users.EXPECT().FindById(gomock.Any(), userId).Return(&models.User{
+					ID:      userId,
+					RoleIDs: []uuid.UUID{adminRoleId, managerRoleId, deletedRoleId},
+				}, nil)
+				roles.EXPECT().FindById(gomock.Any(), adminRoleId).Return(&models.Role{
+					ID:            adminRoleId,
+					Name:          models.AdminRoleType,
+					PermissionIDs: []uuid.UUID{writeSelfId, readSelfId},
+				}, nil)
+				roles.EXPECT().FindById(gomock.Any(), managerRoleId).Return(&models.Role{
+					ID:            managerRoleId,
+					Name:          models.ManagerRoleType,
+					PermissionIDs: []uuid.UUID{readSelfId},
+				}, nil)
+				roles.EXPECT().FindById(gomock.Any(), deletedRoleId).Return(nil, errors.ErrRecordNotFound)
+				permissions.EXPECT().FindById(gomock.Any(), readSelfId).Return(&models.Permission{
+					ID:   readSelfId,
+					Name: models.ReadSelfType,
+				}, nil)
+				permissions.EXPECT().FindById(gomock.Any(), writeSelfId).Return(&models.Permission{
+					ID:   writeSelfId,
+					Name: models.WriteSelfType,
+				}, nil)
+			},
+			expected: &models.EffectivePermissions{
+				UserID: userId,
+				Permissions: []models.EffectivePermission{
+					{
+						Permission: models.Permission{ID: readSelfId, Name: models.ReadSelfType},
+						GrantedBy: []models.Role{
+							{ID: adminRoleId, Name: models.AdminRoleType},
+							{ID: managerRoleId, Name: models.ManagerRoleType},
+						},
+					},
+					{
+						Permission: models.Permission{ID: writeSelfId, Name: models.WriteSelfType},
+						GrantedBy: []models.Role{
+							{ID: adminRoleId, Name: models.AdminRoleType},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "User without roles",
+			before: func() {
+				users.EXPECT().FindById(gomock.Any(), userId).Return(&models.User{ID: userId}, nil)
+			},
+			expected: &models.EffectivePermissions{
+				UserID:      userId,
+				Permissions: []models.EffectivePermission{},
+			},
+		},
+		{
+			name: "User not found",
+			before: func() {
+				users.EXPECT().FindById(gomock.Any(), userId).Return(nil, errors.ErrRecordNotFound)
+			},
+			error: errors.ErrRecordNotFound,
+		},
+		{
+			name: "Role lookup failure",
+			before: func() {
+				users.EXPECT().FindById(gomock.Any(), userId).Return(&models.User{
+					ID:      userId,
+					RoleIDs: []uuid.UUID{adminRoleId},
+				}, nil)
+				roles.EXPECT().FindById(gomock.Any(), adminRoleId).Return(nil, errors.ErrFailedToFetchResults)
+			},
+			error: errors.ErrFailedToFetchResults,
+		},
+		{
+			name: "Permission lookup failure",
+			before: func() {
+				users.EXPECT().FindById(gomock.Any(), userId).Return(&models.User{
+					ID:      userId,
+					RoleIDs: []uuid.UUID{managerRoleId},
+				}, nil)
+				roles.EXPECT().FindById(gomock.Any(), managerRoleId).Return(&models.Role{
+					ID:            managerRoleId,
+					Name:          models.ManagerRoleType,
+					PermissionIDs: []uuid.UUID{readSelfId},
+				}, nil)
+				permissions.EXPECT().FindById(gomock.Any(), readSelfId).Return(nil, assert.AnError)
+			},
+			error: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			result, err := service.Resolve(ctx, userId)
+
+			if tt.error != nil {
+				assert.Equal(t, tt.error, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/config/logger"
+)
+
+const (
+	ExportFormatParam = "format"
+
+	CSVExportFormat    = "csv"
+	NDJSONExportFormat = "ndjson"
+	JSONExportFormat   = "json"
+)
+
+// Exports streams whole collections with the filters of the list endpoints,
+// every upstream page is handed to emit as soon as it is fetched so nothing is buffered
+type Exports interface {
+	Permissions(ctx context.Context, query *Query, emit func(batch []models.Permission) error) error
+	Roles(ctx context.Context, query *Query, emit func(batch []models.Role) error) error
+	Tokens(ctx context.Context, query *Query, emit func(batch []models.Token) error) error
+	Users(ctx context.Context, query *Query, emit func(batch []models.UserExport) error) error
+}
+
+type exports struct {
+	permissions Permissions
+	roles       Roles
+	scopes      Scopes
+	tokens      Tokens
+	users       Users
+	log         *logger.Logger
+}
+
+func NewExports(permissions Permissions, roles Roles, scopes Scopes, tokens Tokens, users Users, log *logger.Logger) Exports {
+	return &exports{
+		permissions: permissions,
+		roles:       roles,
+		scopes:      scopes,
+		tokens:      tokens,
+		users:       users,
+		log:         log.WithComponent("exports"),
+	}
+}
+
+func (s *exports) Permissions(ctx context.Context, query *Query, emit func(batch []models.Permission) error) error {
+	return stream(ctx, query, PermissionsQuerySchema, func(ctx context.Context, pagination *Pagination) ([]models.Permission, uint64, error) {
+		return s.permissions.List(ctx, pagination, nil)
+	}, permissionField, emit)
+}
+
+func (s *exports) Roles(ctx context.Context, query *Query, emit func(batch []models.Role) error) error {
+	return stream(ctx, query, RolesQuerySchema, func(ctx context.Context, pagination *Pagination) ([]models.Role, uint64, error) {
+		return s.roles.List(ctx, pagination, nil)
+	}, roleField, emit)
+}
+
+func (s *exports) Tokens(ctx context.Context, query *Query, emit func(batch []models.Token) error) error {
+	return stream(ctx, query, TokensQuerySchema, func(ctx context.Context, pagination *Pagination) ([]models.Token, uint64, error) {
+		return s.tokens.List(ctx, pagination, nil)
+	}, tokenField, emit)
+}
+
+// Users resolves role and scope names once and loads the relations of every page before emitting it
+func (s *exports) Users(ctx context.Context, query *Query, emit func(batch []models.UserExport) error) error {
+	roleNames, err := s.roleNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	scopeNames, err := s.scopeNames(ctx)
+	if err != nil {
+		return err
+	}
+
+	return stream(ctx, query, UsersQuerySchema, func(ctx context.Context, pagination *Pagination) ([]models.User, uint64, error) {
+		return s.users.List(ctx, pagination, nil)
+	}, userField, func(batch []models.User) error {
+		ids := make([]uuid.UUID, 0, len(batch))
+		for _, user := range batch {
+			ids = append(ids, user.ID)
+		}
+
+		// List responses carry no relations, so every user of the page is loaded individually
+		records, err := fanOut(ctx, ids, s.users.FindById)
+		if err != nil {
+			return err
+		}
+
+		result := make([]models.UserExport, 0, len(records))
+		for _, record := range records {
+			result = append(result, models.UserExport{
+				User:   *record,
+				Roles:  namesOf(record.RoleIDs, roleNames),
+				Scopes: namesOf(record.ScopeIDs, scopeNames),
+			})
+		}
+
+		return emit(result)
+	})
+}
+
+func (s *exports) roleNames(ctx context.Context) (map[uuid.UUID]string, error) {
+	rows, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Role, uint64, error) {
+		return s.roles.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uuid.UUID]string, len(rows))
+	for _, role := range rows {
+		names[role.ID] = role.Name
+	}
+
+	return names, nil
+}
+
+func (s *exports) scopeNames(ctx context.Context) (map[uuid.UUID]string, error) {
+	rows, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Scope, uint64, error) {
+		return s.scopes.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uuid.UUID]string, len(rows))
+	for _, scope := range rows {
+		names[scope.ID] = scope.Name
+	}
+
+	return names, nil
+}
+
+// namesOf maps ids to names, ids removed since the names were loaded are skipped
+func namesOf(ids []uuid.UUID, names map[uuid.UUID]string) []string {
+	result := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		if name, ok := names[id]; ok {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
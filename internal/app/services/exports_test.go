@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func Test_Exports_Users(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.Background()
+	permissions := NewMockPermissions(ctrl)
+	roles := NewMockRoles(ctrl)
+	scopes := NewMockScopes(ctrl)
+	tokens := NewMockTokens(ctrl)
+	users := NewMockUsers(ctrl)
+	service := NewExports(permissions, roles, scopes, tokens, users, log)
+
+	adminRoleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	managerRoleId := uuid.MustParse("10000000-1000-1000-3000-000000000002")
+	scopeId := uuid.MustParse("10000000-1000-1000-2000-000000000001")
+
+	john := models.User{ID: uuid.MustParse("10000000-1000-1000-1234-000000000001"), IdentityNumber: "PNOEE-60001017869", FirstName: "John", LastName: "Doe"}
+	jane := models.User{ID: uuid.MustParse("10000000-1000-1000-1234-000000000002"), IdentityNumber: "PNOEE-60001017870", FirstName: "Jane", LastName: "Doe"}
+	bob := models.User{ID: uuid.MustParse("10000000-1000-1000-1234-000000000003"), IdentityNumber: "PNOEE-60001017871", FirstName: "Bob", LastName: "Smith"}
+
+	expectNames := func() {
+		roles.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Role{{ID: adminRoleId, Name: "admin"}, {ID: managerRoleId, Name: "manager"}}, uint64(2), nil)
+		scopes.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Scope{{ID: scopeId, Name: "sso-service"}}, uint64(1), nil)
+	}
+
+	expectRelations := func(records ...models.User) {
+		for _, record := range records {
+			found := record
+			found.RoleIDs = []uuid.UUID{adminRoleId, managerRoleId}
+			found.ScopeIDs = []uuid.UUID{scopeId}
+			users.EXPECT().FindById(gomock.Any(), record.ID).Return(&found, nil)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		before   func()
+		query    *Query
+		expected [][]string
+		error    error
+	}{
+		{
+			name: "Streams every page",
+			before: func() {
+				expectNames()
+				users.EXPECT().List(gomock.Any(), &Pagination{Page: 1, PerPage: MaxPerPage}, nil).Return([]models.User{john, jane}, uint64(3), nil)
+				users.EXPECT().List(gomock.Any(), &Pagination{Page: 2, PerPage: MaxPerPage}, nil).Return([]models.User{bob}, uint64(3), nil)
+				expectRelations(john, jane, bob)
+			},
+			expected: [][]string{{"John", "Jane"}, {"Bob"}},
+		},
+		{
+			name: "Filters every page",
+			before: func() {
+				expectNames()
+				users.EXPECT().List(gomock.Any(), &Pagination{Page: 1, PerPage: MaxPerPage}, nil).Return([]models.User{john, jane}, uint64(3), nil)
+				users.EXPECT().List(gomock.Any(), &Pagination{Page: 2, PerPage: MaxPerPage}, nil).Return([]models.User{bob}, uint64(3), nil)
+				expectRelations(john, jane)
+			},
+			query:    &Query{Filters: map[string]string{"last_name": "doe"}},
+			expected: [][]string{{"John", "Jane"}},
+		},
+		{
+			name: "Sorts the whole collection",
+			before: func() {
+				expectNames()
+				users.EXPECT().List(gomock.Any(), &Pagination{Page: 1, PerPage: MaxPerPage}, nil).Return([]models.User{john, jane, bob}, uint64(3), nil)
+				expectRelations(bob, jane, john)
+			},
+			query:    &Query{Sort: []SortField{{Field: "first_name"}}},
+			expected: [][]string{{"Bob", "Jane", "John"}},
+		},
+		{
+			name: "Upstream error",
+			before: func() {
+				expectNames()
+				users.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return(nil, uint64(0), errors.ErrUnavailable)
+			},
+			error: errors.ErrUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			batches := make([][]string, 0)
+			err := service.Users(ctx, tt.query, func(batch []models.UserExport) error {
+				names := make([]string, 0, len(batch))
+				for _, item := range batch {
+					assert.Equal(t, []string{"admin", "manager"}, item.Roles)
+					assert.Equal(t, []string{"sso-service"}, item.Scopes)
+					names = append(names, item.FirstName)
+				}
+
+				batches = append(batches, names)
+				return nil
+			})
+
+			if tt.error != nil {
+				assert.ErrorIs(t, err, tt.error)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, batches)
+			}
+		})
+	}
+}
+
+func Test_Exports_Tokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	tokens := NewMockTokens(ctrl)
+	service := NewExports(NewMockPermissions(ctrl), NewMockRoles(ctrl), NewMockScopes(ctrl), tokens, NewMockUsers(ctrl), log)
+
+	access := models.Token{ID: uuid.MustParse("10000000-1000-1000-6000-000000000001"), Type: models.AccessTokenType}
+	refresh := models.Token{ID: uuid.MustParse("10000000-1000-1000-6000-000000000002"), Type: models.RefreshTokenType}
+
+	tokens.EXPECT().List(gomock.Any(), &Pagination{Page: 1, PerPage: MaxPerPage}, nil).Return([]models.Token{access, refresh}, uint64(2), nil)
+
+	emitted := make([]models.Token, 0)
+	err := service.Tokens(context.Background(), &Query{Filters: map[string]string{"type": models.RefreshTokenType}}, func(batch []models.Token) error {
+		emitted = append(emitted, batch...)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Token{refresh}, emitted)
+}
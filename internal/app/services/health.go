@@ -4,22 +4,31 @@ import (
 	"context"
 
 	"loki-backoffice/internal/app/repositories"
+	"loki-backoffice/internal/app/rpcs/interceptors"
 )
 
 type HealthChecker interface {
 	Ping(ctx context.Context) error
+	Circuits() map[string]string
 }
 
 type health struct {
 	repository repositories.HealthRepository
+	breaker    interceptors.BreakerInterceptor
 }
 
-func NewHealthChecker(repository repositories.HealthRepository) HealthChecker {
+func NewHealthChecker(repository repositories.HealthRepository, breaker interceptors.BreakerInterceptor) HealthChecker {
 	return &health{
 		repository: repository,
+		breaker:    breaker,
 	}
 }
 
 func (h *health) Ping(ctx context.Context) error {
 	return h.repository.Ping(ctx)
 }
+
+// Circuits returns the circuit breaker state of every SSO service
+func (h *health) Circuits() map[string]string {
+	return h.breaker.States()
+}
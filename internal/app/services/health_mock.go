@@ -40,6 +40,20 @@ func (m *MockHealthChecker) EXPECT() *MockHealthCheckerMockRecorder {
 	return m.recorder
 }
 
+// Circuits mocks base method.
+func (m *MockHealthChecker) Circuits() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Circuits")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// Circuits indicates an expected call of Circuits.
+func (mr *MockHealthCheckerMockRecorder) Circuits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Circuits", reflect.TypeOf((*MockHealthChecker)(nil).Circuits))
+}
+
 // Ping mocks base method.
 func (m *MockHealthChecker) Ping(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -8,6 +8,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"loki-backoffice/internal/app/repositories"
+	"loki-backoffice/internal/app/rpcs/interceptors"
 )
 
 func Test_HealthChecker_Ping(t *testing.T) {
@@ -16,7 +17,8 @@ func Test_HealthChecker_Ping(t *testing.T) {
 
 	ctx := context.Background()
 	repository := repositories.NewMockHealthRepository(ctrl)
-	service := NewHealthChecker(repository)
+	breaker := interceptors.NewMockBreakerInterceptor(ctrl)
+	service := NewHealthChecker(repository, breaker)
 
 	tests := []struct {
 		name     string
@@ -48,3 +50,17 @@ func Test_HealthChecker_Ping(t *testing.T) {
 		})
 	}
 }
+
+func Test_HealthChecker_Circuits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repository := repositories.NewMockHealthRepository(ctrl)
+	breaker := interceptors.NewMockBreakerInterceptor(ctrl)
+	service := NewHealthChecker(repository, breaker)
+
+	states := map[string]string{"sso.v1.UserService": interceptors.BreakerOpen}
+	breaker.EXPECT().States().Return(states)
+
+	assert.Equal(t, states, service.Circuits())
+}
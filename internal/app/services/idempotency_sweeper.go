@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"loki-backoffice/internal/app/repositories"
+	"loki-backoffice/internal/config/logger"
+)
+
+// IdempotencySweepInterval is how often expired idempotency keys are deleted
+const IdempotencySweepInterval = 15 * time.Minute
+
+type IdempotencySweeper interface {
+	Run(ctx context.Context)
+	Sweep(ctx context.Context) (int64, error)
+}
+
+type idempotencySweeper struct {
+	repository repositories.IdempotencyRepository
+	interval   time.Duration
+	log        *logger.Logger
+}
+
+func NewIdempotencySweeper(repository repositories.IdempotencyRepository, log *logger.Logger) IdempotencySweeper {
+	return &idempotencySweeper{
+		repository: repository,
+		interval:   IdempotencySweepInterval,
+		log:        log.WithComponent("idempotency_sweeper"),
+	}
+}
+
+// Run sweeps on every interval until ctx is canceled
+func (s *idempotencySweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = s.Sweep(ctx)
+		}
+	}
+}
+
+// Sweep deletes the expired idempotency keys once
+func (s *idempotencySweeper) Sweep(ctx context.Context) (int64, error) {
+	deleted, err := s.repository.DeleteExpired(ctx)
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to delete expired idempotency keys")
+		return 0, err
+	}
+
+	if deleted > 0 {
+		s.log.Info().Int64("deleted", deleted).Msg("Deleted expired idempotency keys")
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/repositories"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func Test_IdempotencySweeper_Sweep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.Background()
+	repository := repositories.NewMockIdempotencyRepository(ctrl)
+	service := NewIdempotencySweeper(repository, log)
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected int64
+		error    error
+	}{
+		{
+			name: "Success",
+			before: func() {
+				repository.EXPECT().DeleteExpired(ctx).Return(int64(3), nil)
+			},
+			expected: 3,
+		},
+		{
+			name: "Error",
+			before: func() {
+				repository.EXPECT().DeleteExpired(ctx).Return(int64(0), assert.AnError)
+			},
+			error: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			result, err := service.Sweep(ctx)
+			assert.Equal(t, tt.error, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_IdempotencySweeper_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	repository := repositories.NewMockIdempotencyRepository(ctrl)
+	service := NewIdempotencySweeper(repository, log).(*idempotencySweeper)
+	service.interval = time.Millisecond
+
+	repository.EXPECT().DeleteExpired(gomock.Any()).
+		DoAndReturn(func(_ context.Context) (int64, error) {
+			cancel()
+			return 1, nil
+		}).
+		MinTimes(1)
+
+	done := make(chan struct{})
+	go func() {
+		service.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sweeper did not stop after cancellation")
+	}
+}
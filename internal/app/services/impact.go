@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/config/logger"
+)
+
+const (
+	ForceParam  = "force"
+	DryRunParam = "dry_run"
+)
+
+// DeleteOptions controls the referential checks performed before a delete
+type DeleteOptions struct {
+	// Force skips the dependents check
+	Force bool
+	// DryRun reports the impact without deleting
+	DryRun bool
+}
+
+func NewDeleteOptions(r *http.Request) (*DeleteOptions, error) {
+	force, err := parseBoolParam(r, ForceParam)
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun, err := parseBoolParam(r, DryRunParam)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteOptions{
+		Force:  force,
+		DryRun: dryRun,
+	}, nil
+}
+
+// Checked reports whether the impact has to be analyzed before deleting
+func (o *DeleteOptions) Checked() bool {
+	return o.DryRun || !o.Force
+}
+
+func parseBoolParam(r *http.Request, key string) (bool, error) {
+	param := r.URL.Query().Get(key)
+	if param == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, errors.ErrInvalidArguments
+	}
+
+	return value, nil
+}
+
+// DependentsError reports the dependents blocking a delete
+func DependentsError(impact *models.Impact) error {
+	return fmt.Errorf("%w: %d roles and %d users", errors.ErrResourceHasDependents, len(impact.Roles), len(impact.Users))
+}
+
+// ImpactAnalyzer reports which roles and users reference a permission, role or scope.
+// It gates deletes, so every check scans upstream instead of reading the cached Index,
+// which would miss assignments made outside the backoffice for up to IndexTTL.
+type ImpactAnalyzer interface {
+	Permission(ctx context.Context, id uuid.UUID) (*models.Impact, error)
+	Role(ctx context.Context, id uuid.UUID) (*models.Impact, error)
+	Scope(ctx context.Context, id uuid.UUID) (*models.Impact, error)
+}
+
+type impactAnalyzer struct {
+	// index returns an empty index for each check, it is built on the first lookup
+	// and shared by the lookups of that check only
+	index func() Index
+	log   *logger.Logger
+}
+
+func NewImpactAnalyzer(users Users, roles Roles, log *logger.Logger) ImpactAnalyzer {
+	return &impactAnalyzer{
+		index: func() Index {
+			return NewIndex(users, roles, log)
+		},
+		log: log,
+	}
+}
+
+// Permission returns the roles containing the permission and the users holding those roles
+func (a *impactAnalyzer) Permission(ctx context.Context, id uuid.UUID) (*models.Impact, error) {
+	index := a.index()
+
+	roles, err := index.RolesByPermission(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	users := make([]models.User, 0)
+
+	for _, role := range roles {
+		holders, err := index.UsersByRole(ctx, role.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range holders {
+			if !seen[user.ID] {
+				seen[user.ID] = true
+				users = append(users, user)
+			}
+		}
+	}
+
+	return &models.Impact{
+		ResourceType: models.PermissionResourceType,
+		ResourceId:   id,
+		Roles:        roles,
+		Users:        users,
+	}, nil
+}
+
+// Role returns the users holding the role
+func (a *impactAnalyzer) Role(ctx context.Context, id uuid.UUID) (*models.Impact, error) {
+	users, err := a.index().UsersByRole(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Impact{
+		ResourceType: models.RoleResourceType,
+		ResourceId:   id,
+		Users:        users,
+	}, nil
+}
+
+// Scope returns the users holding the scope
+func (a *impactAnalyzer) Scope(ctx context.Context, id uuid.UUID) (*models.Impact, error) {
+	users, err := a.index().UsersByScope(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Impact{
+		ResourceType: models.ScopeResourceType,
+		ResourceId:   id,
+		Users:        users,
+	}, nil
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func Test_NewDeleteOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected *DeleteOptions
+		checked  bool
+		error    error
+	}{
+		{
+			name:     "Defaults",
+			path:     "/",
+			expected: &DeleteOptions{},
+			checked:  true,
+		},
+		{
+			name:     "Force",
+			path:     "/?force=true",
+			expected: &DeleteOptions{Force: true},
+			checked:  false,
+		},
+		{
+			name:     "Dry run overrides force",
+			path:     "/?force=1&dry_run=1",
+			expected: &DeleteOptions{Force: true, DryRun: true},
+			checked:  true,
+		},
+		{
+			name:  "Invalid dry run",
+			path:  "/?dry_run=yes",
+			error: errors.ErrInvalidArguments,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("DELETE", tt.path, nil)
+			options, err := NewDeleteOptions(request)
+
+			if tt.error != nil {
+				assert.Equal(t, tt.error, err)
+				assert.Nil(t, options)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, options)
+				assert.Equal(t, tt.checked, options.Checked())
+			}
+		})
+	}
+}
+
+func Test_ImpactAnalyzer_Permission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.Background()
+	index := NewMockIndex(ctrl)
+	service := &impactAnalyzer{index: func() Index { return index }, log: log}
+
+	permissionId := uuid.MustParse("10000000-1000-1000-4000-000000000001")
+	adminRole := models.Role{ID: uuid.MustParse("10000000-1000-1000-3000-000000000001"), Name: models.AdminRoleType}
+	managerRole := models.Role{ID: uuid.MustParse("10000000-1000-1000-3000-000000000002"), Name: models.ManagerRoleType}
+	john := models.User{ID: uuid.MustParse("10000000-1000-1000-1234-000000000001")}
+	jane := models.User{ID: uuid.MustParse("10000000-1000-1000-1234-000000000002")}
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected *models.Impact
+		error    error
+	}{
+		{
+			name: "Success",
+			before: func() {
+				index.EXPECT().RolesByPermission(gomock.Any(), permissionId).Return([]models.Role{adminRole, managerRole}, nil)
+				index.EXPECT().UsersByRole(gomock.Any(), adminRole.ID).Return([]models.User{john, jane}, nil)
+				index.EXPECT().UsersByRole(gomock.Any(), managerRole.ID).Return([]models.User{jane}, nil)
+			},
+			expected: &models.Impact{
+				ResourceType: models.PermissionResourceType,
+				ResourceId:   permissionId,
+				Roles:        []models.Role{adminRole, managerRole},
+				Users:        []models.User{john, jane},
+			},
+		},
+		{
+			name: "Unreferenced",
+			before: func() {
+				index.EXPECT().RolesByPermission(gomock.Any(), permissionId).Return(nil, nil)
+			},
+			expected: &models.Impact{
+				ResourceType: models.PermissionResourceType,
+				ResourceId:   permissionId,
+				Users:        []models.User{},
+			},
+		},
+		{
+			name: "Error",
+			before: func() {
+				index.EXPECT().RolesByPermission(gomock.Any(), permissionId).Return([]models.Role{adminRole}, nil)
+				index.EXPECT().UsersByRole(gomock.Any(), adminRole.ID).Return(nil, errors.ErrFailedToFetchResults)
+			},
+			error: errors.ErrFailedToFetchResults,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			result, err := service.Permission(ctx, permissionId)
+
+			if tt.error != nil {
+				assert.Equal(t, tt.error, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+				assert.Equal(t, len(tt.expected.Users) == 0, result.IsEmpty())
+			}
+		})
+	}
+}
+
+func Test_ImpactAnalyzer_ScansUpstreamOnEveryCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.Background()
+	users := NewMockUsers(ctrl)
+	roles := NewMockRoles(ctrl)
+	service := NewImpactAnalyzer(users, roles, log)
+
+	roleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	john := models.User{ID: uuid.MustParse("10000000-1000-1000-1234-000000000001")}
+
+	users.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.User{john}, uint64(1), nil).Times(2)
+	users.EXPECT().FindById(gomock.Any(), john.ID).Return(&models.User{ID: john.ID}, nil)
+	users.EXPECT().FindById(gomock.Any(), john.ID).Return(&models.User{ID: john.ID, RoleIDs: []uuid.UUID{roleId}}, nil)
+
+	result, err := service.Role(ctx, roleId)
+	assert.NoError(t, err)
+	assert.True(t, result.IsEmpty())
+
+	// the role was assigned upstream in between, a cached index would still report no holders
+	result, err = service.Role(ctx, roleId)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.User{{ID: john.ID, RoleIDs: []uuid.UUID{roleId}}}, result.Users)
+}
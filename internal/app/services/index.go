@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/config/logger"
+)
+
+const (
+	// IndexTTL bounds how long changes made outside the backoffice stay invisible to reverse lookups
+	IndexTTL = 5 * time.Minute
+	// indexBuildTimeout bounds a shared build, it is detached from the lookup that started it
+	indexBuildTimeout = time.Minute
+)
+
+// Index answers reverse relation lookups the SSO service does not expose,
+// it is built by scanning the upstream collections and cached until invalidated
+type Index interface {
+	UsersByRole(ctx context.Context, roleId uuid.UUID) ([]models.User, error)
+	UsersByScope(ctx context.Context, scopeId uuid.UUID) ([]models.User, error)
+	RolesByPermission(ctx context.Context, permissionId uuid.UUID) ([]models.Role, error)
+	Invalidate()
+}
+
+type index struct {
+	users Users
+	roles Roles
+	log   *logger.Logger
+	now   func() time.Time
+
+	// generation is bumped by every write, a snapshot built for an older generation is stale
+	generation atomic.Uint64
+	group      singleflight.Group
+
+	usersIndex atomic.Pointer[usersIndex]
+	rolesIndex atomic.Pointer[rolesIndex]
+}
+
+type usersIndex struct {
+	generation uint64
+	builtAt    time.Time
+	byRole     map[uuid.UUID][]models.User
+	byScope    map[uuid.UUID][]models.User
+}
+
+type rolesIndex struct {
+	generation   uint64
+	builtAt      time.Time
+	byPermission map[uuid.UUID][]models.Role
+}
+
+func NewIndex(users Users, roles Roles, log *logger.Logger) Index {
+	return &index{
+		users: users,
+		roles: roles,
+		log:   log.WithComponent("index"),
+		now:   time.Now,
+	}
+}
+
+func (i *index) UsersByRole(ctx context.Context, roleId uuid.UUID) ([]models.User, error) {
+	current, err := i.loadUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return current.byRole[roleId], nil
+}
+
+func (i *index) UsersByScope(ctx context.Context, scopeId uuid.UUID) ([]models.User, error) {
+	current, err := i.loadUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return current.byScope[scopeId], nil
+}
+
+func (i *index) RolesByPermission(ctx context.Context, permissionId uuid.UUID) ([]models.Role, error) {
+	current, err := i.loadRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return current.byPermission[permissionId], nil
+}
+
+// Invalidate marks the cached index stale without waiting for a build in progress, the next lookup rebuilds it
+func (i *index) Invalidate() {
+	i.generation.Add(1)
+}
+
+func (i *index) fresh(generation uint64, builtAt time.Time) bool {
+	return generation == i.generation.Load() && i.now().Sub(builtAt) < IndexTTL
+}
+
+func (i *index) loadUsers(ctx context.Context) (*usersIndex, error) {
+	if current := i.usersIndex.Load(); current != nil && i.fresh(current.generation, current.builtAt) {
+		return current, nil
+	}
+
+	value, err := i.share(ctx, "users", func(ctx context.Context, generation uint64) (interface{}, error) {
+		return i.buildUsers(ctx, generation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*usersIndex), nil
+}
+
+func (i *index) loadRoles(ctx context.Context) (*rolesIndex, error) {
+	if current := i.rolesIndex.Load(); current != nil && i.fresh(current.generation, current.builtAt) {
+		return current, nil
+	}
+
+	value, err := i.share(ctx, "roles", func(ctx context.Context, generation uint64) (interface{}, error) {
+		return i.buildRoles(ctx, generation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*rolesIndex), nil
+}
+
+// share runs one build per collection and generation for all concurrent lookups,
+// a write bumps the generation so that lookups after it never join a build started before it
+func (i *index) share(ctx context.Context, name string, build func(context.Context, uint64) (interface{}, error)) (interface{}, error) {
+	generation := i.generation.Load()
+	key := name + ":" + strconv.FormatUint(generation, 10)
+
+	result := i.group.DoChan(key, func() (interface{}, error) {
+		buildCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), indexBuildTimeout)
+		defer cancel()
+
+		return build(buildCtx, generation)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-result:
+		return r.Val, r.Err
+	}
+}
+
+func (i *index) buildUsers(ctx context.Context, generation uint64) (*usersIndex, error) {
+	rows, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.User, uint64, error) {
+		return i.users.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	// List responses carry no relations, so every user is loaded individually
+	records, err := fanOut(ctx, ids, i.users.FindById)
+	if err != nil {
+		return nil, err
+	}
+
+	byRole := make(map[uuid.UUID][]models.User)
+	byScope := make(map[uuid.UUID][]models.User)
+
+	for _, record := range records {
+		for _, roleId := range record.RoleIDs {
+			byRole[roleId] = append(byRole[roleId], *record)
+		}
+
+		for _, scopeId := range record.ScopeIDs {
+			byScope[scopeId] = append(byScope[scopeId], *record)
+		}
+	}
+
+	built := &usersIndex{
+		generation: generation,
+		builtAt:    i.now(),
+		byRole:     byRole,
+		byScope:    byScope,
+	}
+	i.usersIndex.Store(built)
+
+	i.log.Info().Int("users", len(records)).Msg("Built users index")
+
+	return built, nil
+}
+
+func (i *index) buildRoles(ctx context.Context, generation uint64) (*rolesIndex, error) {
+	rows, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Role, uint64, error) {
+		return i.roles.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	records, err := fanOut(ctx, ids, i.roles.FindById)
+	if err != nil {
+		return nil, err
+	}
+
+	byPermission := make(map[uuid.UUID][]models.Role)
+
+	for _, record := range records {
+		for _, permissionId := range record.PermissionIDs {
+			byPermission[permissionId] = append(byPermission[permissionId], *record)
+		}
+	}
+
+	built := &rolesIndex{
+		generation:   generation,
+		builtAt:      i.now(),
+		byPermission: byPermission,
+	}
+	i.rolesIndex.Store(built)
+
+	i.log.Info().Int("roles", len(records)).Msg("Built roles index")
+
+	return built, nil
+}
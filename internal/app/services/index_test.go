@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func Test_Index_Users(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.Background()
+	users := NewMockUsers(ctrl)
+	roles := NewMockRoles(ctrl)
+	service := NewIndex(users, roles, log).(*index)
+
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	adminRoleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	managerRoleId := uuid.MustParse("10000000-1000-1000-3000-000000000002")
+	scopeId := uuid.MustParse("10000000-1000-1000-2000-000000000001")
+
+	john := models.User{
+		ID:       uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+		RoleIDs:  []uuid.UUID{adminRoleId},
+		ScopeIDs: []uuid.UUID{scopeId},
+	}
+	jane := models.User{
+		ID:       uuid.MustParse("10000000-1000-1000-1234-000000000002"),
+		RoleIDs:  []uuid.UUID{adminRoleId, managerRoleId},
+		ScopeIDs: []uuid.UUID{scopeId},
+	}
+
+	expectBuild := func() {
+		users.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.User{
+			{ID: john.ID},
+			{ID: jane.ID},
+		}, uint64(2), nil)
+		users.EXPECT().FindById(gomock.Any(), john.ID).Return(&john, nil)
+		users.EXPECT().FindById(gomock.Any(), jane.ID).Return(&jane, nil)
+	}
+
+	tests := []struct {
+		name   string
+		before func()
+	}{
+		{
+			name:   "Builds index on first lookup",
+			before: expectBuild,
+		},
+		{
+			name:   "Reuses cached index",
+			before: func() {},
+		},
+		{
+			name: "Rebuilds after invalidation",
+			before: func() {
+				service.Invalidate()
+				expectBuild()
+			},
+		},
+		{
+			name: "Rebuilds after TTL",
+			before: func() {
+				now = now.Add(IndexTTL)
+				expectBuild()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			result, err := service.UsersByRole(ctx, adminRoleId)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.User{john, jane}, result)
+
+			result, err = service.UsersByRole(ctx, managerRoleId)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.User{jane}, result)
+
+			result, err = service.UsersByScope(ctx, scopeId)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.User{john, jane}, result)
+
+			result, err = service.UsersByScope(ctx, uuid.New())
+			assert.NoError(t, err)
+			assert.Empty(t, result)
+		})
+	}
+}
+
+func Test_Index_RolesByPermission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.Background()
+	users := NewMockUsers(ctrl)
+	roles := NewMockRoles(ctrl)
+
+	permissionId := uuid.MustParse("10000000-1000-1000-4000-000000000001")
+	adminRoleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	managerRoleId := uuid.MustParse("10000000-1000-1000-3000-000000000002")
+	deletedRoleId := uuid.MustParse("10000000-1000-1000-3000-000000000003")
+
+	tests := []struct {
+		name     string
+		before   func()
+		expected []models.Role
+		error    error
+	}{
+		{
+			name: "Success",
+			before: func() {
+				roles.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Role{
+					{ID: adminRoleId},
+					{ID: managerRoleId},
+					{ID: deletedRoleId},
+				}, uint64(3), nil)
+				roles.EXPECT().FindById(gomock.Any(), adminRoleId).Return(&models.Role{
+					ID:            adminRoleId,
+					Name:          models.AdminRoleType,
+					PermissionIDs: []uuid.UUID{permissionId},
+				}, nil)
+				roles.EXPECT().FindById(gomock.Any(), managerRoleId).Return(&models.Role{
+					ID:   managerRoleId,
+					Name: models.ManagerRoleType,
+				}, nil)
+				roles.EXPECT().FindById(gomock.Any(), deletedRoleId).Return(nil, errors.ErrRecordNotFound)
+			},
+			expected: []models.Role{
+				{
+					ID:            adminRoleId,
+					Name:          models.AdminRoleType,
+					PermissionIDs: []uuid.UUID{permissionId},
+				},
+			},
+		},
+		{
+			name: "Error",
+			before: func() {
+				roles.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return(nil, uint64(0), errors.ErrFailedToFetchResults)
+			},
+			error: errors.ErrFailedToFetchResults,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			service := NewIndex(users, roles, log)
+			result, err := service.RolesByPermission(ctx, permissionId)
+
+			if tt.error != nil {
+				assert.Equal(t, tt.error, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func Test_Index_SharedBuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.Background()
+	users := NewMockUsers(ctrl)
+	roles := NewMockRoles(ctrl)
+	service := NewIndex(users, roles, log)
+
+	roleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	john := models.User{
+		ID:      uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+		RoleIDs: []uuid.UUID{roleId},
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	users.EXPECT().List(gomock.Any(), gomock.Any(), nil).
+		DoAndReturn(func(_ context.Context, _ *Pagination, _ *Query) ([]models.User, uint64, error) {
+			close(started)
+			<-release
+			return []models.User{{ID: john.ID}}, uint64(1), nil
+		})
+	users.EXPECT().FindById(gomock.Any(), john.ID).Return(&john, nil)
+
+	var wg sync.WaitGroup
+	results := make([][]models.User, 2)
+
+	for n := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result, err := service.UsersByRole(ctx, roleId)
+			assert.NoError(t, err)
+			results[n] = result
+		}()
+
+		if n == 0 {
+			<-started
+		}
+	}
+
+	time.Sleep(10 * time.Millisecond)
+
+	invalidated := make(chan struct{})
+	go func() {
+		service.Invalidate()
+		close(invalidated)
+	}()
+
+	select {
+	case <-invalidated:
+	case <-time.After(time.Second):
+		t.Fatal("Invalidate waited for the build in progress")
+	}
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, []models.User{john}, results[0])
+	assert.Equal(t, []models.User{john}, results[1])
+
+	users.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.User{}, uint64(0), nil)
+
+	result, err := service.UsersByRole(ctx, roleId)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
@@ -8,7 +8,17 @@ import (
 )
 
 var Module = fx.Options(
+	fx.Provide(NewAudit),
+	fx.Provide(NewEffectivePermissions),
+	fx.Provide(NewExports),
 	fx.Provide(NewHealthChecker),
+	fx.Provide(NewIdempotencySweeper),
+	fx.Provide(NewImpactAnalyzer),
+	fx.Provide(NewIndex),
+	fx.Provide(NewPaginator),
+	fx.Provide(NewRBAC),
+	fx.Provide(NewSnapshots),
+	fx.Provide(NewUserImports),
 	fx.Provide(
 		func(registry *rpcs.Registry) proto.PermissionServiceClient {
 			return registry.GetPermissionClient()
@@ -2,7 +2,15 @@ package services
 
 import (
 	"net/http"
+	"slices"
+	"sort"
 	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
 )
 
 const (
@@ -14,6 +22,20 @@ const (
 type Pagination struct {
 	Page    uint64
 	PerPage uint64
+
+	// Cursor is set when the request was paginated with ?cursor= instead of ?page=
+	Cursor *Cursor
+	// Window is filled by a cursor mode list with the keys bounding the returned page
+	Window *Window
+}
+
+// Window holds the keys of the first and last record of a cursor page
+// and whether records exist before and after it
+type Window struct {
+	First   []string
+	Last    []string
+	HasPrev bool
+	HasNext bool
 }
 
 func NewPagination(r *http.Request) *Pagination {
@@ -56,6 +78,152 @@ func (p *Pagination) Limit() uint64 {
 	return p.PerPage
 }
 
+// Offset is zero in cursor mode, the page is located by the cursor key instead
 func (p *Pagination) Offset() uint64 {
+	if p.Cursor != nil {
+		return 0
+	}
+
 	return (p.Page - 1) * p.PerPage
 }
+
+// PageOf returns the records of the current page from a fully loaded collection.
+// In cursor mode the records are ordered by id and the page is located by the cursor key.
+func PageOf[T any](rows []T, pagination *Pagination, id func(item T) uuid.UUID) []T {
+	key := func(item T) []string {
+		return []string{id(item).String()}
+	}
+
+	return pageOf(rows, pagination, key, slices.Compare[[]string])
+}
+
+// pageOf orders the records by key in cursor mode and returns the ones after or before the cursor key,
+// so records created or deleted between requests neither shift nor repeat the following pages
+func pageOf[T any](rows []T, pagination *Pagination, key func(item T) []string, compare func(a, b []string) int) []T {
+	if pagination.Cursor == nil {
+		total := uint64(len(rows))
+		start := min(pagination.Offset(), total)
+		end := min(start+pagination.Limit(), total)
+
+		return rows[start:end]
+	}
+
+	ordered := slices.Clone(rows)
+	slices.SortStableFunc(ordered, func(a, b T) int {
+		return compare(key(a), key(b))
+	})
+
+	size := int(pagination.Limit()) //nolint:gosec
+	start, end := 0, min(size, len(ordered))
+
+	switch {
+	case pagination.Cursor.After != nil:
+		start = sort.Search(len(ordered), func(i int) bool {
+			return compare(key(ordered[i]), pagination.Cursor.After) > 0
+		})
+		end = min(start+size, len(ordered))
+	case pagination.Cursor.Before != nil:
+		end = sort.Search(len(ordered), func(i int) bool {
+			return compare(key(ordered[i]), pagination.Cursor.Before) >= 0
+		})
+		start = max(end-size, 0)
+	}
+
+	page := ordered[start:end]
+
+	pagination.Window = &Window{
+		First:   pagination.Cursor.After,
+		Last:    pagination.Cursor.Before,
+		HasPrev: start > 0,
+		HasNext: end < len(ordered),
+	}
+	if len(page) > 0 {
+		pagination.Window.First = key(page[0])
+		pagination.Window.Last = key(page[len(page)-1])
+	}
+
+	return page
+}
+
+// Seek returns the position a cursor page of a newest first Postgres collection starts after or ends before,
+// it is nil in page mode and on the first cursor page
+func (p *Pagination) Seek() (*models.Seek, error) {
+	if p.Cursor == nil {
+		return nil, nil
+	}
+
+	key, reverse := p.Cursor.After, false
+	if p.Cursor.Before != nil {
+		key, reverse = p.Cursor.Before, true
+	}
+
+	if key == nil {
+		return nil, nil
+	}
+
+	if len(key) != 2 {
+		return nil, errors.ErrInvalidCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, key[0])
+	if err != nil {
+		return nil, errors.ErrInvalidCursor
+	}
+
+	id, err := uuid.Parse(key[1])
+	if err != nil {
+		return nil, errors.ErrInvalidCursor
+	}
+
+	return &models.Seek{CreatedAt: createdAt, ID: id, Reverse: reverse}, nil
+}
+
+// SeekLimit reads one record more than the page in cursor mode to learn whether the list goes on in the seek direction
+func (p *Pagination) SeekLimit() uint64 {
+	if p.Cursor == nil {
+		return p.Limit()
+	}
+
+	return p.Limit() + 1
+}
+
+// seekOf trims the record read beyond the page by SeekLimit and fills the cursor window
+func seekOf[T any](rows []T, pagination *Pagination, seek *models.Seek, key func(item T) (time.Time, uuid.UUID)) []T {
+	if pagination.Cursor == nil {
+		return rows
+	}
+
+	more := uint64(len(rows)) > pagination.Limit()
+
+	window := &Window{
+		First: pagination.Cursor.After,
+		Last:  pagination.Cursor.Before,
+	}
+
+	if seek != nil && seek.Reverse {
+		if more {
+			rows = rows[1:]
+		}
+		window.HasPrev = more
+		window.HasNext = true
+	} else {
+		if more {
+			rows = rows[:len(rows)-1]
+		}
+		window.HasPrev = seek != nil
+		window.HasNext = more
+	}
+
+	encode := func(item T) []string {
+		createdAt, id := key(item)
+		return []string{createdAt.UTC().Format(time.RFC3339Nano), id.String()}
+	}
+
+	if len(rows) > 0 {
+		window.First = encode(rows[0])
+		window.Last = encode(rows[len(rows)-1])
+	}
+	pagination.Window = window
+
+	return rows
+}
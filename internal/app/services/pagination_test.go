@@ -1,10 +1,16 @@
 package services
 
 import (
+	"fmt"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
 )
 
 func Test_NewPagination(t *testing.T) {
@@ -119,3 +125,176 @@ func Test_Pagination_Offset(t *testing.T) {
 		})
 	}
 }
+
+func Test_Pagination_Offset_Cursor(t *testing.T) {
+	pagination := &Pagination{
+		PerPage: 10,
+		Cursor:  &Cursor{After: []string{"10000000-1000-1000-1234-000000000015"}, PerPage: 10},
+	}
+
+	assert.Equal(t, uint64(10), pagination.Limit())
+	assert.Equal(t, uint64(0), pagination.Offset())
+}
+
+func Test_PageOf(t *testing.T) {
+	ids := make([]uuid.UUID, 0, 5)
+	for n := 1; n <= 5; n++ {
+		ids = append(ids, uuid.MustParse(fmt.Sprintf("10000000-1000-1000-1234-00000000000%d", n)))
+	}
+	key := func(n int) []string {
+		return []string{ids[n].String()}
+	}
+	id := func(item uuid.UUID) uuid.UUID { return item }
+
+	// Reversed so that cursor mode has to order the records itself
+	rows := []uuid.UUID{ids[4], ids[3], ids[2], ids[1], ids[0]}
+
+	tests := []struct {
+		name       string
+		rows       []uuid.UUID
+		pagination *Pagination
+		expected   []uuid.UUID
+		window     *Window
+	}{
+		{
+			name:       "Page mode",
+			rows:       rows,
+			pagination: &Pagination{Page: 2, PerPage: 2},
+			expected:   []uuid.UUID{ids[2], ids[1]},
+		},
+		{
+			name:       "First cursor page",
+			rows:       rows,
+			pagination: &Pagination{PerPage: 2, Cursor: &Cursor{PerPage: 2}},
+			expected:   []uuid.UUID{ids[0], ids[1]},
+			window:     &Window{First: key(0), Last: key(1), HasNext: true},
+		},
+		{
+			name:       "After key",
+			rows:       rows,
+			pagination: &Pagination{PerPage: 2, Cursor: &Cursor{After: key(1), PerPage: 2}},
+			expected:   []uuid.UUID{ids[2], ids[3]},
+			window:     &Window{First: key(2), Last: key(3), HasPrev: true, HasNext: true},
+		},
+		{
+			name:       "After deleted key",
+			rows:       []uuid.UUID{ids[0], ids[2], ids[3], ids[4]},
+			pagination: &Pagination{PerPage: 2, Cursor: &Cursor{After: key(1), PerPage: 2}},
+			expected:   []uuid.UUID{ids[2], ids[3]},
+			window:     &Window{First: key(2), Last: key(3), HasPrev: true, HasNext: true},
+		},
+		{
+			name:       "Before key",
+			rows:       rows,
+			pagination: &Pagination{PerPage: 2, Cursor: &Cursor{Before: key(3), PerPage: 2}},
+			expected:   []uuid.UUID{ids[1], ids[2]},
+			window:     &Window{First: key(1), Last: key(2), HasPrev: true, HasNext: true},
+		},
+		{
+			name:       "After last key",
+			rows:       rows,
+			pagination: &Pagination{PerPage: 2, Cursor: &Cursor{After: key(4), PerPage: 2}},
+			expected:   []uuid.UUID{},
+			window:     &Window{First: key(4), HasPrev: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := PageOf(tt.rows, tt.pagination, id)
+
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.window, tt.pagination.Window)
+		})
+	}
+}
+
+func Test_Pagination_Seek(t *testing.T) {
+	createdAt := time.Date(2025, 3, 15, 16, 3, 20, 123456000, time.UTC)
+	id := uuid.MustParse("10000000-1000-1000-1234-000000000001")
+	key := []string{createdAt.Format(time.RFC3339Nano), id.String()}
+
+	type item struct {
+		id        uuid.UUID
+		createdAt time.Time
+	}
+	itemKey := func(record item) (time.Time, uuid.UUID) {
+		return record.createdAt, record.id
+	}
+	rows := []item{
+		{id: uuid.MustParse("10000000-1000-1000-1234-000000000003"), createdAt: createdAt.Add(2 * time.Second)},
+		{id: uuid.MustParse("10000000-1000-1000-1234-000000000002"), createdAt: createdAt.Add(time.Second)},
+		{id: id, createdAt: createdAt},
+	}
+	encode := func(record item) []string {
+		return []string{record.createdAt.Format(time.RFC3339Nano), record.id.String()}
+	}
+
+	tests := []struct {
+		name       string
+		pagination *Pagination
+		seek       *models.Seek
+		limit      uint64
+		rows       []item
+		expected   []item
+		window     *Window
+		error      error
+	}{
+		{
+			name:       "Page mode",
+			pagination: &Pagination{Page: 1, PerPage: 2},
+			limit:      2,
+			rows:       rows[:2],
+			expected:   rows[:2],
+		},
+		{
+			name:       "First cursor page",
+			pagination: &Pagination{PerPage: 2, Cursor: &Cursor{PerPage: 2}},
+			limit:      3,
+			rows:       rows,
+			expected:   rows[:2],
+			window:     &Window{First: encode(rows[0]), Last: encode(rows[1]), HasNext: true},
+		},
+		{
+			name:       "After key",
+			pagination: &Pagination{PerPage: 2, Cursor: &Cursor{After: key, PerPage: 2}},
+			seek:       &models.Seek{CreatedAt: createdAt, ID: id},
+			limit:      3,
+			rows:       []item{},
+			expected:   []item{},
+			window:     &Window{First: key, HasPrev: true},
+		},
+		{
+			name:       "Before key",
+			pagination: &Pagination{PerPage: 1, Cursor: &Cursor{Before: key, PerPage: 1}},
+			seek:       &models.Seek{CreatedAt: createdAt, ID: id, Reverse: true},
+			limit:      2,
+			rows:       rows[:2],
+			expected:   rows[1:2],
+			window:     &Window{First: encode(rows[1]), Last: encode(rows[1]), HasPrev: true, HasNext: true},
+		},
+		{
+			name:       "Invalid key",
+			pagination: &Pagination{PerPage: 2, Cursor: &Cursor{After: []string{"invalid"}, PerPage: 2}},
+			error:      errors.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seek, err := tt.pagination.Seek()
+			if tt.error != nil {
+				assert.Equal(t, tt.error, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.seek, seek)
+			assert.Equal(t, tt.limit, tt.pagination.SeekLimit())
+
+			result := seekOf(tt.rows, tt.pagination, seek, itemKey)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.window, tt.pagination.Window)
+		})
+	}
+}
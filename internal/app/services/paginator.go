@@ -0,0 +1,178 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+const CursorParam = "cursor"
+
+// Cursor is the signed position of a cursor paginated list request. It holds the sort key
+// of the record the page starts after or ends before, so records created or deleted between
+// requests neither shift nor repeat the following pages
+type Cursor struct {
+	After   []string `json:"a,omitempty"`
+	Before  []string `json:"b,omitempty"`
+	PerPage uint64   `json:"p"`
+	Query   string   `json:"q"`
+}
+
+// Paginator resolves page and cursor pagination and builds cursor links
+type Paginator interface {
+	Paginate(r *http.Request) (*Pagination, error)
+	Next(r *http.Request, pagination *Pagination) string
+	Prev(r *http.Request, pagination *Pagination) string
+}
+
+type paginator struct {
+	secret []byte
+	log    *logger.Logger
+}
+
+func NewPaginator(cfg *config.Config, log *logger.Logger) Paginator {
+	secret := []byte(cfg.CursorSecret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		_, _ = rand.Read(secret)
+		log.Warn().Msg("CURSOR_SECRET is not set, issued cursors will not survive a restart")
+	}
+
+	return &paginator{
+		secret: secret,
+		log:    log,
+	}
+}
+
+// Paginate falls back to page mode unless the cursor param is present.
+// An empty cursor starts cursor mode from the first record.
+func (p *paginator) Paginate(r *http.Request) (*Pagination, error) {
+	params := r.URL.Query()
+	if !params.Has(CursorParam) {
+		return NewPagination(r), nil
+	}
+
+	fingerprint := queryFingerprint(params)
+
+	cursor := &Cursor{
+		PerPage: NewPagination(r).PerPage,
+		Query:   fingerprint,
+	}
+
+	if value := params.Get(CursorParam); value != "" {
+		decoded, err := p.decode(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if decoded.Query != fingerprint || decoded.PerPage < 1 || decoded.PerPage > MaxPerPage || (decoded.After != nil && decoded.Before != nil) {
+			return nil, errors.ErrInvalidCursor
+		}
+
+		cursor = decoded
+	}
+
+	return &Pagination{
+		PerPage: cursor.PerPage,
+		Cursor:  cursor,
+	}, nil
+}
+
+// Next returns the link to the following cursor page, or an empty string when there is none
+func (p *paginator) Next(r *http.Request, pagination *Pagination) string {
+	if pagination.Cursor == nil || pagination.Window == nil || !pagination.Window.HasNext {
+		return ""
+	}
+
+	return p.link(r, &Cursor{
+		After:   pagination.Window.Last,
+		PerPage: pagination.PerPage,
+		Query:   pagination.Cursor.Query,
+	})
+}
+
+// Prev returns the link to the preceding cursor page, or an empty string when there is none
+func (p *paginator) Prev(r *http.Request, pagination *Pagination) string {
+	if pagination.Cursor == nil || pagination.Window == nil || !pagination.Window.HasPrev {
+		return ""
+	}
+
+	return p.link(r, &Cursor{
+		Before:  pagination.Window.First,
+		PerPage: pagination.PerPage,
+		Query:   pagination.Cursor.Query,
+	})
+}
+
+func (p *paginator) link(r *http.Request, cursor *Cursor) string {
+	params := r.URL.Query()
+	params.Del("page")
+	params.Del("per")
+	params.Set(CursorParam, p.encode(cursor))
+
+	return r.URL.Path + "?" + params.Encode()
+}
+
+func (p *paginator) encode(cursor *Cursor) string {
+	payload, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(p.sign(payload))
+}
+
+func (p *paginator) decode(value string) (*Cursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(value, ".")
+	if !ok {
+		return nil, errors.ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, errors.ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, p.sign(payload)) {
+		return nil, errors.ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err = json.Unmarshal(payload, &cursor); err != nil {
+		return nil, errors.ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+func (p *paginator) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+
+	return mac.Sum(nil)
+}
+
+// queryFingerprint binds a cursor to the search, sort and filter params it was issued for
+func queryFingerprint(params url.Values) string {
+	filtered := url.Values{}
+	for key, values := range params {
+		switch key {
+		case CursorParam, "page", "per":
+			continue
+		default:
+			filtered[key] = values
+		}
+	}
+
+	sum := sha256.Sum256([]byte(filtered.Encode()))
+
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func Test_Paginator_Paginate(t *testing.T) {
+	cfg := &config.Config{
+		AppEnv:       "test",
+		LogLevel:     "info",
+		CursorSecret: "secret",
+	}
+	log := logger.NewLogger(cfg)
+	service := NewPaginator(cfg, log).(*paginator)
+	other := NewPaginator(&config.Config{CursorSecret: "other"}, log).(*paginator)
+
+	fingerprint := queryFingerprint(url.Values{"sort": {"name"}})
+	key := []string{"admin", "10000000-1000-1000-3000-000000000001"}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected *Pagination
+		error    error
+	}{
+		{
+			name: "Page mode",
+			path: "/?page=3&per=20",
+			expected: &Pagination{
+				Page:    3,
+				PerPage: 20,
+			},
+		},
+		{
+			name: "Empty cursor",
+			path: "/?cursor=&per=20&sort=name",
+			expected: &Pagination{
+				PerPage: 20,
+				Cursor:  &Cursor{PerPage: 20, Query: fingerprint},
+			},
+		},
+		{
+			name: "Valid cursor",
+			path: "/?sort=name&cursor=" + service.encode(&Cursor{After: key, PerPage: 20, Query: fingerprint}),
+			expected: &Pagination{
+				PerPage: 20,
+				Cursor:  &Cursor{After: key, PerPage: 20, Query: fingerprint},
+			},
+		},
+		{
+			name:  "Cursor issued for another query",
+			path:  "/?sort=-name&cursor=" + service.encode(&Cursor{After: key, PerPage: 20, Query: fingerprint}),
+			error: errors.ErrInvalidCursor,
+		},
+		{
+			name:  "Cursor signed with another secret",
+			path:  "/?sort=name&cursor=" + other.encode(&Cursor{After: key, PerPage: 20, Query: fingerprint}),
+			error: errors.ErrInvalidCursor,
+		},
+		{
+			name:  "Cursor with invalid per page",
+			path:  "/?sort=name&cursor=" + service.encode(&Cursor{After: key, PerPage: 0, Query: fingerprint}),
+			error: errors.ErrInvalidCursor,
+		},
+		{
+			name:  "Cursor with both directions",
+			path:  "/?sort=name&cursor=" + service.encode(&Cursor{After: key, Before: key, PerPage: 20, Query: fingerprint}),
+			error: errors.ErrInvalidCursor,
+		},
+		{
+			name:  "Malformed cursor",
+			path:  "/?cursor=invalid",
+			error: errors.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", tt.path, nil)
+			pagination, err := service.Paginate(request)
+
+			if tt.error != nil {
+				assert.Equal(t, tt.error, err)
+				assert.Nil(t, pagination)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, pagination)
+			}
+		})
+	}
+}
+
+func Test_Paginator_Links(t *testing.T) {
+	cfg := &config.Config{
+		AppEnv:       "test",
+		LogLevel:     "info",
+		CursorSecret: "secret",
+	}
+	log := logger.NewLogger(cfg)
+	service := NewPaginator(cfg, log).(*paginator)
+
+	fingerprint := queryFingerprint(url.Values{})
+	first := []string{"10000000-1000-1000-6000-000000000011"}
+	last := []string{"10000000-1000-1000-6000-000000000020"}
+
+	tests := []struct {
+		name       string
+		pagination *Pagination
+		next       string
+		prev       string
+	}{
+		{
+			name:       "Page mode",
+			pagination: &Pagination{Page: 2, PerPage: 10},
+		},
+		{
+			name: "First cursor page",
+			pagination: &Pagination{
+				PerPage: 10,
+				Cursor:  &Cursor{PerPage: 10, Query: fingerprint},
+				Window:  &Window{First: first, Last: last, HasNext: true},
+			},
+			next: "/tokens?cursor=" + service.encode(&Cursor{After: last, PerPage: 10, Query: fingerprint}),
+		},
+		{
+			name: "Middle cursor page",
+			pagination: &Pagination{
+				PerPage: 10,
+				Cursor:  &Cursor{After: first, PerPage: 10, Query: fingerprint},
+				Window:  &Window{First: first, Last: last, HasPrev: true, HasNext: true},
+			},
+			next: "/tokens?cursor=" + service.encode(&Cursor{After: last, PerPage: 10, Query: fingerprint}),
+			prev: "/tokens?cursor=" + service.encode(&Cursor{Before: first, PerPage: 10, Query: fingerprint}),
+		},
+		{
+			name: "Last cursor page",
+			pagination: &Pagination{
+				PerPage: 10,
+				Cursor:  &Cursor{After: first, PerPage: 10, Query: fingerprint},
+				Window:  &Window{First: first, Last: last, HasPrev: true},
+			},
+			prev: "/tokens?cursor=" + service.encode(&Cursor{Before: first, PerPage: 10, Query: fingerprint}),
+		},
+		{
+			name: "Without window",
+			pagination: &Pagination{
+				PerPage: 10,
+				Cursor:  &Cursor{PerPage: 10, Query: fingerprint},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/tokens?per=10", nil)
+
+			assert.Equal(t, tt.next, service.Next(request, tt.pagination))
+			assert.Equal(t, tt.prev, service.Prev(request, tt.pagination))
+		})
+	}
+}
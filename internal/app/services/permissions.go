@@ -4,8 +4,6 @@ import (
 	"context"
 
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
 	"loki-backoffice/internal/app/errors"
 	"loki-backoffice/internal/app/models"
@@ -14,13 +12,21 @@ import (
 )
 
 type Permissions interface {
-	List(ctx context.Context, pagination *Pagination) ([]models.Permission, uint64, error)
+	List(ctx context.Context, pagination *Pagination, query *Query) ([]models.Permission, uint64, error)
 	FindById(ctx context.Context, id uuid.UUID) (*models.Permission, error)
 	Create(ctx context.Context, params *models.Permission) (*models.Permission, error)
 	Update(ctx context.Context, params *models.Permission) (*models.Permission, error)
 	Delete(ctx context.Context, id uuid.UUID) (bool, error)
 }
 
+var PermissionsQuerySchema = &QuerySchema{
+	Search: []string{"name", "description"},
+	Sort:   []string{"name", "description"},
+	Filters: map[string]QueryFilter{
+		"name": AnyValue,
+	},
+}
+
 type permissions struct {
 	client proto.PermissionServiceClient
 	log    *logger.Logger
@@ -33,28 +39,20 @@ func NewPermissions(client proto.PermissionServiceClient, log *logger.Logger) Pe
 	}
 }
 
+func (p *permissions) List(ctx context.Context, pagination *Pagination, query *Query) ([]models.Permission, uint64, error) {
+	return listWithQuery(ctx, pagination, query, PermissionsQuerySchema, p.fetch, permissionField)
+}
+
 //nolint:dupl
-func (p *permissions) List(ctx context.Context, pagination *Pagination) ([]models.Permission, uint64, error) {
+func (p *permissions) fetch(ctx context.Context, pagination *Pagination) ([]models.Permission, uint64, error) {
 	response, err := p.client.List(ctx, &proto.PaginatedListRequest{
-		Limit:  pagination.Page,
-		Offset: pagination.PerPage,
+		Limit:  pagination.Limit(),
+		Offset: pagination.Offset(),
 	})
 	if err != nil {
 		p.log.Error().Err(err).Msg("Failed to fetch permissions")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, 0, errors.ErrInvalidArguments
-			case codes.Unavailable:
-				return nil, 0, errors.ErrFailedToFetchResults
-			case codes.Internal:
-				return nil, 0, errors.ErrFailedToFetchResults
-			}
-		}
-
-		return nil, 0, err
+		return nil, 0, errors.FromGRPC(err, errors.ErrFailedToFetchResults)
 	}
 
 	collection := make([]models.Permission, 0, len(response.Data))
@@ -77,19 +75,7 @@ func (p *permissions) FindById(ctx context.Context, id uuid.UUID) (*models.Permi
 	if err != nil {
 		p.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get permission")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return nil, errors.ErrRecordNotFound
-			case codes.Internal:
-				return nil, errors.ErrFailedToFetchResults
-			}
-		}
-
-		return nil, err
+		return nil, errors.FromGRPC(err, errors.ErrFailedToFetchResults)
 	}
 
 	return &models.Permission{
@@ -108,17 +94,7 @@ func (p *permissions) Create(ctx context.Context, params *models.Permission) (*m
 	if err != nil {
 		p.log.Error().Err(err).Str("name", params.Name).Msg("Failed to create permission")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, errors.ErrInvalidArguments
-			case codes.Internal:
-				return nil, errors.ErrFailedToCreateRecord
-			}
-		}
-
-		return nil, err
+		return nil, errors.FromGRPC(err, errors.ErrFailedToCreateRecord)
 	}
 
 	return &models.Permission{
@@ -138,19 +114,7 @@ func (p *permissions) Update(ctx context.Context, params *models.Permission) (*m
 	if err != nil {
 		p.log.Error().Err(err).Str("id", params.ID.String()).Msg("Failed to update permission")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return nil, errors.ErrRecordNotFound
-			case codes.Internal:
-				return nil, errors.ErrFailedToUpdateRecord
-			}
-		}
-
-		return nil, err
+		return nil, errors.FromGRPC(err, errors.ErrFailedToUpdateRecord)
 	}
 
 	return &models.Permission{
@@ -168,20 +132,21 @@ func (p *permissions) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
 	if err != nil {
 		p.log.Error().Err(err).Str("id", id.String()).Msg("Failed to delete permission")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return false, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return false, errors.ErrRecordNotFound
-			case codes.Internal:
-				return false, errors.ErrFailedToDeleteRecord
-			}
-		}
-
-		return false, err
+		return false, errors.FromGRPC(err, errors.ErrFailedToDeleteRecord)
 	}
 
 	return true, nil
 }
+
+func permissionField(permission models.Permission, name string) string {
+	switch name {
+	case "id":
+		return permission.ID.String()
+	case "name":
+		return permission.Name
+	case "description":
+		return permission.Description
+	default:
+		return ""
+	}
+}
@@ -45,8 +45,8 @@ func Test_Permissions_List(t *testing.T) {
 			name: "Success",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(&proto.ListPermissionsResponse{
 					Data: []*proto.Permission{
 						{
@@ -86,8 +86,8 @@ func Test_Permissions_List(t *testing.T) {
 			name: "Empty",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(&proto.ListPermissionsResponse{
 					Data: []*proto.Permission{},
 					Meta: &proto.PaginationMeta{
@@ -105,8 +105,8 @@ func Test_Permissions_List(t *testing.T) {
 			name: "InvalidArgument status code",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, status.Error(codes.InvalidArgument, "invalid arguments"))
 			},
 			expected: nil,
@@ -117,20 +117,20 @@ func Test_Permissions_List(t *testing.T) {
 			name: "Unavailable status code",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, status.Error(codes.Unavailable, "service unavailable"))
 			},
 			expected: nil,
 			total:    0,
-			error:    errors.ErrFailedToFetchResults,
+			error:    errors.ErrUnavailable,
 		},
 		{
 			name: "Internal status code",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, status.Error(codes.Internal, "internal error"))
 			},
 			expected: nil,
@@ -141,8 +141,8 @@ func Test_Permissions_List(t *testing.T) {
 			name: "Error",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, assert.AnError)
 			},
 			expected: nil,
@@ -158,7 +158,7 @@ func Test_Permissions_List(t *testing.T) {
 			result, total, err := service.List(ctx, &Pagination{
 				Page:    uint64(1),
 				PerPage: uint64(10),
-			})
+			}, nil)
 
 			if tt.error != nil {
 				assert.Error(t, err)
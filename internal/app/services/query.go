@@ -0,0 +1,339 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+)
+
+const (
+	SearchParam = "q"
+	SortParam   = "sort"
+
+	sortableTimeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+	// MaxQueryRecords bounds the upstream collections walked to answer a query or a cursor,
+	// every such request scans the whole collection since the SSO service only pages by offset
+	MaxQueryRecords = 10000
+)
+
+// QueryFilter validates a raw filter value and returns its normalized form
+type QueryFilter func(value string) (string, bool)
+
+// QuerySchema describes which fields of a resource can be searched, sorted and filtered
+type QuerySchema struct {
+	Search  []string
+	Sort    []string
+	Filters map[string]QueryFilter
+}
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// Query holds the free text search, sort order and field filters of a list request
+type Query struct {
+	Search  string
+	Sort    []SortField
+	Filters map[string]string
+}
+
+// NewQuery builds a query from the q, sort and filter query parameters allowed by the schema
+func NewQuery(r *http.Request, schema *QuerySchema) (*Query, error) {
+	params := r.URL.Query()
+
+	query := &Query{
+		Search:  strings.TrimSpace(params.Get(SearchParam)),
+		Sort:    make([]SortField, 0),
+		Filters: make(map[string]string),
+	}
+
+	if sort := strings.TrimSpace(params.Get(SortParam)); sort != "" {
+		for _, item := range strings.Split(sort, ",") {
+			field := SortField{Field: strings.TrimSpace(item)}
+			if strings.HasPrefix(field.Field, "-") {
+				field.Field = strings.TrimPrefix(field.Field, "-")
+				field.Desc = true
+			}
+
+			if !slices.Contains(schema.Sort, field.Field) {
+				return nil, errors.ErrInvalidArguments
+			}
+
+			query.Sort = append(query.Sort, field)
+		}
+	}
+
+	for name, filter := range schema.Filters {
+		if !params.Has(name) {
+			continue
+		}
+
+		value, ok := filter(strings.TrimSpace(params.Get(name)))
+		if !ok {
+			return nil, errors.ErrInvalidArguments
+		}
+
+		query.Filters[name] = value
+	}
+
+	return query, nil
+}
+
+// IsEmpty reports whether the query has nothing to apply
+func (q *Query) IsEmpty() bool {
+	return q == nil || (q.Search == "" && len(q.Sort) == 0 && len(q.Filters) == 0)
+}
+
+// AnyValue accepts any non-empty filter value
+func AnyValue(value string) (string, bool) {
+	return value, value != ""
+}
+
+// UUIDValue accepts a valid UUID filter value
+func UUIDValue(value string) (string, bool) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return "", false
+	}
+
+	return id.String(), true
+}
+
+// BoolValue accepts a boolean filter value
+func BoolValue(value string) (string, bool) {
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", false
+	}
+
+	return strconv.FormatBool(result), true
+}
+
+// OneOfValue accepts one of the given filter values
+func OneOfValue(values ...string) QueryFilter {
+	return func(value string) (string, bool) {
+		return value, slices.Contains(values, value)
+	}
+}
+
+// listWithQuery passes the pagination straight to the upstream when the query is empty.
+// The SSO PaginatedListRequest only supports limit and offset, so otherwise every
+// upstream page is walked and the query is applied before paginating locally.
+// Cursor mode always walks, the cursor key can only be located in the whole collection,
+// so each cursor page costs a full scan and collections above MaxQueryRecords are refused.
+func listWithQuery[T any](
+	ctx context.Context,
+	pagination *Pagination,
+	query *Query,
+	schema *QuerySchema,
+	fetch func(ctx context.Context, pagination *Pagination) ([]T, uint64, error),
+	field func(item T, name string) string,
+) ([]T, uint64, error) {
+	if query.IsEmpty() && pagination.Cursor == nil {
+		return fetch(ctx, pagination)
+	}
+
+	if query == nil {
+		query = &Query{}
+	}
+
+	matched, err := collectWithQuery(ctx, query, schema, capped(fetch, MaxQueryRecords), field)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	key, compare := cursorOrder(query, field)
+
+	return pageOf(matched, pagination, key, compare), uint64(len(matched)), nil
+}
+
+// cursorOrder orders records by the query sort fields and then by id, the id keeps
+// the order total so that a cursor key always falls between two records
+func cursorOrder[T any](query *Query, field func(item T, name string) string) (func(item T) []string, func(a, b []string) int) {
+	key := func(item T) []string {
+		values := make([]string, 0, len(query.Sort)+1)
+		for _, sort := range query.Sort {
+			values = append(values, strings.ToLower(field(item, sort.Field)))
+		}
+
+		return append(values, field(item, "id"))
+	}
+
+	compare := func(a, b []string) int {
+		if len(a) != len(b) {
+			return len(a) - len(b)
+		}
+
+		for i := range a {
+			result := strings.Compare(a[i], b[i])
+			if i < len(query.Sort) && query.Sort[i].Desc {
+				result = -result
+			}
+
+			if result != 0 {
+				return result
+			}
+		}
+
+		return 0
+	}
+
+	return key, compare
+}
+
+// collectWithQuery walks every upstream page and returns the records matching the query in sort order
+func collectWithQuery[T any](
+	ctx context.Context,
+	query *Query,
+	schema *QuerySchema,
+	fetch func(ctx context.Context, pagination *Pagination) ([]T, uint64, error),
+	field func(item T, name string) string,
+) ([]T, error) {
+	rows, err := walk(ctx, fetch)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]T, 0, len(rows))
+	for _, item := range rows {
+		if matchesQuery(item, query, schema, field) {
+			matched = append(matched, item)
+		}
+	}
+
+	if len(query.Sort) > 0 {
+		slices.SortStableFunc(matched, func(a, b T) int {
+			for _, sort := range query.Sort {
+				result := strings.Compare(strings.ToLower(field(a, sort.Field)), strings.ToLower(field(b, sort.Field)))
+				if sort.Desc {
+					result = -result
+				}
+
+				if result != 0 {
+					return result
+				}
+			}
+
+			return 0
+		})
+	}
+
+	return matched, nil
+}
+
+// capped refuses collections larger than limit on their first page, before the rest is fetched
+func capped[T any](fetch func(ctx context.Context, pagination *Pagination) ([]T, uint64, error), limit uint64) func(ctx context.Context, pagination *Pagination) ([]T, uint64, error) {
+	return func(ctx context.Context, pagination *Pagination) ([]T, uint64, error) {
+		rows, total, err := fetch(ctx, pagination)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if total > limit {
+			return nil, 0, fmt.Errorf("%w: %d records, at most %d", errors.ErrQueryTooBroad, total, limit)
+		}
+
+		return rows, total, nil
+	}
+}
+
+// walk pages through a whole upstream collection
+func walk[T any](ctx context.Context, fetch func(ctx context.Context, pagination *Pagination) ([]T, uint64, error)) ([]T, error) {
+	rows := make([]T, 0)
+
+	for page := DefaultPage; ; page++ {
+		batch, total, err := fetch(ctx, &Pagination{Page: page, PerPage: MaxPerPage})
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, batch...)
+
+		if len(batch) == 0 || uint64(len(rows)) >= total {
+			return rows, nil
+		}
+	}
+}
+
+// stream pages through a whole upstream collection and emits the records matching the query page by page.
+// Sorting needs every record, so a sorted query is collected first and then emitted in pages.
+func stream[T any](
+	ctx context.Context,
+	query *Query,
+	schema *QuerySchema,
+	fetch func(ctx context.Context, pagination *Pagination) ([]T, uint64, error),
+	field func(item T, name string) string,
+	emit func(batch []T) error,
+) error {
+	if !query.IsEmpty() && len(query.Sort) > 0 {
+		matched, err := collectWithQuery(ctx, query, schema, fetch, field)
+		if err != nil {
+			return err
+		}
+
+		for start := 0; start < len(matched); start += int(MaxPerPage) {
+			if err := emit(matched[start:min(start+int(MaxPerPage), len(matched))]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	var seen uint64
+	for page := DefaultPage; ; page++ {
+		batch, total, err := fetch(ctx, &Pagination{Page: page, PerPage: MaxPerPage})
+		if err != nil {
+			return err
+		}
+
+		seen += uint64(len(batch))
+
+		matched := batch
+		if !query.IsEmpty() {
+			matched = slices.DeleteFunc(slices.Clone(batch), func(item T) bool {
+				return !matchesQuery(item, query, schema, field)
+			})
+		}
+
+		if len(matched) > 0 {
+			if err := emit(matched); err != nil {
+				return err
+			}
+		}
+
+		if len(batch) == 0 || seen >= total {
+			return nil
+		}
+	}
+}
+
+func matchesQuery[T any](item T, query *Query, schema *QuerySchema, field func(item T, name string) string) bool {
+	for name, value := range query.Filters {
+		if !strings.EqualFold(field(item, name), value) {
+			return false
+		}
+	}
+
+	if query.Search == "" {
+		return true
+	}
+
+	search := strings.ToLower(query.Search)
+	for _, name := range schema.Search {
+		if strings.Contains(strings.ToLower(field(item, name)), search) {
+			return true
+		}
+	}
+
+	return false
+}
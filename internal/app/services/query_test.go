@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+)
+
+func Test_NewQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		schema   *QuerySchema
+		expected *Query
+		error    error
+	}{
+		{
+			name:   "Empty params",
+			path:   "/",
+			schema: UsersQuerySchema,
+			expected: &Query{
+				Sort:    []SortField{},
+				Filters: map[string]string{},
+			},
+		},
+		{
+			name:   "Search, sort and filters",
+			path:   "/?q=test&sort=last_name,-first_name&identity_number=PNOEE-60001017869&page=2",
+			schema: UsersQuerySchema,
+			expected: &Query{
+				Search: "test",
+				Sort: []SortField{
+					{Field: "last_name"},
+					{Field: "first_name", Desc: true},
+				},
+				Filters: map[string]string{
+					"identity_number": "PNOEE-60001017869",
+				},
+			},
+		},
+		{
+			name:   "Token filters",
+			path:   "/?user_id=10000000-1000-1000-1234-000000000001&type=access_token&expired=1",
+			schema: TokensQuerySchema,
+			expected: &Query{
+				Sort: []SortField{},
+				Filters: map[string]string{
+					"user_id": "10000000-1000-1000-1234-000000000001",
+					"type":    models.AccessTokenType,
+					"expired": "true",
+				},
+			},
+		},
+		{
+			name:   "Unknown sort field",
+			path:   "/?sort=-value",
+			schema: TokensQuerySchema,
+			error:  errors.ErrInvalidArguments,
+		},
+		{
+			name:   "Invalid user_id",
+			path:   "/?user_id=invalid",
+			schema: TokensQuerySchema,
+			error:  errors.ErrInvalidArguments,
+		},
+		{
+			name:   "Invalid type",
+			path:   "/?type=id_token",
+			schema: TokensQuerySchema,
+			error:  errors.ErrInvalidArguments,
+		},
+		{
+			name:   "Invalid expired",
+			path:   "/?expired=maybe",
+			schema: TokensQuerySchema,
+			error:  errors.ErrInvalidArguments,
+		},
+		{
+			name:   "Empty filter",
+			path:   "/?name=",
+			schema: RolesQuerySchema,
+			error:  errors.ErrInvalidArguments,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", tt.path, nil)
+			query, err := NewQuery(request, tt.schema)
+
+			if tt.error != nil {
+				assert.Equal(t, tt.error, err)
+				assert.Nil(t, query)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, query)
+			}
+		})
+	}
+}
+
+func Test_listWithQuery(t *testing.T) {
+	ctx := context.Background()
+
+	upstream := []models.Token{
+		{
+			ID:        uuid.MustParse("10000000-1000-1000-6000-000000000001"),
+			UserId:    uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+			Type:      models.AccessTokenType,
+			ExpiresAt: time.Now().Add(-time.Hour),
+		},
+		{
+			ID:        uuid.MustParse("10000000-1000-1000-6000-000000000002"),
+			UserId:    uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+			Type:      models.RefreshTokenType,
+			ExpiresAt: time.Now().Add(models.RefreshTokenExp),
+		},
+		{
+			ID:        uuid.MustParse("10000000-1000-1000-6000-000000000003"),
+			UserId:    uuid.MustParse("10000000-1000-1000-1234-000000000002"),
+			Type:      models.AccessTokenType,
+			ExpiresAt: time.Now().Add(models.AccessTokenExp),
+		},
+	}
+
+	tests := []struct {
+		name       string
+		pagination *Pagination
+		query      *Query
+		expected   []uuid.UUID
+		total      uint64
+		calls      int
+	}{
+		{
+			name:       "Empty query is passed through",
+			pagination: &Pagination{Page: 1, PerPage: 10},
+			query:      &Query{},
+			expected: []uuid.UUID{
+				uuid.MustParse("10000000-1000-1000-6000-000000000001"),
+			},
+			total: 3,
+			calls: 1,
+		},
+		{
+			name:       "Filters",
+			pagination: &Pagination{Page: 1, PerPage: 10},
+			query: &Query{
+				Filters: map[string]string{
+					"type":    models.AccessTokenType,
+					"expired": "false",
+				},
+			},
+			expected: []uuid.UUID{
+				uuid.MustParse("10000000-1000-1000-6000-000000000003"),
+			},
+			total: 1,
+			calls: 3,
+		},
+		{
+			name:       "Search and sort",
+			pagination: &Pagination{Page: 1, PerPage: 10},
+			query: &Query{
+				Search: "1234-000000000001",
+				Sort:   []SortField{{Field: "expires_at", Desc: true}},
+			},
+			expected: []uuid.UUID{
+				uuid.MustParse("10000000-1000-1000-6000-000000000002"),
+				uuid.MustParse("10000000-1000-1000-6000-000000000001"),
+			},
+			total: 2,
+			calls: 3,
+		},
+		{
+			name:       "Paginates locally",
+			pagination: &Pagination{Page: 2, PerPage: 1},
+			query: &Query{
+				Sort: []SortField{{Field: "type"}, {Field: "user_id", Desc: true}},
+			},
+			expected: []uuid.UUID{
+				uuid.MustParse("10000000-1000-1000-6000-000000000001"),
+			},
+			total: 3,
+			calls: 3,
+		},
+		{
+			name: "Cursor walks the collection",
+			pagination: &Pagination{
+				PerPage: 2,
+				Cursor:  &Cursor{PerPage: 2},
+			},
+			query: &Query{},
+			expected: []uuid.UUID{
+				uuid.MustParse("10000000-1000-1000-6000-000000000001"),
+				uuid.MustParse("10000000-1000-1000-6000-000000000002"),
+			},
+			total: 3,
+			calls: 3,
+		},
+		{
+			name: "Cursor resumes after the sort key",
+			pagination: &Pagination{
+				PerPage: 2,
+				Cursor: &Cursor{
+					After:   []string{strings.ToLower(models.AccessTokenType), "10000000-1000-1000-6000-000000000001"},
+					PerPage: 2,
+				},
+			},
+			query: &Query{
+				Sort: []SortField{{Field: "type", Desc: true}},
+			},
+			expected: []uuid.UUID{
+				uuid.MustParse("10000000-1000-1000-6000-000000000003"),
+			},
+			total: 3,
+			calls: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			fetch := func(_ context.Context, pagination *Pagination) ([]models.Token, uint64, error) {
+				calls++
+				if pagination.Page > uint64(len(upstream)) {
+					return []models.Token{}, uint64(len(upstream)), nil
+				}
+				return upstream[pagination.Page-1 : pagination.Page], uint64(len(upstream)), nil
+			}
+
+			result, total, err := listWithQuery(ctx, tt.pagination, tt.query, TokensQuerySchema, fetch, tokenField)
+			assert.NoError(t, err)
+
+			ids := make([]uuid.UUID, 0, len(result))
+			for _, item := range result {
+				ids = append(ids, item.ID)
+			}
+
+			assert.Equal(t, tt.expected, ids)
+			assert.Equal(t, tt.total, total)
+			assert.Equal(t, tt.calls, calls)
+		})
+	}
+}
+
+func Test_listWithQuery_Error(t *testing.T) {
+	fetch := func(_ context.Context, _ *Pagination) ([]models.Token, uint64, error) {
+		return nil, 0, errors.ErrFailedToFetchResults
+	}
+
+	result, total, err := listWithQuery(
+		context.Background(),
+		&Pagination{Page: 1, PerPage: 10},
+		&Query{Search: "access"},
+		TokensQuerySchema,
+		fetch,
+		tokenField,
+	)
+
+	assert.Equal(t, errors.ErrFailedToFetchResults, err)
+	assert.Nil(t, result)
+	assert.Zero(t, total)
+}
+
+func Test_listWithQuery_TooBroad(t *testing.T) {
+	calls := 0
+	fetch := func(_ context.Context, _ *Pagination) ([]models.Token, uint64, error) {
+		calls++
+		return make([]models.Token, MaxPerPage), MaxQueryRecords + 1, nil
+	}
+
+	result, total, err := listWithQuery(
+		context.Background(),
+		&Pagination{PerPage: 10, Cursor: &Cursor{PerPage: 10}},
+		&Query{},
+		TokensQuerySchema,
+		fetch,
+		tokenField,
+	)
+
+	assert.ErrorIs(t, err, errors.ErrQueryTooBroad)
+	assert.Nil(t, result)
+	assert.Zero(t, total)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,571 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/config/logger"
+)
+
+const (
+	PruneParam = "prune"
+
+	DescriptionField = "description"
+	PermissionsField = "permissions"
+)
+
+// RBACOptions controls the deletes of a plan
+type RBACOptions struct {
+	// Prune deletes the records missing from the sections of the configuration
+	Prune bool
+	// Force deletes records that roles or users still depend on
+	Force bool
+}
+
+func NewRBACOptions(r *http.Request) (*RBACOptions, error) {
+	prune, err := parseBoolParam(r, PruneParam)
+	if err != nil {
+		return nil, err
+	}
+
+	force, err := parseBoolParam(r, ForceParam)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RBACOptions{Prune: prune, Force: force}, nil
+}
+
+// RBAC plans and applies declarative configurations of permissions, roles and scopes,
+// resources are matched by name and the ones missing from a section are deleted when pruning
+type RBAC interface {
+	Plan(ctx context.Context, config *models.RBACConfig, options *RBACOptions) (*models.RBACPlan, error)
+	Apply(ctx context.Context, config *models.RBACConfig, options *RBACOptions) (*models.RBACPlan, error)
+}
+
+type rbac struct {
+	permissions Permissions
+	roles       Roles
+	scopes      Scopes
+	audit       Audit
+	index       Index
+	impact      ImpactAnalyzer
+	log         *logger.Logger
+}
+
+// rbacRecord is a live permission, role or scope in the shape of the configuration
+type rbacRecord struct {
+	id            uuid.UUID
+	resource      models.RBACResource
+	permissionIds []uuid.UUID
+}
+
+type rbacState struct {
+	permissions map[string]rbacRecord
+	roles       map[string]rbacRecord
+	scopes      map[string]rbacRecord
+}
+
+func NewRBAC(permissions Permissions, roles Roles, scopes Scopes, audit Audit, index Index, impact ImpactAnalyzer, log *logger.Logger) RBAC {
+	return &rbac{
+		permissions: permissions,
+		roles:       roles,
+		scopes:      scopes,
+		audit:       audit,
+		index:       index,
+		impact:      impact,
+		log:         log.WithComponent("rbac"),
+	}
+}
+
+// Plan diffs the configuration against the live state, creates and updates of permissions and scopes
+// come first so that roles can refer to them, roles are deleted before the permissions they refer to
+func (s *rbac) Plan(ctx context.Context, config *models.RBACConfig, options *RBACOptions) (*models.RBACPlan, error) {
+	plan, _, err := s.plan(ctx, config, options)
+	return plan, err
+}
+
+// Apply recomputes the plan and applies it in order, the first failure skips the remaining changes.
+// Nothing is applied while a planned delete is blocked by dependents
+func (s *rbac) Apply(ctx context.Context, config *models.RBACConfig, options *RBACOptions) (*models.RBACPlan, error) {
+	plan, state, err := s.plan(ctx, config, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.ContainsFunc(plan.Changes, func(change models.RBACChange) bool { return change.Err != nil }) {
+		for i := range plan.Changes {
+			change := &plan.Changes[i]
+			if change.Err != nil {
+				change.State = BulkFailedState
+			} else {
+				change.State = BulkSkippedState
+			}
+		}
+
+		s.log.Warn().Int("changes", len(plan.Changes)).Msg("Refused to apply RBAC configuration with blocked deletes")
+		return plan, nil
+	}
+
+	permissionIds := make(map[string]uuid.UUID, len(state.permissions))
+	for name, record := range state.permissions {
+		permissionIds[name] = record.id
+	}
+
+	applied, failed := 0, false
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+
+		if failed {
+			change.State = BulkSkippedState
+			continue
+		}
+
+		if err = s.apply(ctx, change, state, permissionIds); err != nil {
+			s.log.Error().Err(err).Str("resource_type", change.ResourceType).Str("name", change.Name).Msgf("Failed to %s", change.Action)
+
+			change.State = BulkFailedState
+			change.Err = err
+			failed = true
+			continue
+		}
+
+		change.State = BulkAppliedState
+		applied++
+	}
+
+	if applied > 0 {
+		s.index.Invalidate()
+	}
+
+	s.log.Info().Int("changes", len(plan.Changes)).Int("applied", applied).Msg("Applied RBAC configuration")
+
+	return plan, nil
+}
+
+func (s *rbac) plan(ctx context.Context, config *models.RBACConfig, options *RBACOptions) (*models.RBACPlan, *rbacState, error) {
+	state, err := s.load(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = unknownPermissions(config, state); err != nil {
+		return nil, nil, err
+	}
+
+	changes := make([]models.RBACChange, 0)
+	changes = append(changes, upsertChanges(models.PermissionResourceType, config.Permissions, state.permissions)...)
+	changes = append(changes, upsertChanges(models.ScopeResourceType, config.Scopes, state.scopes)...)
+	changes = append(changes, upsertChanges(models.RoleResourceType, config.Roles, state.roles)...)
+
+	if options.Prune {
+		changes = append(changes, deleteChanges(models.RoleResourceType, config.Roles, state.roles)...)
+		changes = append(changes, deleteChanges(models.PermissionResourceType, config.Permissions, state.permissions)...)
+		changes = append(changes, deleteChanges(models.ScopeResourceType, config.Scopes, state.scopes)...)
+
+		if err = s.dependents(ctx, changes, options.Force); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return &models.RBACPlan{Changes: changes}, state, nil
+}
+
+// dependents runs the planned deletes through the impact analysis, roles that the plan deletes or
+// takes a permission away from no longer depend on it. Without force a delete with dependents is blocked
+func (s *rbac) dependents(ctx context.Context, changes []models.RBACChange, force bool) error {
+	roleChanges := make(map[uuid.UUID]*models.RBACChange)
+	for i := range changes {
+		if changes[i].ResourceType == models.RoleResourceType && changes[i].ID != uuid.Nil {
+			roleChanges[changes[i].ID] = &changes[i]
+		}
+	}
+
+	for i := range changes {
+		change := &changes[i]
+		if change.Action != models.DeleteActionType {
+			continue
+		}
+
+		var impact *models.Impact
+		var err error
+
+		switch change.ResourceType {
+		case models.PermissionResourceType:
+			impact, err = s.permissionImpact(ctx, change, roleChanges)
+		case models.RoleResourceType:
+			impact, err = s.impact.Role(ctx, change.ID)
+		case models.ScopeResourceType:
+			impact, err = s.impact.Scope(ctx, change.ID)
+		}
+		if err != nil {
+			s.log.Error().Err(err).Str("resource_type", change.ResourceType).Str("name", change.Name).Msg("Failed to analyze delete impact")
+			return err
+		}
+
+		if impact.IsEmpty() {
+			continue
+		}
+
+		change.Dependents = impact
+		if !force {
+			change.Err = DependentsError(impact)
+		}
+	}
+
+	return nil
+}
+
+func (s *rbac) permissionImpact(ctx context.Context, change *models.RBACChange, roleChanges map[uuid.UUID]*models.RBACChange) (*models.Impact, error) {
+	impact, err := s.impact.Permission(ctx, change.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]models.Role, 0, len(impact.Roles))
+	for _, role := range impact.Roles {
+		roleChange, ok := roleChanges[role.ID]
+		if ok && (roleChange.Action == models.DeleteActionType || !slices.Contains(roleChange.After.Permissions, change.Name)) {
+			continue
+		}
+
+		roles = append(roles, role)
+	}
+
+	if len(roles) == len(impact.Roles) {
+		return impact, nil
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	users := make([]models.User, 0)
+	for _, role := range roles {
+		holders, err := s.impact.Role(ctx, role.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range holders.Users {
+			if !seen[user.ID] {
+				seen[user.ID] = true
+				users = append(users, user)
+			}
+		}
+	}
+
+	impact.Roles = roles
+	impact.Users = users
+
+	return impact, nil
+}
+
+func (s *rbac) apply(ctx context.Context, change *models.RBACChange, state *rbacState, permissionIds map[string]uuid.UUID) error {
+	before := state.record(change.ResourceType, change.Name)
+
+	if change.Action == models.DeleteActionType {
+		if err := s.remove(ctx, change); err != nil {
+			return err
+		}
+
+		RecordApplied(ctx, s.audit, s.log, models.DeleteActionType, change.ResourceType, change.ID, rbacSnapshot(change.ResourceType, before), nil)
+		return nil
+	}
+
+	after, err := s.save(ctx, change, permissionIds)
+	if err != nil {
+		return err
+	}
+
+	change.ID = after.id
+	RecordApplied(ctx, s.audit, s.log, change.Action, change.ResourceType, after.id, rbacSnapshot(change.ResourceType, before), rbacSnapshot(change.ResourceType, after))
+
+	return nil
+}
+
+func (s *rbac) remove(ctx context.Context, change *models.RBACChange) error {
+	var err error
+
+	switch change.ResourceType {
+	case models.PermissionResourceType:
+		_, err = s.permissions.Delete(ctx, change.ID)
+	case models.RoleResourceType:
+		_, err = s.roles.Delete(ctx, change.ID)
+	case models.ScopeResourceType:
+		_, err = s.scopes.Delete(ctx, change.ID)
+	}
+
+	return err
+}
+
+// save creates or updates the resource, roles get the ids of permissions created earlier in the plan
+func (s *rbac) save(ctx context.Context, change *models.RBACChange, permissionIds map[string]uuid.UUID) (*rbacRecord, error) {
+	after := &rbacRecord{id: change.ID, resource: *change.After}
+
+	switch change.ResourceType {
+	case models.PermissionResourceType:
+		record, err := upsert(ctx, change.Action, &models.Permission{ID: change.ID, Name: change.Name, Description: change.After.Description}, s.permissions.Create, s.permissions.Update)
+		if err != nil {
+			return nil, err
+		}
+
+		after.id = record.ID
+		permissionIds[change.Name] = record.ID
+	case models.RoleResourceType:
+		for _, name := range change.After.Permissions {
+			after.permissionIds = append(after.permissionIds, permissionIds[name])
+		}
+
+		record, err := upsert(ctx, change.Action, &models.Role{ID: change.ID, Name: change.Name, Description: change.After.Description, PermissionIDs: after.permissionIds}, s.roles.Create, s.roles.Update)
+		if err != nil {
+			return nil, err
+		}
+
+		after.id = record.ID
+	case models.ScopeResourceType:
+		record, err := upsert(ctx, change.Action, &models.Scope{ID: change.ID, Name: change.Name, Description: change.After.Description}, s.scopes.Create, s.scopes.Update)
+		if err != nil {
+			return nil, err
+		}
+
+		after.id = record.ID
+	}
+
+	return after, nil
+}
+
+// load reads the live permissions, roles and scopes, roles are loaded individually for their permissions
+func (s *rbac) load(ctx context.Context) (*rbacState, error) {
+	permissions, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Permission, uint64, error) {
+		return s.permissions.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Scope, uint64, error) {
+		return s.scopes.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Role, uint64, error) {
+		return s.roles.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	roles, err := fanOut(ctx, ids, s.roles.FindById)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &rbacState{
+		permissions: make(map[string]rbacRecord, len(permissions)),
+		roles:       make(map[string]rbacRecord, len(roles)),
+		scopes:      make(map[string]rbacRecord, len(scopes)),
+	}
+
+	names := make(map[uuid.UUID]string, len(permissions))
+	for _, item := range permissions {
+		names[item.ID] = item.Name
+		state.permissions[item.Name] = rbacRecord{id: item.ID, resource: models.RBACResource{Name: item.Name, Description: item.Description}}
+	}
+
+	for _, item := range scopes {
+		state.scopes[item.Name] = rbacRecord{id: item.ID, resource: models.RBACResource{Name: item.Name, Description: item.Description}}
+	}
+
+	for _, item := range roles {
+		permissionNames := make([]string, 0, len(item.PermissionIDs))
+		for _, id := range item.PermissionIDs {
+			// a dangling id keeps its value so that the role is planned for an update
+			name, ok := names[id]
+			if !ok {
+				name = id.String()
+			}
+
+			permissionNames = append(permissionNames, name)
+		}
+
+		state.roles[item.Name] = rbacRecord{
+			id:            item.ID,
+			resource:      models.RBACResource{Name: item.Name, Description: item.Description, Permissions: sortedNames(permissionNames)},
+			permissionIds: item.PermissionIDs,
+		}
+	}
+
+	return state, nil
+}
+
+func upsertChanges(resourceType string, desired []models.RBACResource, live map[string]rbacRecord) []models.RBACChange {
+	changes := make([]models.RBACChange, 0)
+
+	for _, item := range desired {
+		after := item
+		if resourceType == models.RoleResourceType {
+			after.Permissions = sortedNames(item.Permissions)
+		}
+
+		record, ok := live[item.Name]
+		if !ok {
+			changes = append(changes, models.RBACChange{
+				Action:       models.CreateActionType,
+				ResourceType: resourceType,
+				Name:         item.Name,
+				After:        &after,
+			})
+			continue
+		}
+
+		fields := make([]string, 0)
+		if record.resource.Description != after.Description {
+			fields = append(fields, DescriptionField)
+		}
+		if !slices.Equal(record.resource.Permissions, after.Permissions) {
+			fields = append(fields, PermissionsField)
+		}
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		before := record.resource
+		changes = append(changes, models.RBACChange{
+			Action:       models.UpdateActionType,
+			ResourceType: resourceType,
+			ID:           record.id,
+			Name:         item.Name,
+			Fields:       fields,
+			Before:       &before,
+			After:        &after,
+		})
+	}
+
+	return changes
+}
+
+// deleteChanges plans the deletes of the live records missing from a section, a section that is
+// not in the configuration is not managed and plans nothing
+func deleteChanges(resourceType string, desired []models.RBACResource, live map[string]rbacRecord) []models.RBACChange {
+	if desired == nil {
+		return nil
+	}
+
+	declared := make(map[string]bool, len(desired))
+	for _, item := range desired {
+		declared[item.Name] = true
+	}
+
+	names := make([]string, 0)
+	for name := range live {
+		if !declared[name] {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	changes := make([]models.RBACChange, 0, len(names))
+	for _, name := range names {
+		before := live[name].resource
+		changes = append(changes, models.RBACChange{
+			Action:       models.DeleteActionType,
+			ResourceType: resourceType,
+			ID:           live[name].id,
+			Name:         name,
+			Before:       &before,
+		})
+	}
+
+	return changes
+}
+
+// unknownPermissions reports role permissions that are neither in the configuration nor live,
+// roles may refer to live permissions when the permissions section is not managed
+func unknownPermissions(config *models.RBACConfig, state *rbacState) error {
+	declared := make(map[string]bool, len(config.Permissions))
+	for _, item := range config.Permissions {
+		declared[item.Name] = true
+	}
+
+	unknown := make([]string, 0)
+	for _, role := range config.Roles {
+		for _, name := range role.Permissions {
+			if _, ok := state.permissions[name]; !ok && !declared[name] {
+				unknown = append(unknown, name)
+			}
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", errors.ErrUnknownReferences, strings.Join(sortedNames(unknown), ", "))
+	}
+
+	return nil
+}
+
+func upsert[T any](ctx context.Context, action string, params *T, create, update func(ctx context.Context, params *T) (*T, error)) (*T, error) {
+	if action == models.CreateActionType {
+		return create(ctx, params)
+	}
+
+	return update(ctx, params)
+}
+
+// record returns the live resource of the given type and name, nil when it does not exist yet
+func (s *rbacState) record(resourceType, name string) *rbacRecord {
+	records := s.scopes
+	switch resourceType {
+	case models.PermissionResourceType:
+		records = s.permissions
+	case models.RoleResourceType:
+		records = s.roles
+	}
+
+	record, ok := records[name]
+	if !ok {
+		return nil
+	}
+
+	return &record
+}
+
+// sortedNames returns a sorted copy without duplicates, nil for an empty list
+func sortedNames(names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+
+	result := slices.Clone(names)
+	slices.Sort(result)
+
+	return slices.Compact(result)
+}
+
+// rbacSnapshot audits the record in the shape of the permission, role or scope it stands for
+func rbacSnapshot(resourceType string, record *rbacRecord) interface{} {
+	if record == nil {
+		return nil
+	}
+
+	switch resourceType {
+	case models.PermissionResourceType:
+		return (&models.Permission{ID: record.id, Name: record.resource.Name, Description: record.resource.Description}).AuditSnapshot()
+	case models.RoleResourceType:
+		return (&models.Role{ID: record.id, Name: record.resource.Name, Description: record.resource.Description, PermissionIDs: record.permissionIds}).AuditSnapshot()
+	default:
+		return (&models.Scope{ID: record.id, Name: record.resource.Name, Description: record.resource.Description}).AuditSnapshot()
+	}
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+)
+
+func Test_RBAC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.Background()
+	permissions := NewMockPermissions(ctrl)
+	roles := NewMockRoles(ctrl)
+	scopes := NewMockScopes(ctrl)
+	audit := NewMockAudit(ctrl)
+	index := NewMockIndex(ctrl)
+	impact := NewMockImpactAnalyzer(ctrl)
+	service := NewRBAC(permissions, roles, scopes, audit, index, impact, log)
+
+	readId := uuid.MustParse("10000000-1000-1000-4000-000000000001")
+	legacyId := uuid.MustParse("10000000-1000-1000-4000-000000000002")
+	writeId := uuid.MustParse("10000000-1000-1000-4000-000000000003")
+	adminId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	guestId := uuid.MustParse("10000000-1000-1000-3000-000000000002")
+	scopeId := uuid.MustParse("10000000-1000-1000-2000-000000000001")
+	userId := uuid.MustParse("10000000-1000-1000-1000-000000000001")
+
+	prune := &RBACOptions{Prune: true}
+
+	expectLoad := func() {
+		permissions.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Permission{
+			{ID: readId, Name: "read:users", Description: "Read users"},
+			{ID: legacyId, Name: "legacy", Description: "Legacy"},
+		}, uint64(2), nil)
+		scopes.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Scope{
+			{ID: scopeId, Name: "sso-service", Description: "SSO service"},
+		}, uint64(1), nil)
+		roles.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Role{{ID: adminId}, {ID: guestId}}, uint64(2), nil)
+		roles.EXPECT().FindById(gomock.Any(), adminId).Return(&models.Role{ID: adminId, Name: "admin", Description: "Administrator", PermissionIDs: []uuid.UUID{readId, legacyId}}, nil)
+		roles.EXPECT().FindById(gomock.Any(), guestId).Return(&models.Role{ID: guestId, Name: "guest", Description: "Guest"}, nil)
+	}
+
+	desired := &models.RBACConfig{
+		Permissions: []models.RBACResource{
+			{Name: "read:users", Description: "Read users"},
+			{Name: "write:users", Description: "Write users"},
+		},
+		Roles: []models.RBACResource{
+			{Name: "admin", Description: "Administrator", Permissions: []string{"write:users", "read:users"}},
+		},
+		Scopes: []models.RBACResource{
+			{Name: "sso-service", Description: "SSO"},
+		},
+	}
+
+	// admin drops legacy in the same plan, so it no longer depends on it
+	expectImpact := func() {
+		impact.EXPECT().Role(gomock.Any(), guestId).Return(&models.Impact{ResourceType: models.RoleResourceType, ResourceId: guestId}, nil)
+		impact.EXPECT().Permission(gomock.Any(), legacyId).Return(&models.Impact{
+			ResourceType: models.PermissionResourceType,
+			ResourceId:   legacyId,
+			Roles:        []models.Role{{ID: adminId, Name: "admin"}},
+			Users:        []models.User{{ID: userId}},
+		}, nil)
+	}
+
+	t.Run("Plan", func(t *testing.T) {
+		expectLoad()
+		expectImpact()
+
+		plan, err := service.Plan(ctx, desired, prune)
+		require.NoError(t, err)
+
+		assert.Equal(t, []models.RBACChange{
+			{
+				Action:       models.CreateActionType,
+				ResourceType: models.PermissionResourceType,
+				Name:         "write:users",
+				After:        &models.RBACResource{Name: "write:users", Description: "Write users"},
+			},
+			{
+				Action:       models.UpdateActionType,
+				ResourceType: models.ScopeResourceType,
+				ID:           scopeId,
+				Name:         "sso-service",
+				Fields:       []string{DescriptionField},
+				Before:       &models.RBACResource{Name: "sso-service", Description: "SSO service"},
+				After:        &models.RBACResource{Name: "sso-service", Description: "SSO"},
+			},
+			{
+				Action:       models.UpdateActionType,
+				ResourceType: models.RoleResourceType,
+				ID:           adminId,
+				Name:         "admin",
+				Fields:       []string{PermissionsField},
+				Before:       &models.RBACResource{Name: "admin", Description: "Administrator", Permissions: []string{"legacy", "read:users"}},
+				After:        &models.RBACResource{Name: "admin", Description: "Administrator", Permissions: []string{"read:users", "write:users"}},
+			},
+			{
+				Action:       models.DeleteActionType,
+				ResourceType: models.RoleResourceType,
+				ID:           guestId,
+				Name:         "guest",
+				Before:       &models.RBACResource{Name: "guest", Description: "Guest"},
+			},
+			{
+				Action:       models.DeleteActionType,
+				ResourceType: models.PermissionResourceType,
+				ID:           legacyId,
+				Name:         "legacy",
+				Before:       &models.RBACResource{Name: "legacy", Description: "Legacy"},
+			},
+		}, plan.Changes)
+	})
+
+	t.Run("Without prune", func(t *testing.T) {
+		expectLoad()
+
+		plan, err := service.Plan(ctx, desired, &RBACOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, len(plan.Changes))
+		assert.Equal(t, 0, plan.Count(models.DeleteActionType))
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		expectLoad()
+		expectImpact()
+
+		gomock.InOrder(
+			permissions.EXPECT().Create(gomock.Any(), &models.Permission{Name: "write:users", Description: "Write users"}).
+				Return(&models.Permission{ID: writeId, Name: "write:users", Description: "Write users"}, nil),
+			scopes.EXPECT().Update(gomock.Any(), &models.Scope{ID: scopeId, Name: "sso-service", Description: "SSO"}).
+				Return(&models.Scope{ID: scopeId}, nil),
+			roles.EXPECT().Update(gomock.Any(), &models.Role{ID: adminId, Name: "admin", Description: "Administrator", PermissionIDs: []uuid.UUID{readId, writeId}}).
+				Return(&models.Role{ID: adminId}, nil),
+			roles.EXPECT().Delete(gomock.Any(), guestId).Return(false, errors.ErrRecordNotFound),
+		)
+		audit.EXPECT().Record(gomock.Any(), models.CreateActionType, models.PermissionResourceType, writeId, nil, gomock.Any()).Return(nil)
+		audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.ScopeResourceType, scopeId, gomock.Any(), gomock.Any()).Return(nil)
+		audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.RoleResourceType, adminId, gomock.Any(), gomock.Any()).Return(nil)
+		index.EXPECT().Invalidate()
+
+		plan, err := service.Apply(ctx, desired, prune)
+		require.NoError(t, err)
+
+		states := make([]string, 0, len(plan.Changes))
+		for _, change := range plan.Changes {
+			states = append(states, change.State)
+		}
+
+		assert.Equal(t, []string{BulkAppliedState, BulkAppliedState, BulkAppliedState, BulkFailedState, BulkSkippedState}, states)
+		assert.Equal(t, writeId, plan.Changes[0].ID)
+		assert.ErrorIs(t, plan.Changes[3].Err, errors.ErrRecordNotFound)
+	})
+
+	t.Run("Absent sections", func(t *testing.T) {
+		expectLoad()
+		impact.EXPECT().Role(gomock.Any(), guestId).Return(&models.Impact{ResourceType: models.RoleResourceType, ResourceId: guestId}, nil)
+
+		plan, err := service.Plan(ctx, &models.RBACConfig{
+			Roles: []models.RBACResource{{Name: "admin", Description: "Administrator", Permissions: []string{"read:users", "legacy"}}},
+		}, prune)
+		require.NoError(t, err)
+
+		assert.Equal(t, []models.RBACChange{
+			{
+				Action:       models.DeleteActionType,
+				ResourceType: models.RoleResourceType,
+				ID:           guestId,
+				Name:         "guest",
+				Before:       &models.RBACResource{Name: "guest", Description: "Guest"},
+			},
+		}, plan.Changes)
+	})
+
+	t.Run("Unknown permission", func(t *testing.T) {
+		expectLoad()
+
+		plan, err := service.Plan(ctx, &models.RBACConfig{
+			Roles: []models.RBACResource{{Name: "admin", Description: "Administrator", Permissions: []string{"read:users", "missing"}}},
+		}, prune)
+		assert.ErrorIs(t, err, errors.ErrUnknownReferences)
+		assert.Nil(t, plan)
+	})
+
+	t.Run("Blocked by dependents", func(t *testing.T) {
+		expectLoad()
+		dependents := &models.Impact{ResourceType: models.RoleResourceType, ResourceId: guestId, Users: []models.User{{ID: userId}}}
+		impact.EXPECT().Role(gomock.Any(), guestId).Return(dependents, nil)
+
+		plan, err := service.Apply(ctx, &models.RBACConfig{
+			Roles: []models.RBACResource{{Name: "admin", Description: "Administrator", Permissions: []string{"read:users"}}},
+		}, prune)
+		require.NoError(t, err)
+
+		require.Len(t, plan.Changes, 2)
+		assert.Equal(t, BulkSkippedState, plan.Changes[0].State)
+		assert.Equal(t, BulkFailedState, plan.Changes[1].State)
+		assert.Equal(t, dependents, plan.Changes[1].Dependents)
+		assert.ErrorIs(t, plan.Changes[1].Err, errors.ErrResourceHasDependents)
+	})
+
+	t.Run("Forced", func(t *testing.T) {
+		expectLoad()
+		dependents := &models.Impact{ResourceType: models.RoleResourceType, ResourceId: guestId, Users: []models.User{{ID: userId}}}
+		impact.EXPECT().Role(gomock.Any(), guestId).Return(dependents, nil)
+
+		plan, err := service.Plan(ctx, &models.RBACConfig{
+			Roles: []models.RBACResource{
+				{Name: "admin", Description: "Administrator", Permissions: []string{"read:users", "legacy"}},
+			},
+		}, &RBACOptions{Prune: true, Force: true})
+		require.NoError(t, err)
+
+		require.Len(t, plan.Changes, 1)
+		assert.Equal(t, dependents, plan.Changes[0].Dependents)
+		assert.NoError(t, plan.Changes[0].Err)
+	})
+
+	t.Run("Up to date", func(t *testing.T) {
+		expectLoad()
+
+		plan, err := service.Apply(ctx, &models.RBACConfig{
+			Permissions: []models.RBACResource{{Name: "read:users", Description: "Read users"}, {Name: "legacy", Description: "Legacy"}},
+			Roles: []models.RBACResource{
+				{Name: "admin", Description: "Administrator", Permissions: []string{"read:users", "legacy", "legacy"}},
+				{Name: "guest", Description: "Guest"},
+			},
+			Scopes: []models.RBACResource{{Name: "sso-service", Description: "SSO service"}},
+		}, prune)
+		require.NoError(t, err)
+		assert.Empty(t, plan.Changes)
+	})
+
+	t.Run("Load error", func(t *testing.T) {
+		permissions.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return(nil, uint64(0), errors.ErrUnavailable)
+
+		plan, err := service.Plan(ctx, desired, prune)
+		assert.ErrorIs(t, err, errors.ErrUnavailable)
+		assert.Nil(t, plan)
+	})
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"loki-backoffice/internal/app/errors"
+)
+
+const (
+	RoleIdsField       = "role_ids"
+	ScopeIdsField      = "scope_ids"
+	PermissionIdsField = "permission_ids"
+)
+
+// Reference is a request field holding ids of records that must exist upstream
+type Reference struct {
+	Field string
+	IDs   []uuid.UUID
+	Check func(ctx context.Context, id uuid.UUID) error
+}
+
+// referenceOf adapts a FindById method to a reference check
+func referenceOf[T any](field string, ids []uuid.UUID, find func(ctx context.Context, id uuid.UUID) (*T, error)) Reference {
+	return Reference{
+		Field: field,
+		IDs:   ids,
+		Check: func(ctx context.Context, id uuid.UUID) error {
+			_, err := find(ctx, id)
+			return err
+		},
+	}
+}
+
+// checkReferences looks every referenced id up concurrently and returns a ValidationError
+// listing the ids that were not found, any other lookup error aborts the check
+func checkReferences(ctx context.Context, references ...Reference) error {
+	fields := make([]errors.FieldError, 0)
+
+	for _, reference := range references {
+		ids := uniqueIds(reference.IDs)
+		missing := make(map[uuid.UUID]bool, len(ids))
+		var mu sync.Mutex
+
+		group, groupCtx := errgroup.WithContext(ctx)
+		group.SetLimit(ResolveConcurrency)
+
+		for _, id := range ids {
+			group.Go(func() error {
+				err := reference.Check(groupCtx, id)
+				if errors.Is(err, errors.ErrRecordNotFound) {
+					mu.Lock()
+					missing[id] = true
+					mu.Unlock()
+					return nil
+				}
+
+				return err
+			})
+		}
+
+		if err := group.Wait(); err != nil {
+			return err
+		}
+
+		// every occurrence is reported so that clients can highlight each offending entry
+		for i, id := range reference.IDs {
+			if missing[id] {
+				fields = append(fields, errors.FieldError{
+					Pointer: fmt.Sprintf("/%s/%d", reference.Field, i),
+					Code:    errors.UnknownIdCode,
+					Detail:  fmt.Sprintf("%s does not exist", id),
+				})
+			}
+		}
+	}
+
+	if len(fields) > 0 {
+		return &errors.ValidationError{
+			Err:    errors.ErrUnknownReferences,
+			Fields: fields,
+		}
+	}
+
+	return nil
+}
+
+func uniqueIds(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	result := make([]uuid.UUID, 0, len(ids))
+
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
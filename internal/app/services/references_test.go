@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/rpcs"
+)
+
+func Test_checkReferences(t *testing.T) {
+	ctx := context.Background()
+
+	known := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	unknown := uuid.MustParse("10000000-1000-1000-3000-000000000002")
+
+	find := func(ids ...uuid.UUID) func(ctx context.Context, id uuid.UUID) (*models.Role, error) {
+		return func(_ context.Context, id uuid.UUID) (*models.Role, error) {
+			for _, item := range ids {
+				if item == id {
+					return &models.Role{ID: id}, nil
+				}
+			}
+			return nil, errors.ErrRecordNotFound
+		}
+	}
+
+	tests := []struct {
+		name       string
+		references []Reference
+		expected   error
+	}{
+		{
+			name:       "No references",
+			references: []Reference{referenceOf(RoleIdsField, nil, find())},
+		},
+		{
+			name:       "Known references",
+			references: []Reference{referenceOf(RoleIdsField, []uuid.UUID{known, known}, find(known))},
+		},
+		{
+			name: "Unknown references",
+			references: []Reference{
+				referenceOf(RoleIdsField, []uuid.UUID{known, unknown, unknown}, find(known)),
+				referenceOf(ScopeIdsField, []uuid.UUID{known}, find()),
+			},
+			expected: &errors.ValidationError{
+				Err: errors.ErrUnknownReferences,
+				Fields: []errors.FieldError{
+					{Pointer: "/role_ids/1", Code: errors.UnknownIdCode, Detail: unknown.String() + " does not exist"},
+					{Pointer: "/role_ids/2", Code: errors.UnknownIdCode, Detail: unknown.String() + " does not exist"},
+					{Pointer: "/scope_ids/0", Code: errors.UnknownIdCode, Detail: known.String() + " does not exist"},
+				},
+			},
+		},
+		{
+			name: "Lookup error",
+			references: []Reference{
+				referenceOf(RoleIdsField, []uuid.UUID{known}, func(_ context.Context, _ uuid.UUID) (*models.Role, error) {
+					return nil, errors.ErrFailedToFetchResults
+				}),
+			},
+			expected: errors.ErrFailedToFetchResults,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, checkReferences(ctx, tt.references...))
+		})
+	}
+}
+
+func Test_Users_Create_UnknownReferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	roleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+
+	roles := NewMockRoles(ctrl)
+	roles.EXPECT().FindById(gomock.Any(), roleId).Return(nil, errors.ErrRecordNotFound)
+
+	service := NewUsers(rpcs.NewMockUserServiceClient(ctrl), roles, NewMockScopes(ctrl), nil)
+	result, err := service.Create(ctx, &models.User{
+		IdentityNumber: "PNOEE-60001017869",
+		RoleIDs:        []uuid.UUID{roleId},
+	})
+
+	assert.ErrorIs(t, err, errors.ErrUnknownReferences)
+	assert.Nil(t, result)
+}
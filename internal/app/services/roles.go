@@ -4,8 +4,6 @@ import (
 	"context"
 
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
 	"loki-backoffice/internal/app/errors"
 	"loki-backoffice/internal/app/models"
@@ -14,47 +12,49 @@ import (
 )
 
 type Roles interface {
-	List(ctx context.Context, pagination *Pagination) ([]models.Role, uint64, error)
+	List(ctx context.Context, pagination *Pagination, query *Query) ([]models.Role, uint64, error)
 	FindById(ctx context.Context, id uuid.UUID) (*models.Role, error)
 	Create(ctx context.Context, params *models.Role) (*models.Role, error)
 	Update(ctx context.Context, params *models.Role) (*models.Role, error)
 	Delete(ctx context.Context, id uuid.UUID) (bool, error)
 }
 
+var RolesQuerySchema = &QuerySchema{
+	Search: []string{"name", "description"},
+	Sort:   []string{"name", "description"},
+	Filters: map[string]QueryFilter{
+		"name": AnyValue,
+	},
+}
+
 type roles struct {
-	client proto.RoleServiceClient
-	log    *logger.Logger
+	client      proto.RoleServiceClient
+	permissions Permissions
+	log         *logger.Logger
 }
 
-func NewRoles(client proto.RoleServiceClient, log *logger.Logger) Roles {
+func NewRoles(client proto.RoleServiceClient, permissions Permissions, log *logger.Logger) Roles {
 	return &roles{
-		client: client,
-		log:    log,
+		client:      client,
+		permissions: permissions,
+		log:         log,
 	}
 }
 
+func (p *roles) List(ctx context.Context, pagination *Pagination, query *Query) ([]models.Role, uint64, error) {
+	return listWithQuery(ctx, pagination, query, RolesQuerySchema, p.fetch, roleField)
+}
+
 //nolint:dupl
-func (p *roles) List(ctx context.Context, pagination *Pagination) ([]models.Role, uint64, error) {
+func (p *roles) fetch(ctx context.Context, pagination *Pagination) ([]models.Role, uint64, error) {
 	response, err := p.client.List(ctx, &proto.PaginatedListRequest{
-		Limit:  pagination.Page,
-		Offset: pagination.PerPage,
+		Limit:  pagination.Limit(),
+		Offset: pagination.Offset(),
 	})
 	if err != nil {
 		p.log.Error().Err(err).Msg("Failed to fetch roles")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, 0, errors.ErrInvalidArguments
-			case codes.Unavailable:
-				return nil, 0, errors.ErrFailedToFetchResults
-			case codes.Internal:
-				return nil, 0, errors.ErrFailedToFetchResults
-			}
-		}
-
-		return nil, 0, err
+		return nil, 0, errors.FromGRPC(err, errors.ErrFailedToFetchResults)
 	}
 
 	collection := make([]models.Role, 0, len(response.Data))
@@ -77,19 +77,7 @@ func (p *roles) FindById(ctx context.Context, id uuid.UUID) (*models.Role, error
 	if err != nil {
 		p.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get role")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return nil, errors.ErrRecordNotFound
-			case codes.Internal:
-				return nil, errors.ErrFailedToFetchResults
-			}
-		}
-
-		return nil, err
+		return nil, errors.FromGRPC(err, errors.ErrFailedToFetchResults)
 	}
 
 	permissionIds := make([]uuid.UUID, 0, len(response.Data.PermissionIds))
@@ -115,6 +103,10 @@ func (p *roles) FindById(ctx context.Context, id uuid.UUID) (*models.Role, error
 
 //nolint:dupl
 func (p *roles) Create(ctx context.Context, params *models.Role) (*models.Role, error) {
+	if err := checkReferences(ctx, referenceOf(PermissionIdsField, params.PermissionIDs, p.permissions.FindById)); err != nil {
+		return nil, err
+	}
+
 	paramsPermissionIds := []string{}
 	if params.PermissionIDs != nil {
 		paramsPermissionIds = make([]string, 0, len(params.PermissionIDs))
@@ -131,17 +123,7 @@ func (p *roles) Create(ctx context.Context, params *models.Role) (*models.Role,
 	if err != nil {
 		p.log.Error().Err(err).Str("name", params.Name).Msg("Failed to create role")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, errors.ErrInvalidArguments
-			case codes.Internal:
-				return nil, errors.ErrFailedToCreateRecord
-			}
-		}
-
-		return nil, err
+		return nil, errors.FromGRPC(err, errors.ErrFailedToCreateRecord)
 	}
 
 	permissionIds := make([]uuid.UUID, 0, len(response.Data.PermissionIds))
@@ -167,6 +149,10 @@ func (p *roles) Create(ctx context.Context, params *models.Role) (*models.Role,
 
 //nolint:dupl
 func (p *roles) Update(ctx context.Context, params *models.Role) (*models.Role, error) {
+	if err := checkReferences(ctx, referenceOf(PermissionIdsField, params.PermissionIDs, p.permissions.FindById)); err != nil {
+		return nil, err
+	}
+
 	paramsPermissionIds := []string{}
 	if params.PermissionIDs != nil {
 		paramsPermissionIds = make([]string, 0, len(params.PermissionIDs))
@@ -184,19 +170,7 @@ func (p *roles) Update(ctx context.Context, params *models.Role) (*models.Role,
 	if err != nil {
 		p.log.Error().Err(err).Str("id", params.ID.String()).Msg("Failed to update role")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return nil, errors.ErrRecordNotFound
-			case codes.Internal:
-				return nil, errors.ErrFailedToUpdateRecord
-			}
-		}
-
-		return nil, err
+		return nil, errors.FromGRPC(err, errors.ErrFailedToUpdateRecord)
 	}
 
 	permissionIds := make([]uuid.UUID, 0, len(response.Data.PermissionIds))
@@ -228,20 +202,21 @@ func (p *roles) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
 	if err != nil {
 		p.log.Error().Err(err).Str("id", id.String()).Msg("Failed to delete role")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return false, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return false, errors.ErrRecordNotFound
-			case codes.Internal:
-				return false, errors.ErrFailedToDeleteRecord
-			}
-		}
-
-		return false, err
+		return false, errors.FromGRPC(err, errors.ErrFailedToDeleteRecord)
 	}
 
 	return true, nil
 }
+
+func roleField(role models.Role, name string) string {
+	switch name {
+	case "id":
+		return role.ID.String()
+	case "name":
+		return role.Name
+	case "description":
+		return role.Description
+	default:
+		return ""
+	}
+}
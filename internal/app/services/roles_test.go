@@ -32,7 +32,7 @@ func Test_Roles_List(t *testing.T) {
 
 	ctx := context.Background()
 	mockClient := rpcs.NewMockRoleServiceClient(ctrl)
-	service := NewRoles(mockClient, log)
+	service := NewRoles(mockClient, NewMockPermissions(ctrl), log)
 
 	tests := []struct {
 		name     string
@@ -45,8 +45,8 @@ func Test_Roles_List(t *testing.T) {
 			name: "Success",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(&proto.ListRolesResponse{
 					Data: []*proto.Role{
 						{
@@ -96,8 +96,8 @@ func Test_Roles_List(t *testing.T) {
 			name: "Empty",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(&proto.ListRolesResponse{
 					Data: []*proto.Role{},
 					Meta: &proto.PaginationMeta{
@@ -115,8 +115,8 @@ func Test_Roles_List(t *testing.T) {
 			name: "InvalidArgument status code",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, status.Error(codes.InvalidArgument, "invalid arguments"))
 			},
 			expected: nil,
@@ -127,20 +127,20 @@ func Test_Roles_List(t *testing.T) {
 			name: "Unavailable status code",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, status.Error(codes.Unavailable, "service unavailable"))
 			},
 			expected: nil,
 			total:    0,
-			error:    errors.ErrFailedToFetchResults,
+			error:    errors.ErrUnavailable,
 		},
 		{
 			name: "Internal status code",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, status.Error(codes.Internal, "internal error"))
 			},
 			expected: nil,
@@ -151,8 +151,8 @@ func Test_Roles_List(t *testing.T) {
 			name: "Error",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, assert.AnError)
 			},
 			expected: nil,
@@ -168,7 +168,7 @@ func Test_Roles_List(t *testing.T) {
 			result, total, err := service.List(ctx, &Pagination{
 				Page:    uint64(1),
 				PerPage: uint64(10),
-			})
+			}, nil)
 
 			if tt.error != nil {
 				assert.Error(t, err)
@@ -197,7 +197,7 @@ func Test_Roles_FindById(t *testing.T) {
 
 	ctx := context.Background()
 	mockClient := rpcs.NewMockRoleServiceClient(ctrl)
-	service := NewRoles(mockClient, log)
+	service := NewRoles(mockClient, NewMockPermissions(ctrl), log)
 
 	id := uuid.MustParse("10000000-1000-1000-1000-000000000001")
 	permissionIds := []uuid.UUID{
@@ -310,7 +310,11 @@ func Test_Roles_Create(t *testing.T) {
 
 	ctx := context.Background()
 	mockClient := rpcs.NewMockRoleServiceClient(ctrl)
-	service := NewRoles(mockClient, log)
+	permissions := NewMockPermissions(ctrl)
+	permissions.EXPECT().FindById(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, id uuid.UUID) (*models.Permission, error) {
+		return &models.Permission{ID: id}, nil
+	})
+	service := NewRoles(mockClient, permissions, log)
 
 	permissionIds := []uuid.UUID{
 		uuid.MustParse("10000000-1000-1000-3000-000000000001"),
@@ -465,7 +469,11 @@ func Test_Roles_Update(t *testing.T) {
 
 	ctx := context.Background()
 	mockClient := rpcs.NewMockRoleServiceClient(ctrl)
-	service := NewRoles(mockClient, log)
+	permissions := NewMockPermissions(ctrl)
+	permissions.EXPECT().FindById(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, id uuid.UUID) (*models.Permission, error) {
+		return &models.Permission{ID: id}, nil
+	})
+	service := NewRoles(mockClient, permissions, log)
 
 	id := uuid.MustParse("10000000-1000-1000-1000-000000000001")
 	permissionIds := []uuid.UUID{
@@ -649,7 +657,7 @@ func Test_Roles_Delete(t *testing.T) {
 
 	ctx := context.Background()
 	mockClient := rpcs.NewMockRoleServiceClient(ctrl)
-	service := NewRoles(mockClient, log)
+	service := NewRoles(mockClient, NewMockPermissions(ctrl), log)
 
 	id := uuid.MustParse("10000000-1000-1000-1000-000000000001")
 
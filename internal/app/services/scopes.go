@@ -4,8 +4,6 @@ import (
 	"context"
 
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
 	"loki-backoffice/internal/app/errors"
 	"loki-backoffice/internal/app/models"
@@ -14,13 +12,21 @@ import (
 )
 
 type Scopes interface {
-	List(ctx context.Context, pagination *Pagination) ([]models.Scope, uint64, error)
+	List(ctx context.Context, pagination *Pagination, query *Query) ([]models.Scope, uint64, error)
 	FindById(ctx context.Context, id uuid.UUID) (*models.Scope, error)
 	Create(ctx context.Context, params *models.Scope) (*models.Scope, error)
 	Update(ctx context.Context, params *models.Scope) (*models.Scope, error)
 	Delete(ctx context.Context, id uuid.UUID) (bool, error)
 }
 
+var ScopesQuerySchema = &QuerySchema{
+	Search: []string{"name", "description"},
+	Sort:   []string{"name", "description"},
+	Filters: map[string]QueryFilter{
+		"name": AnyValue,
+	},
+}
+
 type scopes struct {
 	client proto.ScopeServiceClient
 	log    *logger.Logger
@@ -33,28 +39,20 @@ func NewScopes(client proto.ScopeServiceClient, log *logger.Logger) Scopes {
 	}
 }
 
+func (p *scopes) List(ctx context.Context, pagination *Pagination, query *Query) ([]models.Scope, uint64, error) {
+	return listWithQuery(ctx, pagination, query, ScopesQuerySchema, p.fetch, scopeField)
+}
+
 //nolint:dupl
-func (p *scopes) List(ctx context.Context, pagination *Pagination) ([]models.Scope, uint64, error) {
+func (p *scopes) fetch(ctx context.Context, pagination *Pagination) ([]models.Scope, uint64, error) {
 	response, err := p.client.List(ctx, &proto.PaginatedListRequest{
-		Limit:  pagination.Page,
-		Offset: pagination.PerPage,
+		Limit:  pagination.Limit(),
+		Offset: pagination.Offset(),
 	})
 	if err != nil {
 		p.log.Error().Err(err).Msg("Failed to fetch scopes")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, 0, errors.ErrInvalidArguments
-			case codes.Unavailable:
-				return nil, 0, errors.ErrFailedToFetchResults
-			case codes.Internal:
-				return nil, 0, errors.ErrFailedToFetchResults
-			}
-		}
-
-		return nil, 0, err
+		return nil, 0, errors.FromGRPC(err, errors.ErrFailedToFetchResults)
 	}
 
 	collection := make([]models.Scope, 0, len(response.Data))
@@ -77,19 +75,7 @@ func (p *scopes) FindById(ctx context.Context, id uuid.UUID) (*models.Scope, err
 	if err != nil {
 		p.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get scope")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return nil, errors.ErrRecordNotFound
-			case codes.Internal:
-				return nil, errors.ErrFailedToFetchResults
-			}
-		}
-
-		return nil, err
+		return nil, errors.FromGRPC(err, errors.ErrFailedToFetchResults)
 	}
 
 	return &models.Scope{
@@ -108,17 +94,7 @@ func (p *scopes) Create(ctx context.Context, params *models.Scope) (*models.Scop
 	if err != nil {
 		p.log.Error().Err(err).Str("name", params.Name).Msg("Failed to create scope")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, errors.ErrInvalidArguments
-			case codes.Internal:
-				return nil, errors.ErrFailedToCreateRecord
-			}
-		}
-
-		return nil, err
+		return nil, errors.FromGRPC(err, errors.ErrFailedToCreateRecord)
 	}
 
 	return &models.Scope{
@@ -138,19 +114,7 @@ func (p *scopes) Update(ctx context.Context, params *models.Scope) (*models.Scop
 	if err != nil {
 		p.log.Error().Err(err).Str("id", params.ID.String()).Msg("Failed to update scope")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return nil, errors.ErrRecordNotFound
-			case codes.Internal:
-				return nil, errors.ErrFailedToUpdateRecord
-			}
-		}
-
-		return nil, err
+		return nil, errors.FromGRPC(err, errors.ErrFailedToUpdateRecord)
 	}
 
 	return &models.Scope{
@@ -168,20 +132,21 @@ func (p *scopes) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
 	if err != nil {
 		p.log.Error().Err(err).Str("id", id.String()).Msg("Failed to delete scope")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return false, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return false, errors.ErrRecordNotFound
-			case codes.Internal:
-				return false, errors.ErrFailedToDeleteRecord
-			}
-		}
-
-		return false, err
+		return false, errors.FromGRPC(err, errors.ErrFailedToDeleteRecord)
 	}
 
 	return true, nil
 }
+
+func scopeField(scope models.Scope, name string) string {
+	switch name {
+	case "id":
+		return scope.ID.String()
+	case "name":
+		return scope.Name
+	case "description":
+		return scope.Description
+	default:
+		return ""
+	}
+}
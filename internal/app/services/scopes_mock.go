@@ -88,9 +88,9 @@ func (mr *MockScopesMockRecorder) FindById(ctx, id any) *gomock.Call {
 }
 
 // List mocks base method.
-func (m *MockScopes) List(ctx context.Context, pagination *Pagination) ([]models.Scope, uint64, error) {
+func (m *MockScopes) List(ctx context.Context, pagination *Pagination, query *Query) ([]models.Scope, uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, pagination)
+	ret := m.ctrl.Call(m, "List", ctx, pagination, query)
 	ret0, _ := ret[0].([]models.Scope)
 	ret1, _ := ret[1].(uint64)
 	ret2, _ := ret[2].(error)
@@ -98,9 +98,9 @@ func (m *MockScopes) List(ctx context.Context, pagination *Pagination) ([]models
 }
 
 // List indicates an expected call of List.
-func (mr *MockScopesMockRecorder) List(ctx, pagination any) *gomock.Call {
+func (mr *MockScopesMockRecorder) List(ctx, pagination, query any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockScopes)(nil).List), ctx, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockScopes)(nil).List), ctx, pagination, query)
 }
 
 // Update mocks base method.
@@ -0,0 +1,625 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/models/dto"
+	"loki-backoffice/internal/app/repositories"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/internal/config/middlewares"
+)
+
+const (
+	DeleteUsersParam = "delete_users"
+
+	PersonalCodeField = "personal_code"
+	FirstNameField    = "first_name"
+	LastNameField     = "last_name"
+	RolesField        = "roles"
+	ScopesField       = "scopes"
+)
+
+// RestoreOptions controls whether a restore is applied or only planned
+type RestoreOptions struct {
+	// DryRun reports the changes without making them
+	DryRun bool
+	// DeleteUsers deletes the live users missing from the snapshot, they are kept otherwise
+	DeleteUsers bool
+	// Prune deletes the permissions, roles and scopes missing from the snapshot
+	Prune bool
+	// Force deletes permissions, roles and scopes that roles or users still depend on
+	Force bool
+}
+
+func NewRestoreOptions(r *http.Request) (*RestoreOptions, error) {
+	dryRun, err := parseBoolParam(r, DryRunParam)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteUsers, err := parseBoolParam(r, DeleteUsersParam)
+	if err != nil {
+		return nil, err
+	}
+
+	rbacOptions, err := NewRBACOptions(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RestoreOptions{DryRun: dryRun, DeleteUsers: deleteUsers, Prune: rbacOptions.Prune, Force: rbacOptions.Force}, nil
+}
+
+func (options *RestoreOptions) rbac() *RBACOptions {
+	return &RBACOptions{Prune: options.Prune, Force: options.Force}
+}
+
+// Snapshots takes point-in-time copies of the permissions, roles, scopes and users and restores them,
+// records are matched by name and users by identity number so that a restore survives recreated ids
+type Snapshots interface {
+	Take(ctx context.Context) (*models.Snapshot, error)
+	Save(ctx context.Context, snapshot *models.Snapshot) (*models.Snapshot, error)
+	List(ctx context.Context, pagination *Pagination) ([]models.SnapshotRecord, uint64, error)
+	FindById(ctx context.Context, id uuid.UUID) (*models.Snapshot, error)
+	Plan(ctx context.Context, snapshot *models.Snapshot, options *RestoreOptions) (*models.SnapshotRestore, error)
+	Restore(ctx context.Context, snapshot *models.Snapshot, options *RestoreOptions) (*models.SnapshotRestore, error)
+}
+
+type snapshots struct {
+	permissions Permissions
+	roles       Roles
+	scopes      Scopes
+	users       Users
+	engine      RBAC
+	audit       Audit
+	index       Index
+	repository  repositories.SnapshotRepository
+	retention   int
+	log         *logger.Logger
+}
+
+func NewSnapshots(
+	cfg *config.Config,
+	permissions Permissions,
+	roles Roles,
+	scopes Scopes,
+	users Users,
+	engine RBAC,
+	audit Audit,
+	index Index,
+	repository repositories.SnapshotRepository,
+	log *logger.Logger,
+) Snapshots {
+	retention := cfg.SnapshotRetention
+	if retention <= 0 {
+		retention = config.SnapshotRetention
+	}
+
+	return &snapshots{
+		permissions: permissions,
+		roles:       roles,
+		scopes:      scopes,
+		users:       users,
+		engine:      engine,
+		audit:       audit,
+		index:       index,
+		repository:  repository,
+		retention:   retention,
+		log:         log.WithComponent("snapshots"),
+	}
+}
+
+// Take reads the live state, roles and users are loaded individually for their relations
+func (s *snapshots) Take(ctx context.Context) (*models.Snapshot, error) {
+	permissions, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Permission, uint64, error) {
+		return s.permissions.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scopes, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Scope, uint64, error) {
+		return s.scopes.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.loadRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.loadUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &models.Snapshot{
+		Version:     models.SnapshotVersion,
+		CreatedAt:   time.Now().UTC(),
+		Permissions: permissions,
+		Roles:       roles,
+		Scopes:      scopes,
+		Users:       users,
+	}
+
+	if claim, ok := middlewares.CurrentClaimFromContext(ctx); ok {
+		snapshot.ActorID = claim.ID
+	}
+
+	return snapshot, nil
+}
+
+// Save stores the archive of the snapshot and deletes the stored snapshots beyond the retention
+func (s *snapshots) Save(ctx context.Context, snapshot *models.Snapshot) (*models.Snapshot, error) {
+	archive, err := json.Marshal(dto.NewSnapshotArchive(snapshot))
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.repository.Create(ctx, &models.SnapshotRecord{
+		Version: snapshot.Version,
+		ActorID: snapshot.ActorID,
+		Archive: archive,
+	})
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to store snapshot")
+		return nil, fmt.Errorf("failed to store snapshot: %w", err)
+	}
+
+	deleted, err := s.repository.DeleteBeyondRetention(ctx, s.retention)
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to delete snapshots beyond retention")
+	} else if deleted > 0 {
+		s.log.Info().Int64("deleted", deleted).Int("retention", s.retention).Msg("Deleted snapshots beyond retention")
+	}
+
+	result := *snapshot
+	result.ID = record.ID
+
+	return &result, nil
+}
+
+// List returns the stored snapshots without their archives
+func (s *snapshots) List(ctx context.Context, pagination *Pagination) ([]models.SnapshotRecord, uint64, error) {
+	seek, err := pagination.Seek()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, total, err := s.repository.List(ctx, seek, pagination.SeekLimit(), pagination.Offset())
+	if err != nil {
+		s.log.Error().Err(err).Msg("Failed to fetch snapshots")
+		return nil, 0, errors.ErrFailedToFetchResults
+	}
+
+	return seekOf(rows, pagination, seek, snapshotRecordKey), total, nil
+}
+
+func snapshotRecordKey(record models.SnapshotRecord) (time.Time, uuid.UUID) {
+	return record.CreatedAt, record.ID
+}
+
+// FindById reads a stored snapshot back from its archive
+func (s *snapshots) FindById(ctx context.Context, id uuid.UUID) (*models.Snapshot, error) {
+	record, err := s.repository.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var params dto.SnapshotArchive
+	if err = params.Validate(bytes.NewReader(record.Archive)); err != nil {
+		s.log.Error().Err(err).Str("id", id.String()).Msg("Failed to read snapshot archive")
+		return nil, err
+	}
+
+	snapshot := params.Snapshot()
+	snapshot.ID = record.ID
+
+	return snapshot, nil
+}
+
+// Plan reports the changes a restore would make without making them
+func (s *snapshots) Plan(ctx context.Context, snapshot *models.Snapshot, options *RestoreOptions) (*models.SnapshotRestore, error) {
+	plan, err := s.engine.Plan(ctx, snapshotConfig(snapshot), options.rbac())
+	if err != nil {
+		return nil, err
+	}
+
+	roleNames, scopeNames, err := s.names(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users, retained, _, err := s.userChanges(ctx, snapshot, roleNames, scopeNames, options.DeleteUsers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SnapshotRestore{RBAC: plan, Users: users, DeleteUsers: options.DeleteUsers, Retained: retained}, nil
+}
+
+// Restore applies the permissions, roles and scopes through the RBAC engine and then the users,
+// users are skipped when a change of the engine failed and the first failed user skips the remaining ones
+func (s *snapshots) Restore(ctx context.Context, snapshot *models.Snapshot, options *RestoreOptions) (*models.SnapshotRestore, error) {
+	plan, err := s.engine.Apply(ctx, snapshotConfig(snapshot), options.rbac())
+	if err != nil {
+		return nil, err
+	}
+
+	// read after the engine ran so that roles and scopes it created resolve to their new ids
+	roleNames, scopeNames, err := s.names(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, retained, live, err := s.userChanges(ctx, snapshot, roleNames, scopeNames, options.DeleteUsers)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := slices.ContainsFunc(plan.Changes, func(change models.RBACChange) bool {
+		return change.State == BulkFailedState
+	})
+
+	roleIds, scopeIds := invert(roleNames), invert(scopeNames)
+
+	applied := 0
+	for i := range changes {
+		change := &changes[i]
+
+		if failed {
+			change.State = BulkSkippedState
+			continue
+		}
+
+		if err = s.applyUser(ctx, change, live, roleIds, scopeIds); err != nil {
+			s.log.Error().Err(err).Str("identity_number", change.IdentityNumber).Msgf("Failed to %s user", change.Action)
+
+			change.State = BulkFailedState
+			change.Err = err
+			failed = true
+			continue
+		}
+
+		change.State = BulkAppliedState
+		applied++
+	}
+
+	if applied > 0 {
+		s.index.Invalidate()
+	}
+
+	s.log.Info().Int("changes", len(plan.Changes)+len(changes)).Msg("Restored snapshot")
+
+	return &models.SnapshotRestore{RBAC: plan, Users: changes, DeleteUsers: options.DeleteUsers, Retained: retained}, nil
+}
+
+func (s *snapshots) applyUser(ctx context.Context, change *models.SnapshotUserChange, live map[uuid.UUID]models.User, roleIds, scopeIds map[string]uuid.UUID) error {
+	if change.Action == models.DeleteActionType {
+		before := live[change.ID]
+		if _, err := s.users.Delete(ctx, change.ID); err != nil {
+			return err
+		}
+
+		RecordApplied(ctx, s.audit, s.log, models.DeleteActionType, models.UserResourceType, change.ID, before.AuditSnapshot(), nil)
+		return nil
+	}
+
+	params := &models.User{
+		ID:             change.ID,
+		IdentityNumber: change.After.IdentityNumber,
+		PersonalCode:   change.After.PersonalCode,
+		FirstName:      change.After.FirstName,
+		LastName:       change.After.LastName,
+	}
+
+	var err error
+	if params.RoleIDs, err = idsOf(change.After.Roles, roleIds); err != nil {
+		return err
+	}
+	if params.ScopeIDs, err = idsOf(change.After.Scopes, scopeIds); err != nil {
+		return err
+	}
+
+	if change.Action == models.CreateActionType {
+		record, err := s.users.Create(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		change.ID = record.ID
+		params.ID = record.ID
+		RecordApplied(ctx, s.audit, s.log, models.CreateActionType, models.UserResourceType, record.ID, nil, params.AuditSnapshot())
+		return nil
+	}
+
+	if _, err = s.users.Update(ctx, params); err != nil {
+		return err
+	}
+
+	before := live[change.ID]
+	RecordApplied(ctx, s.audit, s.log, models.UpdateActionType, models.UserResourceType, change.ID, before.AuditSnapshot(), params.AuditSnapshot())
+
+	return nil
+}
+
+// userChanges diffs the archived users against the live ones by identity number and role and scope names,
+// live users missing from the snapshot are deleted only with deleteUsers and returned as retained otherwise
+func (s *snapshots) userChanges(ctx context.Context, snapshot *models.Snapshot, roleNames, scopeNames map[uuid.UUID]string, deleteUsers bool) ([]models.SnapshotUserChange, []string, map[uuid.UUID]models.User, error) {
+	users, err := s.loadUsers(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	archivedRoles := make(map[uuid.UUID]string, len(snapshot.Roles))
+	for _, role := range snapshot.Roles {
+		archivedRoles[role.ID] = role.Name
+	}
+
+	archivedScopes := make(map[uuid.UUID]string, len(snapshot.Scopes))
+	for _, scope := range snapshot.Scopes {
+		archivedScopes[scope.ID] = scope.Name
+	}
+
+	live := make(map[uuid.UUID]models.User, len(users))
+	byIdentity := make(map[string]models.User, len(users))
+	for _, user := range users {
+		live[user.ID] = user
+		byIdentity[strings.ToUpper(user.IdentityNumber)] = user
+	}
+
+	changes := make([]models.SnapshotUserChange, 0)
+	declared := make(map[string]bool, len(snapshot.Users))
+
+	for _, user := range snapshot.Users {
+		key := strings.ToUpper(user.IdentityNumber)
+		declared[key] = true
+		after := snapshotUser(&user, archivedRoles, archivedScopes)
+
+		record, ok := byIdentity[key]
+		if !ok {
+			changes = append(changes, models.SnapshotUserChange{
+				Action:         models.CreateActionType,
+				IdentityNumber: user.IdentityNumber,
+				After:          after,
+			})
+			continue
+		}
+
+		before := snapshotUser(&record, roleNames, scopeNames)
+		fields := userFields(before, after)
+		if len(fields) == 0 {
+			continue
+		}
+
+		changes = append(changes, models.SnapshotUserChange{
+			Action:         models.UpdateActionType,
+			ID:             record.ID,
+			IdentityNumber: record.IdentityNumber,
+			Fields:         fields,
+			Before:         before,
+			After:          after,
+		})
+	}
+
+	removed := make([]models.User, 0)
+	for _, user := range users {
+		if !declared[strings.ToUpper(user.IdentityNumber)] {
+			removed = append(removed, user)
+		}
+	}
+	slices.SortFunc(removed, func(a, b models.User) int {
+		return strings.Compare(a.IdentityNumber, b.IdentityNumber)
+	})
+
+	retained := make([]string, 0)
+	for _, user := range removed {
+		if !deleteUsers {
+			retained = append(retained, user.IdentityNumber)
+			continue
+		}
+
+		changes = append(changes, models.SnapshotUserChange{
+			Action:         models.DeleteActionType,
+			ID:             user.ID,
+			IdentityNumber: user.IdentityNumber,
+			Before:         snapshotUser(&user, roleNames, scopeNames),
+		})
+	}
+
+	return changes, retained, live, nil
+}
+
+func (s *snapshots) loadRoles(ctx context.Context) ([]models.Role, error) {
+	rows, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Role, uint64, error) {
+		return s.roles.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	records, err := fanOut(ctx, ids, s.roles.FindById)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]models.Role, 0, len(records))
+	for _, record := range records {
+		roles = append(roles, *record)
+	}
+
+	return roles, nil
+}
+
+func (s *snapshots) loadUsers(ctx context.Context) ([]models.User, error) {
+	rows, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.User, uint64, error) {
+		return s.users.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+
+	records, err := fanOut(ctx, ids, s.users.FindById)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]models.User, 0, len(records))
+	for _, record := range records {
+		users = append(users, *record)
+	}
+
+	return users, nil
+}
+
+// names maps the live role and scope ids to their names
+func (s *snapshots) names(ctx context.Context) (map[uuid.UUID]string, map[uuid.UUID]string, error) {
+	roles, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Role, uint64, error) {
+		return s.roles.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scopes, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Scope, uint64, error) {
+		return s.scopes.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	roleNames := make(map[uuid.UUID]string, len(roles))
+	for _, role := range roles {
+		roleNames[role.ID] = role.Name
+	}
+
+	scopeNames := make(map[uuid.UUID]string, len(scopes))
+	for _, scope := range scopes {
+		scopeNames[scope.ID] = scope.Name
+	}
+
+	return roleNames, scopeNames, nil
+}
+
+// snapshotConfig converts the archived permissions, roles and scopes into the desired state of the RBAC engine
+func snapshotConfig(snapshot *models.Snapshot) *models.RBACConfig {
+	result := &models.RBACConfig{
+		Permissions: make([]models.RBACResource, 0, len(snapshot.Permissions)),
+		Roles:       make([]models.RBACResource, 0, len(snapshot.Roles)),
+		Scopes:      make([]models.RBACResource, 0, len(snapshot.Scopes)),
+	}
+
+	permissions := make(map[uuid.UUID]string, len(snapshot.Permissions))
+	for _, item := range snapshot.Permissions {
+		permissions[item.ID] = item.Name
+		result.Permissions = append(result.Permissions, models.RBACResource{Name: item.Name, Description: item.Description})
+	}
+
+	for _, item := range snapshot.Roles {
+		result.Roles = append(result.Roles, models.RBACResource{Name: item.Name, Description: item.Description, Permissions: namesOf(item.PermissionIDs, permissions)})
+	}
+
+	for _, item := range snapshot.Scopes {
+		result.Scopes = append(result.Scopes, models.RBACResource{Name: item.Name, Description: item.Description})
+	}
+
+	return result
+}
+
+// snapshotUser refers to the roles and scopes of the user by name, a dangling id keeps its value
+// so that the user is planned for an update
+func snapshotUser(user *models.User, roleNames, scopeNames map[uuid.UUID]string) *models.SnapshotUser {
+	resolve := func(ids []uuid.UUID, names map[uuid.UUID]string) []string {
+		result := make([]string, 0, len(ids))
+		for _, id := range ids {
+			name, ok := names[id]
+			if !ok {
+				name = id.String()
+			}
+
+			result = append(result, name)
+		}
+
+		return sortedNames(result)
+	}
+
+	return &models.SnapshotUser{
+		IdentityNumber: user.IdentityNumber,
+		PersonalCode:   user.PersonalCode,
+		FirstName:      user.FirstName,
+		LastName:       user.LastName,
+		Roles:          resolve(user.RoleIDs, roleNames),
+		Scopes:         resolve(user.ScopeIDs, scopeNames),
+	}
+}
+
+func userFields(before, after *models.SnapshotUser) []string {
+	fields := make([]string, 0)
+
+	if before.PersonalCode != after.PersonalCode {
+		fields = append(fields, PersonalCodeField)
+	}
+	if before.FirstName != after.FirstName {
+		fields = append(fields, FirstNameField)
+	}
+	if before.LastName != after.LastName {
+		fields = append(fields, LastNameField)
+	}
+	if !slices.Equal(before.Roles, after.Roles) {
+		fields = append(fields, RolesField)
+	}
+	if !slices.Equal(before.Scopes, after.Scopes) {
+		fields = append(fields, ScopesField)
+	}
+
+	return fields
+}
+
+func invert(names map[uuid.UUID]string) map[string]uuid.UUID {
+	ids := make(map[string]uuid.UUID, len(names))
+	for id, name := range names {
+		ids[name] = id
+	}
+
+	return ids
+}
+
+// idsOf maps names back to live ids, a name the RBAC engine did not restore is an unknown reference
+func idsOf(names []string, ids map[string]uuid.UUID) ([]uuid.UUID, error) {
+	result := make([]uuid.UUID, 0, len(names))
+
+	for _, name := range names {
+		id, ok := ids[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errors.ErrUnknownReferences, name)
+		}
+
+		result = append(result, id)
+	}
+
+	return result, nil
+}
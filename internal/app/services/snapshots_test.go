@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/repositories"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/internal/config/middlewares"
+	"loki-backoffice/pkg/jwt"
+)
+
+func Test_Snapshots(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.WithValue(context.Background(), middlewares.Claim{}, &jwt.Payload{ID: "actor"})
+	permissions := NewMockPermissions(ctrl)
+	roles := NewMockRoles(ctrl)
+	scopes := NewMockScopes(ctrl)
+	users := NewMockUsers(ctrl)
+	engine := NewMockRBAC(ctrl)
+	audit := NewMockAudit(ctrl)
+	index := NewMockIndex(ctrl)
+	repository := repositories.NewMockSnapshotRepository(ctrl)
+	service := NewSnapshots(cfg, permissions, roles, scopes, users, engine, audit, index, repository, log)
+
+	permissionId := uuid.MustParse("10000000-1000-1000-4000-000000000001")
+	adminId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	userRoleId := uuid.MustParse("10000000-1000-1000-3000-000000000002")
+	scopeId := uuid.MustParse("10000000-1000-1000-2000-000000000001")
+	johnId := uuid.MustParse("10000000-1000-1000-1000-000000000001")
+	jackId := uuid.MustParse("10000000-1000-1000-1000-000000000003")
+
+	// the archived ids predate a recreation of every record
+	snapshot := &models.Snapshot{
+		Version:   models.SnapshotVersion,
+		ActorID:   "actor",
+		CreatedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Permissions: []models.Permission{
+			{ID: uuid.MustParse("20000000-1000-1000-4000-000000000001"), Name: "read:users", Description: "Read users"},
+		},
+		Roles: []models.Role{
+			{ID: uuid.MustParse("20000000-1000-1000-3000-000000000001"), Name: "admin", Description: "Administrator", PermissionIDs: []uuid.UUID{uuid.MustParse("20000000-1000-1000-4000-000000000001")}},
+			{ID: uuid.MustParse("20000000-1000-1000-3000-000000000002"), Name: "user", Description: "User"},
+		},
+		Scopes: []models.Scope{
+			{ID: uuid.MustParse("20000000-1000-1000-2000-000000000001"), Name: "sso-service", Description: "SSO service"},
+		},
+		Users: []models.User{
+			{
+				ID:             uuid.MustParse("20000000-1000-1000-1000-000000000001"),
+				IdentityNumber: "PNOEE-60001017869",
+				PersonalCode:   "60001017869",
+				FirstName:      "John",
+				LastName:       "Doe",
+				RoleIDs:        []uuid.UUID{uuid.MustParse("20000000-1000-1000-3000-000000000001")},
+				ScopeIDs:       []uuid.UUID{uuid.MustParse("20000000-1000-1000-2000-000000000001")},
+			},
+			{
+				ID:             uuid.MustParse("20000000-1000-1000-1000-000000000002"),
+				IdentityNumber: "PNOEE-60001018800",
+				PersonalCode:   "60001018800",
+				FirstName:      "Jane",
+				LastName:       "Doe",
+				RoleIDs:        []uuid.UUID{uuid.MustParse("20000000-1000-1000-3000-000000000002")},
+			},
+		},
+	}
+
+	john := models.User{ID: johnId, IdentityNumber: "PNOEE-60001017869", PersonalCode: "60001017869", FirstName: "John", LastName: "Doe", RoleIDs: []uuid.UUID{userRoleId}, ScopeIDs: []uuid.UUID{scopeId}}
+	jack := models.User{ID: jackId, IdentityNumber: "PNOEE-60001019906", PersonalCode: "60001019906", FirstName: "Jack", LastName: "Doe"}
+
+	expectLive := func() {
+		users.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.User{{ID: johnId}, {ID: jackId}}, uint64(2), nil)
+		users.EXPECT().FindById(gomock.Any(), johnId).Return(&john, nil)
+		users.EXPECT().FindById(gomock.Any(), jackId).Return(&jack, nil)
+		roles.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Role{{ID: adminId, Name: "admin"}, {ID: userRoleId, Name: "user"}}, uint64(2), nil)
+		scopes.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Scope{{ID: scopeId, Name: "sso-service"}}, uint64(1), nil)
+	}
+
+	t.Run("Take", func(t *testing.T) {
+		permissions.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Permission{{ID: permissionId, Name: "read:users", Description: "Read users"}}, uint64(1), nil)
+		scopes.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Scope{{ID: scopeId, Name: "sso-service", Description: "SSO service"}}, uint64(1), nil)
+		roles.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Role{{ID: adminId}}, uint64(1), nil)
+		roles.EXPECT().FindById(gomock.Any(), adminId).Return(&models.Role{ID: adminId, Name: "admin", Description: "Administrator", PermissionIDs: []uuid.UUID{permissionId}}, nil)
+		users.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.User{{ID: johnId}}, uint64(1), nil)
+		users.EXPECT().FindById(gomock.Any(), johnId).Return(&john, nil)
+
+		result, err := service.Take(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, models.SnapshotVersion, result.Version)
+		assert.Equal(t, "actor", result.ActorID)
+		assert.Equal(t, []models.Role{{ID: adminId, Name: "admin", Description: "Administrator", PermissionIDs: []uuid.UUID{permissionId}}}, result.Roles)
+		assert.Equal(t, []models.User{john}, result.Users)
+	})
+
+	t.Run("Save and find", func(t *testing.T) {
+		id := uuid.MustParse("30000000-1000-1000-1000-000000000001")
+
+		var archive []byte
+		repository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, params *models.SnapshotRecord) (*models.SnapshotRecord, error) {
+			archive = params.Archive
+			return &models.SnapshotRecord{ID: id, Version: params.Version, ActorID: params.ActorID, Archive: params.Archive}, nil
+		})
+		repository.EXPECT().DeleteBeyondRetention(gomock.Any(), config.SnapshotRetention).Return(int64(1), nil)
+
+		saved, err := service.Save(ctx, snapshot)
+		require.NoError(t, err)
+		assert.Equal(t, id, saved.ID)
+
+		var document map[string]interface{}
+		require.NoError(t, json.Unmarshal(archive, &document))
+		assert.Equal(t, float64(models.SnapshotVersion), document["version"])
+
+		repository.EXPECT().FindById(gomock.Any(), id).Return(&models.SnapshotRecord{ID: id, Archive: archive}, nil)
+
+		found, err := service.FindById(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, saved, found)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		id := uuid.MustParse("30000000-1000-1000-1000-000000000002")
+		repository.EXPECT().FindById(gomock.Any(), id).Return(nil, errors.ErrRecordNotFound)
+
+		result, err := service.FindById(ctx, id)
+		assert.ErrorIs(t, err, errors.ErrRecordNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Restore deleting users", func(t *testing.T) {
+		janeId := uuid.MustParse("10000000-1000-1000-1000-000000000002")
+
+		engine.EXPECT().Apply(gomock.Any(), &models.RBACConfig{
+			Permissions: []models.RBACResource{{Name: "read:users", Description: "Read users"}},
+			Roles: []models.RBACResource{
+				{Name: "admin", Description: "Administrator", Permissions: []string{"read:users"}},
+				{Name: "user", Description: "User", Permissions: []string{}},
+			},
+			Scopes: []models.RBACResource{{Name: "sso-service", Description: "SSO service"}},
+		}, &RBACOptions{Prune: true}).Return(&models.RBACPlan{Changes: []models.RBACChange{}}, nil)
+		expectLive()
+
+		gomock.InOrder(
+			users.EXPECT().Update(gomock.Any(), &models.User{ID: johnId, IdentityNumber: "PNOEE-60001017869", PersonalCode: "60001017869", FirstName: "John", LastName: "Doe", RoleIDs: []uuid.UUID{adminId}, ScopeIDs: []uuid.UUID{scopeId}}).
+				Return(&models.User{ID: johnId}, nil),
+			users.EXPECT().Create(gomock.Any(), &models.User{IdentityNumber: "PNOEE-60001018800", PersonalCode: "60001018800", FirstName: "Jane", LastName: "Doe", RoleIDs: []uuid.UUID{userRoleId}, ScopeIDs: []uuid.UUID{}}).
+				Return(&models.User{ID: janeId}, nil),
+			users.EXPECT().Delete(gomock.Any(), jackId).Return(true, nil),
+		)
+		audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.UserResourceType, johnId, gomock.Any(), gomock.Any()).Return(nil)
+		audit.EXPECT().Record(gomock.Any(), models.CreateActionType, models.UserResourceType, janeId, nil, gomock.Any()).Return(nil)
+		audit.EXPECT().Record(gomock.Any(), models.DeleteActionType, models.UserResourceType, jackId, gomock.Any(), nil).Return(nil)
+		index.EXPECT().Invalidate()
+
+		result, err := service.Restore(ctx, snapshot, &RestoreOptions{DeleteUsers: true, Prune: true})
+		require.NoError(t, err)
+
+		assert.True(t, result.DeleteUsers)
+		assert.Empty(t, result.Retained)
+		require.Len(t, result.Users, 3)
+		assert.Equal(t, models.SnapshotUserChange{
+			Action:         models.UpdateActionType,
+			ID:             johnId,
+			IdentityNumber: "PNOEE-60001017869",
+			Fields:         []string{RolesField},
+			Before:         &models.SnapshotUser{IdentityNumber: "PNOEE-60001017869", PersonalCode: "60001017869", FirstName: "John", LastName: "Doe", Roles: []string{"user"}, Scopes: []string{"sso-service"}},
+			After:          &models.SnapshotUser{IdentityNumber: "PNOEE-60001017869", PersonalCode: "60001017869", FirstName: "John", LastName: "Doe", Roles: []string{"admin"}, Scopes: []string{"sso-service"}},
+			State:          BulkAppliedState,
+		}, result.Users[0])
+		assert.Equal(t, janeId, result.Users[1].ID)
+		assert.Equal(t, models.DeleteActionType, result.Users[2].Action)
+		assert.Equal(t, BulkAppliedState, result.Users[2].State)
+	})
+
+	t.Run("Restore skips users when RBAC failed", func(t *testing.T) {
+		engine.EXPECT().Apply(gomock.Any(), gomock.Any(), &RBACOptions{}).Return(&models.RBACPlan{Changes: []models.RBACChange{
+			{Action: models.CreateActionType, ResourceType: models.PermissionResourceType, Name: "read:users", State: BulkFailedState, Err: errors.ErrUnavailable},
+		}}, nil)
+		expectLive()
+
+		result, err := service.Restore(ctx, snapshot, &RestoreOptions{})
+		require.NoError(t, err)
+
+		for _, change := range result.Users {
+			assert.Equal(t, BulkSkippedState, change.State)
+		}
+	})
+
+	t.Run("Plan", func(t *testing.T) {
+		engine.EXPECT().Plan(gomock.Any(), gomock.Any(), &RBACOptions{}).Return(&models.RBACPlan{Changes: []models.RBACChange{}}, nil)
+		expectLive()
+
+		result, err := service.Plan(ctx, snapshot, &RestoreOptions{DryRun: true, DeleteUsers: true})
+		require.NoError(t, err)
+
+		actions := make([]string, 0, len(result.Users))
+		for _, change := range result.Users {
+			assert.Empty(t, change.State)
+			actions = append(actions, change.Action)
+		}
+		assert.Equal(t, []string{models.UpdateActionType, models.CreateActionType, models.DeleteActionType}, actions)
+	})
+
+	t.Run("Plan keeps users by default", func(t *testing.T) {
+		engine.EXPECT().Plan(gomock.Any(), gomock.Any(), &RBACOptions{}).Return(&models.RBACPlan{Changes: []models.RBACChange{}}, nil)
+		expectLive()
+
+		result, err := service.Plan(ctx, snapshot, &RestoreOptions{DryRun: true})
+		require.NoError(t, err)
+
+		actions := make([]string, 0, len(result.Users))
+		for _, change := range result.Users {
+			actions = append(actions, change.Action)
+		}
+		assert.Equal(t, []string{models.UpdateActionType, models.CreateActionType}, actions)
+		assert.False(t, result.DeleteUsers)
+		assert.Equal(t, []string{"PNOEE-60001019906"}, result.Retained)
+	})
+}
@@ -2,20 +2,44 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"golang.org/x/sync/errgroup"
 
 	"loki-backoffice/internal/app/errors"
 	"loki-backoffice/internal/app/models"
 	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
 	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/pkg/jwt"
 )
 
 type Tokens interface {
-	List(ctx context.Context, pagination *Pagination) ([]models.Token, uint64, error)
+	List(ctx context.Context, pagination *Pagination, query *Query) ([]models.Token, uint64, error)
+	FindById(ctx context.Context, id uuid.UUID) (*models.Token, error)
 	Delete(ctx context.Context, id uuid.UUID) (bool, error)
+	RevokeAll(ctx context.Context, userId uuid.UUID, tokenType string) (*models.TokenRevocation, error)
+}
+
+const (
+	RevokeConcurrency = 8
+
+	// FingerprintLength is the number of hex characters of the value hash shown instead of the token value
+	FingerprintLength = 16
+)
+
+var TokensQuerySchema = &QuerySchema{
+	Search: []string{"user_id", "type", "fingerprint"},
+	Sort:   []string{"user_id", "type", "expires_at"},
+	Filters: map[string]QueryFilter{
+		"user_id":     UUIDValue,
+		"type":        OneOfValue(models.AccessTokenType, models.RefreshTokenType),
+		"expired":     BoolValue,
+		"fingerprint": AnyValue,
+	},
 }
 
 type tokens struct {
@@ -30,44 +54,56 @@ func NewTokens(client proto.TokenServiceClient, log *logger.Logger) Tokens {
 	}
 }
 
+func (p *tokens) List(ctx context.Context, pagination *Pagination, query *Query) ([]models.Token, uint64, error) {
+	return listWithQuery(ctx, pagination, query, TokensQuerySchema, p.fetch, tokenField)
+}
+
 //nolint:dupl
-func (p *tokens) List(ctx context.Context, pagination *Pagination) ([]models.Token, uint64, error) {
+func (p *tokens) fetch(ctx context.Context, pagination *Pagination) ([]models.Token, uint64, error) {
 	response, err := p.client.List(ctx, &proto.PaginatedListRequest{
-		Limit:  pagination.Page,
-		Offset: pagination.PerPage,
+		Limit:  pagination.Limit(),
+		Offset: pagination.Offset(),
 	})
 	if err != nil {
 		p.log.Error().Err(err).Msg("Failed to fetch tokens")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, 0, errors.ErrInvalidArguments
-			case codes.Unavailable:
-				return nil, 0, errors.ErrFailedToFetchResults
-			case codes.Internal:
-				return nil, 0, errors.ErrFailedToFetchResults
-			}
-		}
-
-		return nil, 0, err
+		return nil, 0, errors.FromGRPC(err, errors.ErrFailedToFetchResults)
 	}
 
 	collection := make([]models.Token, 0, len(response.Data))
 	for _, item := range response.Data {
 		collection = append(collection, models.Token{
-			ID:        uuid.MustParse(item.Id),
-			UserId:    uuid.MustParse(item.UserId),
-			Type:      item.Type,
-			Value:     item.Value,
-			ExpiresAt: item.ExpiresAt.AsTime(),
+			ID:          uuid.MustParse(item.Id),
+			UserId:      uuid.MustParse(item.UserId),
+			Type:        item.Type,
+			Value:       item.Value,
+			Fingerprint: tokenFingerprint(item.Value),
+			Metadata:    tokenMetadata(item.Value),
+			ExpiresAt:   item.ExpiresAt.AsTime(),
 		})
 	}
 
 	return collection, response.Meta.Total, nil
 }
 
+// FindById looks the token up by walking the upstream list, the SSO token service has no Get call
+func (p *tokens) FindById(ctx context.Context, id uuid.UUID) (*models.Token, error) {
+	query := &Query{
+		Filters: map[string]string{"id": id.String()},
+	}
+
+	rows, err := collectWithQuery(ctx, query, TokensQuerySchema, p.fetch, tokenField)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.ErrRecordNotFound
+	}
+
+	return &rows[0], nil
+}
+
 //nolint:dupl
 func (p *tokens) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
 	_, err := p.client.Delete(ctx, &proto.DeleteTokenRequest{
@@ -76,20 +112,108 @@ func (p *tokens) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
 	if err != nil {
 		p.log.Error().Err(err).Str("id", id.String()).Msg("Failed to delete token")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return false, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return false, errors.ErrRecordNotFound
-			case codes.Internal:
-				return false, errors.ErrFailedToDeleteRecord
+		return false, errors.FromGRPC(err, errors.ErrFailedToDeleteRecord)
+	}
+
+	return true, nil
+}
+
+// RevokeAll deletes every token of the user, optionally limited to one token type.
+// Tokens already gone upstream count as revoked.
+func (p *tokens) RevokeAll(ctx context.Context, userId uuid.UUID, tokenType string) (*models.TokenRevocation, error) {
+	query := &Query{
+		Filters: map[string]string{"user_id": userId.String()},
+	}
+
+	if tokenType != "" {
+		if _, ok := TokensQuerySchema.Filters["type"](tokenType); !ok {
+			return nil, errors.ErrInvalidArguments
+		}
+
+		query.Filters["type"] = tokenType
+	}
+
+	rows, err := collectWithQuery(ctx, query, TokensQuerySchema, p.fetch, tokenField)
+	if err != nil {
+		return nil, err
+	}
+
+	failures := make([]error, len(rows))
+
+	group := new(errgroup.Group)
+	group.SetLimit(RevokeConcurrency)
+
+	for i, token := range rows {
+		group.Go(func() error {
+			_, err := p.Delete(ctx, token.ID)
+			if err != nil && !errors.Is(err, errors.ErrRecordNotFound) {
+				failures[i] = err
 			}
+
+			return nil
+		})
+	}
+
+	_ = group.Wait()
+
+	result := &models.TokenRevocation{
+		UserId:  userId,
+		Revoked: make([]models.Token, 0, len(rows)),
+		Failed:  make([]models.TokenRevocationFailure, 0),
+	}
+
+	for i, token := range rows {
+		if failures[i] != nil {
+			result.Failed = append(result.Failed, models.TokenRevocationFailure{Token: token, Error: failures[i]})
+			continue
 		}
 
-		return false, err
+		result.Revoked = append(result.Revoked, token)
 	}
 
-	return true, nil
+	p.log.Info().
+		Str("user_id", userId.String()).
+		Int("revoked", len(result.Revoked)).
+		Int("failed", len(result.Failed)).
+		Msg("Revoked user tokens")
+
+	return result, nil
+}
+
+func tokenField(token models.Token, name string) string {
+	switch name {
+	case "id":
+		return token.ID.String()
+	case "user_id":
+		return token.UserId.String()
+	case "type":
+		return token.Type
+	case "expires_at":
+		return token.ExpiresAt.UTC().Format(sortableTimeLayout)
+	case "expired":
+		return strconv.FormatBool(time.Now().After(token.ExpiresAt))
+	case "fingerprint":
+		return token.Fingerprint
+	default:
+		return ""
+	}
+}
+
+func tokenFingerprint(value string) string {
+	sum := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(sum[:])[:FingerprintLength]
+}
+
+func tokenMetadata(value string) *models.TokenMetadata {
+	metadata, err := jwt.Inspect(value)
+	if err != nil {
+		return nil
+	}
+
+	return &models.TokenMetadata{
+		Issuer:   metadata.Issuer,
+		IssuedAt: metadata.IssuedAt,
+		Scope:    metadata.Scope,
+	}
 }
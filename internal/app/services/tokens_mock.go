@@ -57,10 +57,25 @@ func (mr *MockTokensMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTokens)(nil).Delete), ctx, id)
 }
 
+// FindById mocks base method.
+func (m *MockTokens) FindById(ctx context.Context, id uuid.UUID) (*models.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, id)
+	ret0, _ := ret[0].(*models.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockTokensMockRecorder) FindById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTokens)(nil).FindById), ctx, id)
+}
+
 // List mocks base method.
-func (m *MockTokens) List(ctx context.Context, pagination *Pagination) ([]models.Token, uint64, error) {
+func (m *MockTokens) List(ctx context.Context, pagination *Pagination, query *Query) ([]models.Token, uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, pagination)
+	ret := m.ctrl.Call(m, "List", ctx, pagination, query)
 	ret0, _ := ret[0].([]models.Token)
 	ret1, _ := ret[1].(uint64)
 	ret2, _ := ret[2].(error)
@@ -68,7 +83,22 @@ func (m *MockTokens) List(ctx context.Context, pagination *Pagination) ([]models
 }
 
 // List indicates an expected call of List.
-func (mr *MockTokensMockRecorder) List(ctx, pagination any) *gomock.Call {
+func (mr *MockTokensMockRecorder) List(ctx, pagination, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTokens)(nil).List), ctx, pagination, query)
+}
+
+// RevokeAll mocks base method.
+func (m *MockTokens) RevokeAll(ctx context.Context, userId uuid.UUID, tokenType string) (*models.TokenRevocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", ctx, userId, tokenType)
+	ret0, _ := ret[0].(*models.TokenRevocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockTokensMockRecorder) RevokeAll(ctx, userId, tokenType any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTokens)(nil).List), ctx, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockTokens)(nil).RevokeAll), ctx, userId, tokenType)
 }
@@ -2,12 +2,16 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"testing"
 	"time"
 
+	gojwt "github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -19,6 +23,7 @@ import (
 	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
 	"loki-backoffice/internal/config"
 	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/pkg/jwt"
 )
 
 func Test_Tokens_List(t *testing.T) {
@@ -50,8 +55,8 @@ func Test_Tokens_List(t *testing.T) {
 			name: "Success",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(&proto.ListTokensResponse{
 					Data: []*proto.Token{
 						{
@@ -78,18 +83,20 @@ func Test_Tokens_List(t *testing.T) {
 			},
 			expected: []models.Token{
 				{
-					ID:        uuid.MustParse("10000000-1000-1000-6000-000000000001"),
-					UserId:    uuid.MustParse("10000000-1000-1000-1234-000000000001"),
-					Type:      models.AccessTokenType,
-					Value:     "access-token-value",
-					ExpiresAt: accessTokenExp,
+					ID:          uuid.MustParse("10000000-1000-1000-6000-000000000001"),
+					UserId:      uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+					Type:        models.AccessTokenType,
+					Value:       "access-token-value",
+					Fingerprint: "889813cbebaf2f8a",
+					ExpiresAt:   accessTokenExp,
 				},
 				{
-					ID:        uuid.MustParse("10000000-1000-1000-6000-000000000002"),
-					UserId:    uuid.MustParse("10000000-1000-1000-1234-000000000002"),
-					Type:      models.RefreshTokenType,
-					Value:     "refresh-token-value",
-					ExpiresAt: refreshTokenExp,
+					ID:          uuid.MustParse("10000000-1000-1000-6000-000000000002"),
+					UserId:      uuid.MustParse("10000000-1000-1000-1234-000000000002"),
+					Type:        models.RefreshTokenType,
+					Value:       "refresh-token-value",
+					Fingerprint: "e65009f6e0ae9fc2",
+					ExpiresAt:   refreshTokenExp,
 				},
 			},
 			total: 2,
@@ -99,8 +106,8 @@ func Test_Tokens_List(t *testing.T) {
 			name: "Empty",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(&proto.ListTokensResponse{
 					Data: []*proto.Token{},
 					Meta: &proto.PaginationMeta{
@@ -118,8 +125,8 @@ func Test_Tokens_List(t *testing.T) {
 			name: "InvalidArgument status code",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, status.Error(codes.InvalidArgument, "invalid arguments"))
 			},
 			expected: nil,
@@ -130,20 +137,20 @@ func Test_Tokens_List(t *testing.T) {
 			name: "Unavailable status code",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, status.Error(codes.Unavailable, "service unavailable"))
 			},
 			expected: nil,
 			total:    0,
-			error:    errors.ErrFailedToFetchResults,
+			error:    errors.ErrUnavailable,
 		},
 		{
 			name: "Internal status code",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, status.Error(codes.Internal, "internal error"))
 			},
 			expected: nil,
@@ -154,8 +161,8 @@ func Test_Tokens_List(t *testing.T) {
 			name: "Error",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, assert.AnError)
 			},
 			expected: nil,
@@ -171,7 +178,7 @@ func Test_Tokens_List(t *testing.T) {
 			result, total, err := service.List(ctx, &Pagination{
 				Page:    uint64(1),
 				PerPage: uint64(10),
-			})
+			}, nil)
 
 			if tt.error != nil {
 				assert.Error(t, err)
@@ -186,6 +193,8 @@ func Test_Tokens_List(t *testing.T) {
 					assert.Equal(t, expected.UserId, result[i].UserId)
 					assert.Equal(t, expected.Type, result[i].Type)
 					assert.Equal(t, expected.Value, result[i].Value)
+					assert.Equal(t, expected.Fingerprint, result[i].Fingerprint)
+					assert.Nil(t, result[i].Metadata)
 					assert.Equal(t, expected.ExpiresAt.Unix(), result[i].ExpiresAt.Unix())
 				}
 				assert.Equal(t, tt.total, total)
@@ -194,6 +203,125 @@ func Test_Tokens_List(t *testing.T) {
 	}
 }
 
+func Test_Tokens_FindById(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.Background()
+	mockClient := rpcs.NewMockTokenServiceClient(ctrl)
+	service := NewTokens(mockClient, log)
+
+	issuedAt := time.Now().Truncate(time.Second)
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	accessToken, err := gojwt.NewWithClaims(gojwt.SigningMethodRS256, jwt.Claims{
+		RegisteredClaims: gojwt.RegisteredClaims{
+			Issuer:   "loki",
+			IssuedAt: gojwt.NewNumericDate(issuedAt),
+		},
+		Scope: []string{"sso-service"},
+	}).SignedString(privateKey)
+	assert.NoError(t, err)
+
+	expiresAt := timestamppb.New(time.Now().Add(models.AccessTokenExp))
+
+	tests := []struct {
+		name     string
+		id       uuid.UUID
+		before   func()
+		expected *models.Token
+		error    error
+	}{
+		{
+			name: "Success",
+			id:   uuid.MustParse("10000000-1000-1000-6000-000000000002"),
+			before: func() {
+				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{Limit: 1000, Offset: 0}).Return(&proto.ListTokensResponse{
+					Data: []*proto.Token{
+						{
+							Id:        "10000000-1000-1000-6000-000000000001",
+							UserId:    "10000000-1000-1000-1234-000000000001",
+							Type:      models.RefreshTokenType,
+							Value:     "refresh-token-value",
+							ExpiresAt: expiresAt,
+						},
+						{
+							Id:        "10000000-1000-1000-6000-000000000002",
+							UserId:    "10000000-1000-1000-1234-000000000001",
+							Type:      models.AccessTokenType,
+							Value:     accessToken,
+							ExpiresAt: expiresAt,
+						},
+					},
+					Meta: &proto.PaginationMeta{Page: 1, Per: 1000, Total: 2},
+				}, nil)
+			},
+			expected: &models.Token{
+				ID:          uuid.MustParse("10000000-1000-1000-6000-000000000002"),
+				UserId:      uuid.MustParse("10000000-1000-1000-1234-000000000001"),
+				Type:        models.AccessTokenType,
+				Value:       accessToken,
+				Fingerprint: tokenFingerprint(accessToken),
+				Metadata: &models.TokenMetadata{
+					Issuer:   "loki",
+					IssuedAt: issuedAt,
+					Scope:    []string{"sso-service"},
+				},
+				ExpiresAt: expiresAt.AsTime(),
+			},
+		},
+		{
+			name: "Not found",
+			id:   uuid.MustParse("10000000-1000-1000-6000-000000000003"),
+			before: func() {
+				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{Limit: 1000, Offset: 0}).Return(&proto.ListTokensResponse{
+					Data: []*proto.Token{},
+					Meta: &proto.PaginationMeta{Page: 1, Per: 1000, Total: 0},
+				}, nil)
+			},
+			error: errors.ErrRecordNotFound,
+		},
+		{
+			name: "Unavailable status code",
+			id:   uuid.MustParse("10000000-1000-1000-6000-000000000001"),
+			before: func() {
+				mockClient.EXPECT().List(ctx, gomock.Any()).Return(nil, status.Error(codes.Unavailable, "service unavailable"))
+			},
+			error: errors.ErrUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			result, err := service.FindById(ctx, tt.id)
+
+			if tt.error != nil {
+				assert.Equal(t, tt.error, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Fingerprint, FingerprintLength)
+				assert.Equal(t, tt.expected.Metadata.Issuer, result.Metadata.Issuer)
+				assert.True(t, tt.expected.Metadata.IssuedAt.Equal(result.Metadata.IssuedAt))
+				assert.Equal(t, tt.expected.Metadata.Scope, result.Metadata.Scope)
+
+				result.Metadata = tt.expected.Metadata
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
 func Test_Tokens_Delete(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -286,3 +414,137 @@ func Test_Tokens_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_Tokens_RevokeAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.Background()
+	mockClient := rpcs.NewMockTokenServiceClient(ctrl)
+	service := NewTokens(mockClient, log)
+
+	userId := uuid.MustParse("10000000-1000-1000-1234-000000000001")
+	expiresAt := timestamppb.New(time.Now().Add(models.AccessTokenExp))
+
+	listResponse := &proto.ListTokensResponse{
+		Data: []*proto.Token{
+			{
+				Id:        "10000000-1000-1000-6000-000000000001",
+				UserId:    "10000000-1000-1000-1234-000000000001",
+				Type:      models.AccessTokenType,
+				ExpiresAt: expiresAt,
+			},
+			{
+				Id:        "10000000-1000-1000-6000-000000000002",
+				UserId:    "10000000-1000-1000-1234-000000000001",
+				Type:      models.RefreshTokenType,
+				ExpiresAt: expiresAt,
+			},
+			{
+				Id:        "10000000-1000-1000-6000-000000000003",
+				UserId:    "10000000-1000-1000-1234-000000000002",
+				Type:      models.AccessTokenType,
+				ExpiresAt: expiresAt,
+			},
+		},
+		Meta: &proto.PaginationMeta{
+			Page:  1,
+			Per:   1000,
+			Total: 3,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		tokenType string
+		before    func()
+		revoked   []uuid.UUID
+		failed    []uuid.UUID
+		error     error
+	}{
+		{
+			name: "Success",
+			before: func() {
+				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{Limit: 1000, Offset: 0}).Return(listResponse, nil)
+				mockClient.EXPECT().Delete(ctx, gomock.Any()).Times(2).DoAndReturn(
+					func(_ context.Context, req *proto.DeleteTokenRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+						if req.Id == "10000000-1000-1000-6000-000000000002" {
+							return nil, status.Error(codes.NotFound, "not found")
+						}
+						return &emptypb.Empty{}, nil
+					})
+			},
+			revoked: []uuid.UUID{
+				uuid.MustParse("10000000-1000-1000-6000-000000000001"),
+				uuid.MustParse("10000000-1000-1000-6000-000000000002"),
+			},
+			failed: []uuid.UUID{},
+		},
+		{
+			name:      "Type filter with partial failure",
+			tokenType: models.AccessTokenType,
+			before: func() {
+				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{Limit: 1000, Offset: 0}).Return(listResponse, nil)
+				mockClient.EXPECT().Delete(ctx, gomock.Any()).Times(1).DoAndReturn(
+					func(_ context.Context, req *proto.DeleteTokenRequest, _ ...grpc.CallOption) (*emptypb.Empty, error) {
+						assert.Equal(t, "10000000-1000-1000-6000-000000000001", req.Id)
+						return nil, status.Error(codes.Internal, "internal error")
+					})
+			},
+			revoked: []uuid.UUID{},
+			failed: []uuid.UUID{
+				uuid.MustParse("10000000-1000-1000-6000-000000000001"),
+			},
+		},
+		{
+			name:      "Invalid type",
+			tokenType: "id_token",
+			before:    func() {},
+			error:     errors.ErrInvalidArguments,
+		},
+		{
+			name: "List failure",
+			before: func() {
+				mockClient.EXPECT().List(ctx, gomock.Any()).Return(nil, status.Error(codes.Unavailable, "service unavailable"))
+			},
+			error: errors.ErrUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			result, err := service.RevokeAll(ctx, userId, tt.tokenType)
+
+			if tt.error != nil {
+				assert.Equal(t, tt.error, err)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, userId, result.UserId)
+
+			revoked := make([]uuid.UUID, 0, len(result.Revoked))
+			for _, token := range result.Revoked {
+				revoked = append(revoked, token.ID)
+			}
+			assert.Equal(t, tt.revoked, revoked)
+
+			failed := make([]uuid.UUID, 0, len(result.Failed))
+			for _, failure := range result.Failed {
+				failed = append(failed, failure.Token.ID)
+				assert.Equal(t, errors.ErrFailedToDeleteRecord, failure.Error)
+			}
+			assert.Equal(t, tt.failed, failed)
+		})
+	}
+}
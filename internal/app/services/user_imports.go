@@ -0,0 +1,379 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/internal/config/middlewares"
+)
+
+// UserImportTTL bounds how long a preview can be confirmed and a finished import polled
+const UserImportTTL = time.Hour
+
+// UserImports previews CSV imports of users and applies them in the background once confirmed,
+// imports are kept in memory of the instance that previewed them and are visible to their author only
+type UserImports interface {
+	Preview(ctx context.Context, params *models.UserImport) (*models.UserImport, error)
+	Find(ctx context.Context, id uuid.UUID) (*models.UserImport, error)
+	Confirm(ctx context.Context, id uuid.UUID) (*models.UserImport, error)
+	Shutdown(ctx context.Context) error
+}
+
+type userImports struct {
+	users  Users
+	roles  Roles
+	scopes Scopes
+	audit  Audit
+	index  Index
+	log    *logger.Logger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	imports map[uuid.UUID]*models.UserImport
+
+	// ctx is canceled on shutdown as a stop signal only, running jobs check it before each row
+	// and are awaited through jobs, the rows in flight keep their own context and finish
+	ctx    context.Context
+	cancel context.CancelFunc
+	jobs   sync.WaitGroup
+}
+
+func NewUserImports(users Users, roles Roles, scopes Scopes, audit Audit, index Index, log *logger.Logger) UserImports {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &userImports{
+		users:   users,
+		roles:   roles,
+		scopes:  scopes,
+		audit:   audit,
+		index:   index,
+		log:     log.WithComponent("user_imports"),
+		now:     time.Now,
+		imports: make(map[uuid.UUID]*models.UserImport),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Preview resolves role and scope names to ids and matches identity numbers against the existing
+// users, rows of an unknown identity number are created and the others update the matched user
+func (s *userImports) Preview(ctx context.Context, params *models.UserImport) (*models.UserImport, error) {
+	roleIds, err := s.roleIds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scopeIds, err := s.scopeIds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userIds, err := s.userIds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range params.Rows {
+		row := &params.Rows[i]
+
+		row.Action = models.CreateActionType
+		if id, ok := userIds[strings.ToUpper(row.User.IdentityNumber)]; ok {
+			row.Action = models.UpdateActionType
+			row.User.ID = id
+		}
+
+		row.User.RoleIDs, row.Errors = resolveNames(row.Roles, roleIds, "roles", row.Errors)
+		row.User.ScopeIDs, row.Errors = resolveNames(row.Scopes, scopeIds, "scopes", row.Errors)
+	}
+
+	now := s.now()
+	params.ID = uuid.New()
+	params.Status = models.PendingImportStatus
+	params.CreatedAt = now
+	params.ExpiresAt = now.Add(UserImportTTL)
+	if claim, ok := middlewares.CurrentClaimFromContext(ctx); ok {
+		params.ActorID = claim.ID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire(now)
+	s.imports[params.ID] = params
+
+	return snapshotImport(params), nil
+}
+
+func (s *userImports) Find(ctx context.Context, id uuid.UUID) (*models.UserImport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshotImport(record), nil
+}
+
+// Confirm starts applying a previewed import, only imports without invalid rows can be confirmed
+func (s *userImports) Confirm(ctx context.Context, id uuid.UUID) (*models.UserImport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if record.Status != models.PendingImportStatus {
+		return nil, fmt.Errorf("%w: import is %s", errors.ErrFailedPrecondition, record.Status)
+	}
+
+	if invalid := record.Invalid(); invalid > 0 {
+		return nil, fmt.Errorf("%w: import has %d invalid rows", errors.ErrFailedPrecondition, invalid)
+	}
+
+	if s.ctx.Err() != nil {
+		return nil, errors.ErrUnavailable
+	}
+
+	record.Status = models.RunningImportStatus
+	record.ExpiresAt = s.now().Add(UserImportTTL)
+
+	// the job outlives the request, the context still carries the actor for the audit log
+	jobCtx := context.WithoutCancel(ctx)
+
+	s.jobs.Add(1)
+	go func() {
+		defer s.jobs.Done()
+
+		s.run(jobCtx, record)
+	}()
+
+	return snapshotImport(record), nil
+}
+
+// Shutdown stops running imports from starting further rows and waits for the rows in flight,
+// their upstream calls are not canceled so that applied rows are still audited
+func (s *userImports) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.jobs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *userImports) run(ctx context.Context, record *models.UserImport) {
+	operations := make([]BulkOperation, 0, len(record.Rows))
+	for _, row := range record.Rows {
+		operations = append(operations, s.operation(row.Action, row.User, record))
+	}
+
+	results := RunBulk(ctx, operations, &BulkOptions{})
+	s.index.Invalidate()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	failed := 0
+	unapplied := make([]int, 0)
+	for i, result := range results {
+		record.Rows[i].State = result.State
+		record.Rows[i].Err = result.Err
+		switch {
+		case result.State == BulkAppliedState:
+			record.Rows[i].User.ID = result.ID
+		case errors.Is(result.Err, errors.ErrBulkOperationAborted):
+			record.Rows[i].State = BulkSkippedState
+			unapplied = append(unapplied, record.Rows[i].Line)
+		default:
+			failed++
+		}
+	}
+
+	record.Status = models.CompletedImportStatus
+	record.ExpiresAt = s.now().Add(UserImportTTL)
+
+	if len(unapplied) > 0 {
+		s.log.Warn().
+			Str("id", record.ID.String()).
+			Str("actor_id", record.ActorID).
+			Ints("lines", unapplied).
+			Msg("User import interrupted by shutdown, rows left unapplied")
+		return
+	}
+
+	s.log.Info().Str("id", record.ID.String()).Int("rows", len(results)).Int("failed", failed).Msg("Completed user import")
+}
+
+func (s *userImports) operation(action string, user models.User, record *models.UserImport) BulkOperation {
+	return BulkOperation{
+		Op: action,
+		ID: user.ID,
+		Apply: func(ctx context.Context) (*BulkApplied, error) {
+			// rows not started before shutdown are left for the author to import again
+			if s.ctx.Err() != nil {
+				return nil, errors.ErrBulkOperationAborted
+			}
+
+			if action == models.CreateActionType {
+				created, err := s.users.Create(ctx, &user)
+				if err != nil {
+					return nil, err
+				}
+
+				after := user
+				after.ID = created.ID
+				RecordApplied(ctx, s.audit, s.log, models.CreateActionType, models.UserResourceType, created.ID, nil, after.AuditSnapshot())
+				return &BulkApplied{ID: created.ID}, nil
+			}
+
+			before, err := s.users.FindById(ctx, user.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			// a missing column keeps the current assignments
+			if !record.HasRoles {
+				user.RoleIDs = before.RoleIDs
+			}
+			if !record.HasScopes {
+				user.ScopeIDs = before.ScopeIDs
+			}
+
+			if _, err = s.users.Update(ctx, &user); err != nil {
+				return nil, err
+			}
+
+			RecordApplied(ctx, s.audit, s.log, models.UpdateActionType, models.UserResourceType, user.ID, before.AuditSnapshot(), user.AuditSnapshot())
+			return &BulkApplied{ID: user.ID}, nil
+		},
+	}
+}
+
+// find returns imports of the current actor only, others are reported as missing
+func (s *userImports) find(ctx context.Context, id uuid.UUID) (*models.UserImport, error) {
+	s.expire(s.now())
+
+	record, ok := s.imports[id]
+	if !ok {
+		return nil, errors.ErrRecordNotFound
+	}
+
+	var actorId string
+	if claim, ok := middlewares.CurrentClaimFromContext(ctx); ok {
+		actorId = claim.ID
+	}
+	if record.ActorID != actorId {
+		return nil, errors.ErrRecordNotFound
+	}
+
+	return record, nil
+}
+
+// expire drops the imports past their TTL, running imports are kept until they complete
+func (s *userImports) expire(now time.Time) {
+	for id, record := range s.imports {
+		if record.Status != models.RunningImportStatus && now.After(record.ExpiresAt) {
+			delete(s.imports, id)
+		}
+	}
+}
+
+func (s *userImports) roleIds(ctx context.Context) (map[string]uuid.UUID, error) {
+	rows, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Role, uint64, error) {
+		return s.roles.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]uuid.UUID, len(rows))
+	for _, role := range rows {
+		ids[strings.ToLower(role.Name)] = role.ID
+	}
+
+	return ids, nil
+}
+
+func (s *userImports) scopeIds(ctx context.Context) (map[string]uuid.UUID, error) {
+	rows, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.Scope, uint64, error) {
+		return s.scopes.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]uuid.UUID, len(rows))
+	for _, scope := range rows {
+		ids[strings.ToLower(scope.Name)] = scope.ID
+	}
+
+	return ids, nil
+}
+
+func (s *userImports) userIds(ctx context.Context) (map[string]uuid.UUID, error) {
+	rows, err := walk(ctx, func(ctx context.Context, pagination *Pagination) ([]models.User, uint64, error) {
+		return s.users.List(ctx, pagination, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]uuid.UUID, len(rows))
+	for _, user := range rows {
+		ids[strings.ToUpper(user.IdentityNumber)] = user.ID
+	}
+
+	return ids, nil
+}
+
+// resolveNames maps names case insensitively to ids and appends a field error for every unknown name
+func resolveNames(names []string, ids map[string]uuid.UUID, column string, fields []errors.FieldError) ([]uuid.UUID, []errors.FieldError) {
+	result := make([]uuid.UUID, 0, len(names))
+
+	for i, name := range names {
+		id, ok := ids[strings.ToLower(name)]
+		if !ok {
+			fields = append(fields, errors.FieldError{
+				Pointer: fmt.Sprintf("/%s/%d", column, i),
+				Code:    errors.UnknownNameCode,
+				Detail:  fmt.Sprintf("%s does not exist", name),
+			})
+			continue
+		}
+
+		result = append(result, id)
+	}
+
+	return uniqueIds(result), fields
+}
+
+// snapshotImport copies the import so that callers can read it while the job updates the rows
+func snapshotImport(record *models.UserImport) *models.UserImport {
+	result := *record
+	result.Rows = make([]models.UserImportRow, len(record.Rows))
+	copy(result.Rows, record.Rows)
+
+	return &result
+}
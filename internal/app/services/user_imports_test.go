@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/internal/config/middlewares"
+	"loki-backoffice/pkg/jwt"
+)
+
+func Test_UserImports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	ctx := context.WithValue(context.Background(), middlewares.Claim{}, &jwt.Payload{ID: "actor"})
+	users := NewMockUsers(ctrl)
+	roles := NewMockRoles(ctrl)
+	scopes := NewMockScopes(ctrl)
+	audit := NewMockAudit(ctrl)
+	index := NewMockIndex(ctrl)
+	service := NewUserImports(users, roles, scopes, audit, index, log).(*userImports)
+
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	adminRoleId := uuid.MustParse("10000000-1000-1000-3000-000000000001")
+	scopeId := uuid.MustParse("10000000-1000-1000-2000-000000000001")
+	johnId := uuid.MustParse("10000000-1000-1000-1234-000000000001")
+
+	expectLookups := func() {
+		roles.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Role{{ID: adminRoleId, Name: "admin"}}, uint64(1), nil)
+		scopes.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.Scope{{ID: scopeId, Name: "sso-service"}}, uint64(1), nil)
+		users.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return([]models.User{{ID: johnId, IdentityNumber: "PNOEE-60001017869"}}, uint64(1), nil)
+	}
+
+	rows := func(roles ...string) []models.UserImportRow {
+		return []models.UserImportRow{
+			{
+				Line:   2,
+				User:   models.User{IdentityNumber: "pnoee-60001017869", PersonalCode: "60001017869", FirstName: "John", LastName: "Doe"},
+				Roles:  roles,
+				Scopes: []string{},
+			},
+			{
+				Line:   3,
+				User:   models.User{IdentityNumber: "PNOEE-60001017870", PersonalCode: "60001017870", FirstName: "Jane", LastName: "Doe"},
+				Roles:  []string{"Admin"},
+				Scopes: []string{"sso-service"},
+			},
+		}
+	}
+
+	t.Run("Preview reports unknown names", func(t *testing.T) {
+		expectLookups()
+
+		result, err := service.Preview(ctx, &models.UserImport{Rows: rows("admin", "auditor"), HasRoles: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, models.PendingImportStatus, result.Status)
+		assert.Equal(t, "actor", result.ActorID)
+		assert.Equal(t, now.Add(UserImportTTL), result.ExpiresAt)
+		assert.Equal(t, 1, result.Invalid())
+
+		assert.Equal(t, models.UpdateActionType, result.Rows[0].Action)
+		assert.Equal(t, johnId, result.Rows[0].User.ID)
+		assert.Equal(t, []uuid.UUID{adminRoleId}, result.Rows[0].User.RoleIDs)
+		assert.Equal(t, []errors.FieldError{
+			{Pointer: "/roles/1", Code: errors.UnknownNameCode, Detail: "auditor does not exist"},
+		}, result.Rows[0].Errors)
+
+		assert.Equal(t, models.CreateActionType, result.Rows[1].Action)
+		assert.Equal(t, []uuid.UUID{adminRoleId}, result.Rows[1].User.RoleIDs)
+		assert.Equal(t, []uuid.UUID{scopeId}, result.Rows[1].User.ScopeIDs)
+
+		_, err = service.Confirm(ctx, result.ID)
+		assert.ErrorIs(t, err, errors.ErrFailedPrecondition)
+	})
+
+	t.Run("Confirm applies the import", func(t *testing.T) {
+		expectLookups()
+
+		result, err := service.Preview(ctx, &models.UserImport{Rows: rows("admin"), HasRoles: true})
+		require.NoError(t, err)
+
+		_, err = service.Find(context.Background(), result.ID)
+		assert.ErrorIs(t, err, errors.ErrRecordNotFound)
+
+		done := make(chan struct{})
+		users.EXPECT().FindById(gomock.Any(), johnId).Return(&models.User{ID: johnId, ScopeIDs: []uuid.UUID{scopeId}}, nil)
+		users.EXPECT().Update(gomock.Any(), &models.User{
+			ID:             johnId,
+			IdentityNumber: "pnoee-60001017869",
+			PersonalCode:   "60001017869",
+			FirstName:      "John",
+			LastName:       "Doe",
+			RoleIDs:        []uuid.UUID{adminRoleId},
+			ScopeIDs:       []uuid.UUID{scopeId},
+		}).Return(&models.User{ID: johnId}, nil)
+		users.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.ErrAlreadyExists)
+		audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.UserResourceType, johnId, gomock.Any(), gomock.Any()).Return(nil)
+		index.EXPECT().Invalidate().Do(func() { close(done) })
+
+		confirmed, err := service.Confirm(ctx, result.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.RunningImportStatus, confirmed.Status)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("import did not run")
+		}
+
+		assert.Eventually(t, func() bool {
+			current, err := service.Find(ctx, result.ID)
+			return err == nil && current.Status == models.CompletedImportStatus
+		}, time.Second, time.Millisecond)
+
+		current, err := service.Find(ctx, result.ID)
+		require.NoError(t, err)
+		assert.Equal(t, BulkAppliedState, current.Rows[0].State)
+		assert.Equal(t, BulkFailedState, current.Rows[1].State)
+		assert.ErrorIs(t, current.Rows[1].Err, errors.ErrAlreadyExists)
+
+		_, err = service.Confirm(ctx, result.ID)
+		assert.ErrorIs(t, err, errors.ErrFailedPrecondition)
+
+		now = now.Add(UserImportTTL + time.Second)
+		_, err = service.Find(ctx, result.ID)
+		assert.ErrorIs(t, err, errors.ErrRecordNotFound)
+	})
+
+	t.Run("Lookup error", func(t *testing.T) {
+		roles.EXPECT().List(gomock.Any(), gomock.Any(), nil).Return(nil, uint64(0), errors.ErrUnavailable)
+
+		result, err := service.Preview(ctx, &models.UserImport{Rows: rows()})
+		assert.ErrorIs(t, err, errors.ErrUnavailable)
+		assert.Nil(t, result)
+	})
+
+	t.Run("Shutdown waits for running imports", func(t *testing.T) {
+		expectLookups()
+
+		// one row more than run at once, the last one is queued when shutdown starts
+		params := rows("admin")[:1]
+		for i := range BulkConcurrency {
+			params = append(params, models.UserImportRow{
+				Line:   3 + i,
+				User:   models.User{IdentityNumber: fmt.Sprintf("PNOEE-6000101%04d", i), FirstName: "Jane", LastName: "Doe"},
+				Roles:  []string{"admin"},
+				Scopes: []string{},
+			})
+		}
+
+		result, err := service.Preview(ctx, &models.UserImport{Rows: params, HasRoles: true})
+		require.NoError(t, err)
+
+		started := make(chan struct{}, BulkConcurrency)
+		release := make(chan struct{})
+		blocked := func(ctx context.Context) {
+			started <- struct{}{}
+			<-release
+			assert.NoError(t, ctx.Err())
+		}
+		users.EXPECT().FindById(gomock.Any(), johnId).DoAndReturn(func(ctx context.Context, _ uuid.UUID) (*models.User, error) {
+			blocked(ctx)
+			return &models.User{ID: johnId}, nil
+		})
+		users.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&models.User{ID: johnId}, nil)
+		users.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ *models.User) (*models.User, error) {
+			blocked(ctx)
+			return &models.User{ID: uuid.New()}, nil
+		}).Times(BulkConcurrency - 1)
+		audit.EXPECT().Record(gomock.Any(), models.UpdateActionType, models.UserResourceType, johnId, gomock.Any(), gomock.Any()).Return(nil)
+		audit.EXPECT().Record(gomock.Any(), models.CreateActionType, models.UserResourceType, gomock.Any(), nil, gomock.Any()).Return(nil).Times(BulkConcurrency - 1)
+		index.EXPECT().Invalidate()
+
+		_, err = service.Confirm(ctx, result.ID)
+		require.NoError(t, err)
+
+		for range BulkConcurrency {
+			<-started
+		}
+
+		stopCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		stopped := make(chan error, 1)
+		go func() { stopped <- service.Shutdown(stopCtx) }()
+
+		<-service.ctx.Done()
+		close(release)
+		require.NoError(t, <-stopped)
+
+		current, err := service.Find(ctx, result.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.CompletedImportStatus, current.Status)
+		for _, row := range current.Rows[:BulkConcurrency] {
+			assert.Equal(t, BulkAppliedState, row.State)
+			assert.NoError(t, row.Err)
+		}
+		assert.Equal(t, BulkSkippedState, current.Rows[BulkConcurrency].State)
+		assert.ErrorIs(t, current.Rows[BulkConcurrency].Err, errors.ErrBulkOperationAborted)
+
+		expectLookups()
+
+		result, err = service.Preview(ctx, &models.UserImport{Rows: rows("admin"), HasRoles: true})
+		require.NoError(t, err)
+
+		_, err = service.Confirm(ctx, result.ID)
+		assert.ErrorIs(t, err, errors.ErrUnavailable)
+	})
+}
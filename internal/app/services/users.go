@@ -4,8 +4,6 @@ import (
 	"context"
 
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
 	"loki-backoffice/internal/app/errors"
 	"loki-backoffice/internal/app/models"
@@ -14,47 +12,54 @@ import (
 )
 
 type Users interface {
-	List(ctx context.Context, pagination *Pagination) ([]models.User, uint64, error)
+	List(ctx context.Context, pagination *Pagination, query *Query) ([]models.User, uint64, error)
 	FindById(ctx context.Context, id uuid.UUID) (*models.User, error)
 	Create(ctx context.Context, params *models.User) (*models.User, error)
 	Update(ctx context.Context, params *models.User) (*models.User, error)
 	Delete(ctx context.Context, id uuid.UUID) (bool, error)
 }
 
+var UsersQuerySchema = &QuerySchema{
+	Search: []string{"identity_number", "personal_code", "first_name", "last_name"},
+	Sort:   []string{"identity_number", "personal_code", "first_name", "last_name"},
+	Filters: map[string]QueryFilter{
+		"identity_number": AnyValue,
+		"personal_code":   AnyValue,
+		"first_name":      AnyValue,
+		"last_name":       AnyValue,
+	},
+}
+
 type users struct {
 	client proto.UserServiceClient
+	roles  Roles
+	scopes Scopes
 	log    *logger.Logger
 }
 
-func NewUsers(client proto.UserServiceClient, log *logger.Logger) Users {
+func NewUsers(client proto.UserServiceClient, roles Roles, scopes Scopes, log *logger.Logger) Users {
 	return &users{
 		client: client,
+		roles:  roles,
+		scopes: scopes,
 		log:    log,
 	}
 }
 
+func (p *users) List(ctx context.Context, pagination *Pagination, query *Query) ([]models.User, uint64, error) {
+	return listWithQuery(ctx, pagination, query, UsersQuerySchema, p.fetch, userField)
+}
+
 //nolint:dupl
-func (p *users) List(ctx context.Context, pagination *Pagination) ([]models.User, uint64, error) {
+func (p *users) fetch(ctx context.Context, pagination *Pagination) ([]models.User, uint64, error) {
 	response, err := p.client.List(ctx, &proto.PaginatedListRequest{
-		Limit:  pagination.Page,
-		Offset: pagination.PerPage,
+		Limit:  pagination.Limit(),
+		Offset: pagination.Offset(),
 	})
 	if err != nil {
 		p.log.Error().Err(err).Msg("Failed to fetch users")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, 0, errors.ErrInvalidArguments
-			case codes.Unavailable:
-				return nil, 0, errors.ErrFailedToFetchResults
-			case codes.Internal:
-				return nil, 0, errors.ErrFailedToFetchResults
-			}
-		}
-
-		return nil, 0, err
+		return nil, 0, errors.FromGRPC(err, errors.ErrFailedToFetchResults)
 	}
 
 	collection := make([]models.User, 0, len(response.Data))
@@ -79,19 +84,7 @@ func (p *users) FindById(ctx context.Context, id uuid.UUID) (*models.User, error
 	if err != nil {
 		p.log.Error().Err(err).Str("id", id.String()).Msg("Failed to get user")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return nil, errors.ErrRecordNotFound
-			case codes.Internal:
-				return nil, errors.ErrFailedToFetchResults
-			}
-		}
-
-		return nil, err
+		return nil, errors.FromGRPC(err, errors.ErrFailedToFetchResults)
 	}
 
 	roleIds := make([]uuid.UUID, 0, len(response.Data.RoleIds))
@@ -133,6 +126,10 @@ func (p *users) FindById(ctx context.Context, id uuid.UUID) (*models.User, error
 
 //nolint:dupl
 func (p *users) Create(ctx context.Context, params *models.User) (*models.User, error) {
+	if err := p.checkReferences(ctx, params); err != nil {
+		return nil, err
+	}
+
 	paramsRoleIds := []string{}
 	if params.RoleIDs != nil {
 		paramsRoleIds = make([]string, 0, len(params.RoleIDs))
@@ -160,17 +157,7 @@ func (p *users) Create(ctx context.Context, params *models.User) (*models.User,
 	if err != nil {
 		p.log.Error().Err(err).Str("identity_number", params.IdentityNumber).Msg("Failed to create user")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, errors.ErrInvalidArguments
-			case codes.Internal:
-				return nil, errors.ErrFailedToCreateRecord
-			}
-		}
-
-		return nil, err
+		return nil, errors.FromGRPC(err, errors.ErrFailedToCreateRecord)
 	}
 
 	return &models.User{
@@ -184,6 +171,10 @@ func (p *users) Create(ctx context.Context, params *models.User) (*models.User,
 
 //nolint:dupl
 func (p *users) Update(ctx context.Context, params *models.User) (*models.User, error) {
+	if err := p.checkReferences(ctx, params); err != nil {
+		return nil, err
+	}
+
 	paramsRoleIds := []string{}
 	if params.RoleIDs != nil {
 		paramsRoleIds = make([]string, 0, len(params.RoleIDs))
@@ -212,19 +203,7 @@ func (p *users) Update(ctx context.Context, params *models.User) (*models.User,
 	if err != nil {
 		p.log.Error().Err(err).Str("id", params.ID.String()).Msg("Failed to update user")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return nil, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return nil, errors.ErrRecordNotFound
-			case codes.Internal:
-				return nil, errors.ErrFailedToUpdateRecord
-			}
-		}
-
-		return nil, err
+		return nil, errors.FromGRPC(err, errors.ErrFailedToUpdateRecord)
 	}
 
 	return &models.User{
@@ -244,20 +223,32 @@ func (p *users) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
 	if err != nil {
 		p.log.Error().Err(err).Str("id", id.String()).Msg("Failed to delete user")
 
-		st, ok := status.FromError(err)
-		if ok {
-			switch st.Code() {
-			case codes.InvalidArgument:
-				return false, errors.ErrInvalidArguments
-			case codes.NotFound:
-				return false, errors.ErrRecordNotFound
-			case codes.Internal:
-				return false, errors.ErrFailedToDeleteRecord
-			}
-		}
-
-		return false, err
+		return false, errors.FromGRPC(err, errors.ErrFailedToDeleteRecord)
 	}
 
 	return true, nil
 }
+
+func userField(user models.User, name string) string {
+	switch name {
+	case "id":
+		return user.ID.String()
+	case "identity_number":
+		return user.IdentityNumber
+	case "personal_code":
+		return user.PersonalCode
+	case "first_name":
+		return user.FirstName
+	case "last_name":
+		return user.LastName
+	default:
+		return ""
+	}
+}
+
+func (p *users) checkReferences(ctx context.Context, params *models.User) error {
+	return checkReferences(ctx,
+		referenceOf(RoleIdsField, params.RoleIDs, p.roles.FindById),
+		referenceOf(ScopeIdsField, params.ScopeIDs, p.scopes.FindById),
+	)
+}
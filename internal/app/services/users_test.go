@@ -32,7 +32,7 @@ func Test_Users_List(t *testing.T) {
 
 	ctx := context.Background()
 	mockClient := rpcs.NewMockUserServiceClient(ctrl)
-	service := NewUsers(mockClient, log)
+	service := NewUsers(mockClient, NewMockRoles(ctrl), NewMockScopes(ctrl), log)
 
 	tests := []struct {
 		name     string
@@ -45,8 +45,8 @@ func Test_Users_List(t *testing.T) {
 			name: "Success",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(&proto.ListUsersResponse{
 					Data: []*proto.User{
 						{
@@ -94,8 +94,8 @@ func Test_Users_List(t *testing.T) {
 			name: "Empty",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(&proto.ListUsersResponse{
 					Data: []*proto.User{},
 					Meta: &proto.PaginationMeta{
@@ -113,8 +113,8 @@ func Test_Users_List(t *testing.T) {
 			name: "InvalidArgument status code",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, status.Error(codes.InvalidArgument, "invalid arguments"))
 			},
 			expected: nil,
@@ -125,20 +125,20 @@ func Test_Users_List(t *testing.T) {
 			name: "Unavailable status code",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, status.Error(codes.Unavailable, "service unavailable"))
 			},
 			expected: nil,
 			total:    0,
-			error:    errors.ErrFailedToFetchResults,
+			error:    errors.ErrUnavailable,
 		},
 		{
 			name: "Internal status code",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, status.Error(codes.Internal, "internal error"))
 			},
 			expected: nil,
@@ -149,8 +149,8 @@ func Test_Users_List(t *testing.T) {
 			name: "Error",
 			before: func() {
 				mockClient.EXPECT().List(ctx, &proto.PaginatedListRequest{
-					Limit:  uint64(1),
-					Offset: uint64(10),
+					Limit:  uint64(10),
+					Offset: uint64(0),
 				}).Return(nil, assert.AnError)
 			},
 			expected: nil,
@@ -166,7 +166,7 @@ func Test_Users_List(t *testing.T) {
 			result, total, err := service.List(ctx, &Pagination{
 				Page:    uint64(1),
 				PerPage: uint64(10),
-			})
+			}, nil)
 
 			if tt.error != nil {
 				assert.Error(t, err)
@@ -195,7 +195,7 @@ func Test_Users_FindById(t *testing.T) {
 
 	ctx := context.Background()
 	mockClient := rpcs.NewMockUserServiceClient(ctrl)
-	service := NewUsers(mockClient, log)
+	service := NewUsers(mockClient, NewMockRoles(ctrl), NewMockScopes(ctrl), log)
 
 	id := uuid.MustParse("10000000-1000-1000-1234-000000000001")
 	roleIds := []uuid.UUID{
@@ -314,7 +314,15 @@ func Test_Users_Create(t *testing.T) {
 
 	ctx := context.Background()
 	mockClient := rpcs.NewMockUserServiceClient(ctrl)
-	service := NewUsers(mockClient, log)
+	roles := NewMockRoles(ctrl)
+	roles.EXPECT().FindById(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, id uuid.UUID) (*models.Role, error) {
+		return &models.Role{ID: id}, nil
+	})
+	scopes := NewMockScopes(ctrl)
+	scopes.EXPECT().FindById(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, id uuid.UUID) (*models.Scope, error) {
+		return &models.Scope{ID: id}, nil
+	})
+	service := NewUsers(mockClient, roles, scopes, log)
 
 	id := uuid.MustParse("10000000-1000-1000-1234-000000000001")
 	roleIds := []uuid.UUID{
@@ -448,7 +456,15 @@ func Test_Users_Update(t *testing.T) {
 
 	ctx := context.Background()
 	mockClient := rpcs.NewMockUserServiceClient(ctrl)
-	service := NewUsers(mockClient, log)
+	roles := NewMockRoles(ctrl)
+	roles.EXPECT().FindById(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, id uuid.UUID) (*models.Role, error) {
+		return &models.Role{ID: id}, nil
+	})
+	scopes := NewMockScopes(ctrl)
+	scopes.EXPECT().FindById(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, id uuid.UUID) (*models.Scope, error) {
+		return &models.Scope{ID: id}, nil
+	})
+	service := NewUsers(mockClient, roles, scopes, log)
 
 	id := uuid.MustParse("10000000-1000-1000-1234-000000000001")
 	roleIds := []uuid.UUID{
@@ -603,7 +619,7 @@ func Test_Users_Delete(t *testing.T) {
 
 	ctx := context.Background()
 	mockClient := rpcs.NewMockUserServiceClient(ctrl)
-	service := NewUsers(mockClient, log)
+	service := NewUsers(mockClient, NewMockRoles(ctrl), NewMockScopes(ctrl), log)
 
 	id := uuid.MustParse("10000000-1000-1000-1234-000000000001")
 
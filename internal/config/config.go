@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -12,6 +13,9 @@ const (
 	AppAddr    = "0.0.0.0:8081"
 	ClientURL  = "http://localhost:3001"
 	DebugLevel = "debug"
+
+	// SnapshotRetention is how many stored snapshots are kept by default
+	SnapshotRetention = 30
 )
 
 type Config struct {
@@ -22,8 +26,11 @@ type Config struct {
 	ClientURL    string
 	CertPath     string
 	DatabaseDSN  string
+	CursorSecret string
 	TelemetryURI string
 	LogLevel     string
+
+	SnapshotRetention int
 }
 
 func LoadConfig() *Config {
@@ -61,6 +68,10 @@ func LoadConfig() *Config {
 		DatabaseDSN:  getFlagOrEnvString(*flagDatabaseDSN, "DATABASE_DSN", ""),
 		TelemetryURI: getFlagOrEnvString(*flagTelemetryURI, "TELEMETRY_URI", ""),
 
+		CursorSecret: getEnvString("CURSOR_SECRET"),
+
+		SnapshotRetention: getEnvInt("SNAPSHOT_RETENTION", SnapshotRetention),
+
 		LogLevel: getEnvString("LOG_LEVEL"),
 	}
 }
@@ -84,3 +95,13 @@ func getEnvString(envVar string) string {
 
 	return ""
 }
+
+func getEnvInt(envVar string, defaultValue int) int {
+	if envValue, ok := os.LookupEnv(envVar); ok && envValue != "" {
+		if value, err := strconv.Atoi(envValue); err == nil && value > 0 {
+			return value
+		}
+	}
+
+	return defaultValue
+}
@@ -1,10 +1,10 @@
 package middlewares
 
 import (
-	"encoding/json"
 	"net/http"
 	"strings"
 
+	"loki-backoffice/internal/app/errors"
 	"loki-backoffice/internal/app/serializers"
 	"loki-backoffice/internal/config/logger"
 	"loki-backoffice/pkg/jwt"
@@ -31,15 +31,14 @@ func (m *authenticationMiddleware) Authenticate(next http.Handler) http.Handler
 		token, ok := extractBearerToken(r)
 		if !ok {
 			m.log.Error().Msg("Invalid authorization header")
-			w.WriteHeader(http.StatusUnauthorized)
+			serializers.WriteProblem(w, r, errors.ErrUnauthorized)
 			return
 		}
 
 		claims, err := m.jwt.Decode(token)
 		if err != nil {
 			m.log.Error().Err(err).Msg("Failed to decode token")
-			w.WriteHeader(http.StatusUnauthorized)
-			_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: err.Error()})
+			serializers.WriteProblem(w, r, err)
 			return
 		}
 
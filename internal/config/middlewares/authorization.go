@@ -1,7 +1,6 @@
 package middlewares
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"loki-backoffice/internal/app/errors"
@@ -30,15 +29,13 @@ func (m *authorizationMiddleware) Check(permission string) func(http.Handler) ht
 			claim, ok := CurrentClaimFromContext(r.Context())
 			if !ok {
 				m.log.Error().Msg("No claims found in context")
-				w.WriteHeader(http.StatusUnauthorized)
-				_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrUnauthorized.Error()})
+				serializers.WriteProblem(w, r, errors.ErrUnauthorized)
 				return
 			}
 
 			if !rbac.HasPermission(claim.Permissions, permission) {
 				m.log.Warn().Msgf("User %s does not have required permission: %s", claim.ID, permission)
-				w.WriteHeader(http.StatusForbidden)
-				_ = json.NewEncoder(w).Encode(serializers.ErrorSerializer{Error: errors.ErrForbidden.Error()})
+				serializers.WriteProblem(w, r, errors.ErrForbidden)
 				return
 			}
 
@@ -0,0 +1,234 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/repositories"
+	"loki-backoffice/internal/app/serializers"
+	"loki-backoffice/internal/config/logger"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	IdempotencyKeyMaxLength  = 255
+	IdempotencyKeyTTL        = 24 * time.Hour
+	// IdempotencyKeyLease bounds how long a pending key blocks retries when its request never finishes,
+	// it is renewed every third of the lease while the handler runs
+	IdempotencyKeyLease = time.Minute
+	// IdempotencyMaxBodySize caps the body buffered, hashed and stored for a key
+	IdempotencyMaxBodySize     = 1 << 20
+	idempotencyCompleteTimeout = 5 * time.Second
+)
+
+type IdempotencyMiddleware interface {
+	Idempotent(next http.Handler) http.Handler
+}
+
+type idempotencyMiddleware struct {
+	repository repositories.IdempotencyRepository
+	log        *logger.Logger
+	lease      time.Duration
+}
+
+func NewIdempotencyMiddleware(repository repositories.IdempotencyRepository, log *logger.Logger) IdempotencyMiddleware {
+	return &idempotencyMiddleware{
+		repository: repository,
+		log:        log,
+		lease:      IdempotencyKeyLease,
+	}
+}
+
+// Idempotent replays the stored response when a request is retried with the same Idempotency-Key,
+// keys are scoped to the actor and route and requests without the header pass through
+func (m *idempotencyMiddleware) Idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > IdempotencyKeyMaxLength {
+			m.log.Error().Int("length", len(key)).Msg("Idempotency key too long")
+			serializers.WriteProblem(w, r, errors.ErrInvalidArguments)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, IdempotencyMaxBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				m.log.Error().Int64("limit", tooLarge.Limit).Msg("Request body too large")
+				serializers.WriteProblem(w, r, errors.ErrRequestTooLarge)
+				return
+			}
+
+			m.log.Error().Err(err).Msg("Failed to read request body")
+			serializers.WriteProblem(w, r, errors.ErrMalformedBody)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.Sum256(body)
+		record := &models.IdempotencyKey{
+			Key:         key,
+			Route:       r.Method + " " + r.URL.Path,
+			RequestHash: hex.EncodeToString(sum[:]),
+			Owner:       uuid.New(),
+		}
+		if claim, ok := CurrentClaimFromContext(r.Context()); ok {
+			record.ActorID = claim.ID
+		}
+
+		reserved, err := m.repository.Reserve(r.Context(), record, IdempotencyKeyTTL, m.lease)
+		if err != nil {
+			m.log.Error().Err(err).Str("key", key).Msg("Failed to reserve idempotency key")
+			serializers.WriteProblem(w, r, errors.ErrUnavailable)
+			return
+		}
+
+		if !reserved {
+			m.replay(w, r, record)
+			return
+		}
+
+		var response bytes.Buffer
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		ww.Tee(&response)
+
+		// Deferred so that a panicking handler releases the key before the recoverer answers
+		settled := false
+		defer func() {
+			if settled {
+				return
+			}
+
+			ctx, cancel := m.completeContext(r)
+			defer cancel()
+
+			if err := m.repository.Release(ctx, record); err != nil {
+				m.log.Error().Err(err).Str("key", key).Msg("Failed to release idempotency key")
+			}
+		}()
+
+		stop := m.renew(r, record)
+		defer stop()
+
+		next.ServeHTTP(ww, r)
+		stop()
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		// Server errors are not final, a retry should reach the handler again
+		if status >= http.StatusInternalServerError {
+			return
+		}
+
+		settled = true
+		record.StatusCode = status
+		record.ContentType = ww.Header().Get("Content-Type")
+		record.Body = response.Bytes()
+
+		ctx, cancel := m.completeContext(r)
+		defer cancel()
+
+		if err = m.repository.Complete(ctx, record); err != nil {
+			m.log.Error().Err(err).Str("key", key).Msg("Failed to store idempotent response")
+		}
+	})
+}
+
+// renew extends the lease until the returned func is called, so that a retry
+// cannot reclaim the key of a request that is still running
+func (m *idempotencyMiddleware) renew(r *http.Request, record *models.IdempotencyKey) func() {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(r.Context()))
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(m.lease / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			renewed, err := m.repository.Renew(ctx, record, m.lease)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				m.log.Error().Err(err).Str("key", record.Key).Msg("Failed to renew idempotency key")
+				continue
+			}
+			if !renewed {
+				m.log.Warn().Str("key", record.Key).Msg("Idempotency key reclaimed while its request was running")
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// completeContext outlives a canceled request so that retries still find the outcome
+func (m *idempotencyMiddleware) completeContext(r *http.Request) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(r.Context()), idempotencyCompleteTimeout)
+}
+
+func (m *idempotencyMiddleware) replay(w http.ResponseWriter, r *http.Request, record *models.IdempotencyKey) {
+	stored, err := m.repository.Find(r.Context(), record.Key, record.ActorID, record.Route)
+	if err != nil {
+		m.log.Error().Err(err).Str("key", record.Key).Msg("Failed to load idempotency key")
+
+		// The reservation was released or swept in between, a retry can claim the key again
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			err = errors.ErrIdempotencyKeyInProgress
+		} else {
+			err = errors.ErrUnavailable
+		}
+
+		serializers.WriteProblem(w, r, err)
+		return
+	}
+
+	if stored.RequestHash != record.RequestHash {
+		m.log.Warn().Str("key", record.Key).Msg("Idempotency key reused with a different request")
+		serializers.WriteProblem(w, r, errors.ErrIdempotencyKeyReused)
+		return
+	}
+
+	if stored.IsPending() {
+		serializers.WriteProblem(w, r, errors.ErrIdempotencyKeyInProgress)
+		return
+	}
+
+	if stored.ContentType != "" {
+		w.Header().Set("Content-Type", stored.ContentType)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(stored.StatusCode)
+	_, _ = w.Write(stored.Body)
+}
@@ -0,0 +1,297 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"loki-backoffice/internal/app/errors"
+	"loki-backoffice/internal/app/models"
+	"loki-backoffice/internal/app/repositories"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/pkg/jwt"
+)
+
+func Test_NewIdempotencyMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	middleware := NewIdempotencyMiddleware(repositories.NewMockIdempotencyRepository(ctrl), log)
+	assert.NotNil(t, middleware)
+}
+
+func Test_IdempotencyMiddleware_Idempotent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	repository := repositories.NewMockIdempotencyRepository(ctrl)
+	middleware := NewIdempotencyMiddleware(repository, log)
+
+	body := `{"name":"admin"}`
+	sum := sha256.Sum256([]byte(body))
+	hash := hex.EncodeToString(sum[:])
+
+	record := &models.IdempotencyKey{
+		Key:         "key-1",
+		ActorID:     "test-user",
+		Route:       "POST /api/roles",
+		RequestHash: hash,
+	}
+	reservation := gomock.Cond(func(x *models.IdempotencyKey) bool {
+		return x.Key == record.Key && x.ActorID == record.ActorID && x.Route == record.Route &&
+			x.RequestHash == record.RequestHash && x.Owner != uuid.Nil
+	})
+
+	type result struct {
+		status   int
+		body     string
+		replayed bool
+		calls    int
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		body     string
+		status   int
+		before   func()
+		expected result
+	}{
+		{
+			name:   "Without key",
+			status: http.StatusCreated,
+			before: func() {},
+			expected: result{
+				status: http.StatusCreated,
+				body:   `{"id":"1"}`,
+				calls:  1,
+			},
+		},
+		{
+			name:   "Stores first response",
+			key:    "key-1",
+			status: http.StatusCreated,
+			before: func() {
+				repository.EXPECT().Reserve(gomock.Any(), reservation, IdempotencyKeyTTL, IdempotencyKeyLease).Return(true, nil)
+				repository.EXPECT().Complete(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, params *models.IdempotencyKey) error {
+						assert.Equal(t, http.StatusCreated, params.StatusCode)
+						assert.Equal(t, "application/json", params.ContentType)
+						assert.Equal(t, `{"id":"1"}`, string(params.Body))
+						return nil
+					})
+			},
+			expected: result{
+				status: http.StatusCreated,
+				body:   `{"id":"1"}`,
+				calls:  1,
+			},
+		},
+		{
+			name:   "Releases key after server error",
+			key:    "key-1",
+			status: http.StatusServiceUnavailable,
+			before: func() {
+				repository.EXPECT().Reserve(gomock.Any(), reservation, IdempotencyKeyTTL, IdempotencyKeyLease).Return(true, nil)
+				repository.EXPECT().Release(gomock.Any(), reservation).Return(nil)
+			},
+			expected: result{
+				status: http.StatusServiceUnavailable,
+				body:   `{"id":"1"}`,
+				calls:  1,
+			},
+		},
+		{
+			name:   "Releases key after panic",
+			key:    "key-1",
+			status: -1,
+			before: func() {
+				repository.EXPECT().Reserve(gomock.Any(), reservation, IdempotencyKeyTTL, IdempotencyKeyLease).Return(true, nil)
+				repository.EXPECT().Release(gomock.Any(), reservation).Return(nil)
+			},
+			expected: result{
+				status: http.StatusInternalServerError,
+				calls:  1,
+			},
+		},
+		{
+			name: "Replays stored response",
+			key:  "key-1",
+			before: func() {
+				repository.EXPECT().Reserve(gomock.Any(), reservation, IdempotencyKeyTTL, IdempotencyKeyLease).Return(false, nil)
+				repository.EXPECT().Find(gomock.Any(), "key-1", "test-user", "POST /api/roles").Return(&models.IdempotencyKey{
+					RequestHash: hash,
+					StatusCode:  http.StatusCreated,
+					ContentType: "application/json",
+					Body:        []byte(`{"id":"stored"}`),
+				}, nil)
+			},
+			expected: result{
+				status:   http.StatusCreated,
+				body:     `{"id":"stored"}`,
+				replayed: true,
+			},
+		},
+		{
+			name: "Key reused with different body",
+			key:  "key-1",
+			before: func() {
+				repository.EXPECT().Reserve(gomock.Any(), reservation, IdempotencyKeyTTL, IdempotencyKeyLease).Return(false, nil)
+				repository.EXPECT().Find(gomock.Any(), "key-1", "test-user", "POST /api/roles").Return(&models.IdempotencyKey{
+					RequestHash: "other",
+					StatusCode:  http.StatusCreated,
+				}, nil)
+			},
+			expected: result{status: http.StatusUnprocessableEntity},
+		},
+		{
+			name: "Request in progress",
+			key:  "key-1",
+			before: func() {
+				repository.EXPECT().Reserve(gomock.Any(), reservation, IdempotencyKeyTTL, IdempotencyKeyLease).Return(false, nil)
+				repository.EXPECT().Find(gomock.Any(), "key-1", "test-user", "POST /api/roles").Return(&models.IdempotencyKey{
+					RequestHash: hash,
+				}, nil)
+			},
+			expected: result{status: http.StatusConflict},
+		},
+		{
+			name: "Reservation error",
+			key:  "key-1",
+			before: func() {
+				repository.EXPECT().Reserve(gomock.Any(), reservation, IdempotencyKeyTTL, IdempotencyKeyLease).Return(false, errors.ErrFailedToFetchResults)
+			},
+			expected: result{status: http.StatusServiceUnavailable},
+		},
+		{
+			name:     "Key too long",
+			key:      strings.Repeat("k", IdempotencyKeyMaxLength+1),
+			before:   func() {},
+			expected: result{status: http.StatusBadRequest},
+		},
+		{
+			name:     "Body too large",
+			key:      "key-1",
+			body:     strings.Repeat("x", IdempotencyMaxBodySize+1),
+			before:   func() {},
+			expected: result{status: http.StatusRequestEntityTooLarge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.before()
+
+			calls := 0
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if tt.status < 0 {
+					panic("handler failed")
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"id":"1"}`))
+			})
+
+			requestBody := body
+			if tt.body != "" {
+				requestBody = tt.body
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/roles", strings.NewReader(requestBody))
+			if tt.key != "" {
+				req.Header.Set(IdempotencyKeyHeader, tt.key)
+			}
+			ctx := context.WithValue(req.Context(), Claim{}, &jwt.Payload{ID: "test-user"})
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			func() {
+				defer func() {
+					if recover() != nil {
+						rr.WriteHeader(http.StatusInternalServerError)
+					}
+				}()
+				middleware.Idempotent(handler).ServeHTTP(rr, req)
+			}()
+
+			assert.Equal(t, tt.expected.status, rr.Code)
+			assert.Equal(t, tt.expected.calls, calls)
+			if tt.expected.body != "" {
+				assert.Equal(t, tt.expected.body, rr.Body.String())
+			}
+			assert.Equal(t, tt.expected.replayed, rr.Header().Get(IdempotentReplayedHeader) == "true")
+		})
+	}
+}
+
+func Test_IdempotencyMiddleware_RenewsLease(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := &config.Config{
+		AppEnv:   "test",
+		AppAddr:  "localhost:8080",
+		LogLevel: "info",
+	}
+	log := logger.NewLogger(cfg)
+
+	repository := repositories.NewMockIdempotencyRepository(ctrl)
+	middleware := &idempotencyMiddleware{repository: repository, log: log, lease: 30 * time.Millisecond}
+
+	var owner uuid.UUID
+	repository.EXPECT().Reserve(gomock.Any(), gomock.Any(), IdempotencyKeyTTL, 30*time.Millisecond).
+		DoAndReturn(func(_ context.Context, params *models.IdempotencyKey, _, _ time.Duration) (bool, error) {
+			owner = params.Owner
+			return true, nil
+		})
+	repository.EXPECT().Renew(gomock.Any(), gomock.Any(), 30*time.Millisecond).
+		DoAndReturn(func(_ context.Context, params *models.IdempotencyKey, _ time.Duration) (bool, error) {
+			assert.Equal(t, owner, params.Owner)
+			return true, nil
+		}).MinTimes(2)
+	repository.EXPECT().Complete(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, params *models.IdempotencyKey) error {
+			assert.Equal(t, owner, params.Owner)
+			return nil
+		})
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/users/bulk", strings.NewReader(`[]`))
+	req.Header.Set(IdempotencyKeyHeader, "key-1")
+	req = req.WithContext(context.WithValue(req.Context(), Claim{}, &jwt.Payload{ID: "test-user"}))
+
+	rr := httptest.NewRecorder()
+	middleware.Idempotent(handler).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.NotEqual(t, uuid.Nil, owner)
+}
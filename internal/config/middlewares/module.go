@@ -7,4 +7,5 @@ var Module = fx.Options(
 	fx.Provide(NewAuthorizationMiddleware),
 	fx.Provide(NewTelemetryMiddleware),
 	fx.Provide(NewLoggerMiddleware),
+	fx.Provide(NewIdempotencyMiddleware),
 )
@@ -8,6 +8,7 @@ import (
 	"github.com/go-chi/cors"
 
 	"loki-backoffice/internal/app/controllers"
+	"loki-backoffice/internal/app/models"
 	"loki-backoffice/internal/config"
 	"loki-backoffice/internal/config/middlewares"
 	"loki-backoffice/pkg/rbac"
@@ -20,14 +21,20 @@ func NewRouter(
 	authorization middlewares.AuthorizationMiddleware,
 	telemetry middlewares.TelemetryMiddleware,
 	logger middlewares.LoggerMiddleware,
+	idempotency middlewares.IdempotencyMiddleware,
 
 	health controllers.HealthController,
 
+	audit controllers.AuditController,
+	exports controllers.ExportsController,
 	permissions controllers.PermissionsController,
+	rbacConfig controllers.RBACController,
 	roles controllers.RolesController,
 	scopes controllers.ScopesController,
+	snapshots controllers.SnapshotsController,
 	tokens controllers.TokensController,
 	users controllers.UsersController,
+	userImports controllers.UserImportsController,
 ) http.Handler {
 	r := chi.NewRouter()
 
@@ -40,7 +47,8 @@ func NewRouter(
 		cors.Handler(cors.Options{
 			AllowedOrigins: []string{"http://*", cfg.ClientURL},
 			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-			AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-Request-ID", "X-Trace-ID"},
+			AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "Idempotency-Key", "If-Match", "If-None-Match", "X-Request-ID", "X-Trace-ID"},
+			ExposedHeaders: []string{"ETag", "Idempotent-Replayed"},
 			MaxAge:         300,
 		}),
 	)
@@ -51,32 +59,80 @@ func NewRouter(
 	r.Group(func(r chi.Router) {
 		r.Use(authentication.Authenticate)
 		r.Route("/api/backoffice", func(r chi.Router) {
+			r.With(authorization.Check(rbac.ReadAudit)).Get("/audit", audit.List)
+
 			r.With(authorization.Check(rbac.ReadPermissions)).Get("/permissions", permissions.List)
+			r.With(authorization.Check(rbac.ReadPermissions)).Get("/permissions/export", exports.Permissions)
 			r.With(authorization.Check(rbac.ReadPermissions)).Get("/permissions/{id}", permissions.Get)
-			r.With(authorization.Check(rbac.WritePermissions)).Post("/permissions", permissions.Create)
+			r.With(authorization.Check(rbac.ReadAudit)).Get("/permissions/{id}/history", audit.History(models.PermissionResourceType))
+			r.With(authorization.Check(rbac.ReadPermissions), authorization.Check(rbac.ReadRoles)).Get("/permissions/{id}/roles", permissions.Roles)
+			r.With(authorization.Check(rbac.WritePermissions), idempotency.Idempotent).Post("/permissions", permissions.Create)
+			r.With(authorization.Check(rbac.WritePermissions), idempotency.Idempotent).Post("/permissions/bulk", permissions.Bulk)
 			r.With(authorization.Check(rbac.WritePermissions)).Put("/permissions/{id}", permissions.Update)
+			r.With(authorization.Check(rbac.WritePermissions)).Patch("/permissions/{id}", permissions.Patch)
 			r.With(authorization.Check(rbac.WritePermissions)).Delete("/permissions/{id}", permissions.Delete)
 
+			r.With(authorization.Check(rbac.ReadPermissions), authorization.Check(rbac.ReadRoles), authorization.Check(rbac.ReadScopes), authorization.Check(rbac.ReadUsers)).Post("/rbac/plan", rbacConfig.Plan)
+			r.With(authorization.Check(rbac.ReadUsers), authorization.Check(rbac.WritePermissions), authorization.Check(rbac.WriteRoles), authorization.Check(rbac.WriteScopes)).Post("/rbac/apply", rbacConfig.Apply)
+
 			r.With(authorization.Check(rbac.ReadRoles)).Get("/roles", roles.List)
+			r.With(authorization.Check(rbac.ReadRoles)).Get("/roles/export", exports.Roles)
 			r.With(authorization.Check(rbac.ReadRoles)).Get("/roles/{id}", roles.Get)
-			r.With(authorization.Check(rbac.WriteRoles)).Post("/roles", roles.Create)
+			r.With(authorization.Check(rbac.ReadAudit)).Get("/roles/{id}/history", audit.History(models.RoleResourceType))
+			r.With(authorization.Check(rbac.ReadRoles), authorization.Check(rbac.ReadUsers)).Get("/roles/{id}/users", roles.Users)
+			r.With(authorization.Check(rbac.WriteRoles), idempotency.Idempotent).Post("/roles", roles.Create)
+			r.With(authorization.Check(rbac.WriteRoles), idempotency.Idempotent).Post("/roles/bulk", roles.Bulk)
 			r.With(authorization.Check(rbac.WriteRoles)).Put("/roles/{id}", roles.Update)
+			r.With(authorization.Check(rbac.WriteRoles)).Patch("/roles/{id}", roles.Patch)
 			r.With(authorization.Check(rbac.WriteRoles)).Delete("/roles/{id}", roles.Delete)
 
 			r.With(authorization.Check(rbac.ReadScopes)).Get("/scopes", scopes.List)
 			r.With(authorization.Check(rbac.ReadScopes)).Get("/scopes/{id}", scopes.Get)
-			r.With(authorization.Check(rbac.WriteScopes)).Post("/scopes", scopes.Create)
+			r.With(authorization.Check(rbac.ReadAudit)).Get("/scopes/{id}/history", audit.History(models.ScopeResourceType))
+			r.With(authorization.Check(rbac.ReadScopes), authorization.Check(rbac.ReadUsers)).Get("/scopes/{id}/users", scopes.Users)
+			r.With(authorization.Check(rbac.WriteScopes), idempotency.Idempotent).Post("/scopes", scopes.Create)
 			r.With(authorization.Check(rbac.WriteScopes)).Put("/scopes/{id}", scopes.Update)
+			r.With(authorization.Check(rbac.WriteScopes)).Patch("/scopes/{id}", scopes.Patch)
 			r.With(authorization.Check(rbac.WriteScopes)).Delete("/scopes/{id}", scopes.Delete)
 
+			readAll := []func(http.Handler) http.Handler{
+				authorization.Check(rbac.ReadPermissions),
+				authorization.Check(rbac.ReadRoles),
+				authorization.Check(rbac.ReadScopes),
+				authorization.Check(rbac.ReadUsers),
+			}
+			writeAll := []func(http.Handler) http.Handler{
+				authorization.Check(rbac.WritePermissions),
+				authorization.Check(rbac.WriteRoles),
+				authorization.Check(rbac.WriteScopes),
+				authorization.Check(rbac.WriteUsers),
+			}
+			r.With(readAll...).Get("/snapshots", snapshots.List)
+			r.With(readAll...).Get("/snapshots/current", snapshots.Current)
+			r.With(readAll...).Get("/snapshots/{id}", snapshots.Get)
+			r.With(readAll...).With(authorization.Check(rbac.WriteSnapshots)).Post("/snapshots", snapshots.Create)
+			r.With(readAll...).With(writeAll...).Post("/snapshots/restore", snapshots.Restore)
+			r.With(readAll...).With(writeAll...).Post("/snapshots/{id}/restore", snapshots.RestoreById)
+
 			r.With(authorization.Check(rbac.ReadTokens)).Get("/tokens", tokens.List)
+			r.With(authorization.Check(rbac.ReadTokens)).Get("/tokens/export", exports.Tokens)
+			r.With(authorization.Check(rbac.RevealTokens)).Get("/tokens/{id}/value", tokens.Reveal)
 			r.With(authorization.Check(rbac.WriteTokens)).Delete("/tokens/{id}", tokens.Delete)
 
 			r.With(authorization.Check(rbac.ReadUsers)).Get("/users", users.List)
+			r.With(authorization.Check(rbac.ReadUsers), authorization.Check(rbac.ReadRoles), authorization.Check(rbac.ReadScopes)).Get("/users/export", exports.Users)
 			r.With(authorization.Check(rbac.ReadUsers)).Get("/users/{id}", users.Get)
-			r.With(authorization.Check(rbac.WriteUsers)).Post("/users", users.Create)
+			r.With(authorization.Check(rbac.ReadUsers), authorization.Check(rbac.ReadRoles), authorization.Check(rbac.ReadPermissions)).Get("/users/{id}/effective-permissions", users.EffectivePermissions)
+			r.With(authorization.Check(rbac.ReadAudit)).Get("/users/{id}/history", audit.History(models.UserResourceType))
+			r.With(authorization.Check(rbac.WriteUsers), idempotency.Idempotent).Post("/users", users.Create)
+			r.With(authorization.Check(rbac.WriteUsers), idempotency.Idempotent).Post("/users/bulk", users.Bulk)
+			r.With(authorization.Check(rbac.WriteUsers), authorization.Check(rbac.ReadUsers), authorization.Check(rbac.ReadRoles), authorization.Check(rbac.ReadScopes)).Post("/users/import", userImports.Preview)
+			r.With(authorization.Check(rbac.WriteUsers)).Get("/users/import/{id}", userImports.Get)
+			r.With(authorization.Check(rbac.WriteUsers)).Post("/users/import/{id}/confirm", userImports.Confirm)
 			r.With(authorization.Check(rbac.WriteUsers)).Put("/users/{id}", users.Update)
+			r.With(authorization.Check(rbac.WriteUsers)).Patch("/users/{id}", users.Patch)
 			r.With(authorization.Check(rbac.WriteUsers)).Delete("/users/{id}", users.Delete)
+			r.With(authorization.Check(rbac.WriteTokens)).Delete("/users/{id}/tokens", tokens.RevokeAll)
 		})
 	})
 
@@ -26,13 +26,19 @@ func Test_HealthCheck(t *testing.T) {
 	mockAuthorizationMiddleware := middlewares.NewMockAuthorizationMiddleware(ctrl)
 	mockTelemetryMiddleware := middlewares.NewMockTelemetryMiddleware(ctrl)
 	mockLoggerMiddleware := middlewares.NewMockLoggerMiddleware(ctrl)
+	mockIdempotencyMiddleware := middlewares.NewMockIdempotencyMiddleware(ctrl)
 
 	mockHealthController := controllers.NewMockHealthController(ctrl)
+	mockAuditController := controllers.NewMockAuditController(ctrl)
+	mockExportsController := controllers.NewMockExportsController(ctrl)
 	mockPermissionsController := controllers.NewMockPermissionsController(ctrl)
+	mockRBACController := controllers.NewMockRBACController(ctrl)
 	mockRolesController := controllers.NewMockRolesController(ctrl)
 	mockScopesController := controllers.NewMockScopesController(ctrl)
+	mockSnapshotsController := controllers.NewMockSnapshotsController(ctrl)
 	mockTokensController := controllers.NewMockTokensController(ctrl)
 	mockUsersController := controllers.NewMockUsersController(ctrl)
+	mockUserImportsController := controllers.NewMockUserImportsController(ctrl)
 
 	mockAuthenticationMiddleware.EXPECT().
 		Authenticate(gomock.Any()).
@@ -54,12 +60,22 @@ func Test_HealthCheck(t *testing.T) {
 		DoAndReturn(func(next http.Handler) http.Handler {
 			return next
 		})
+	mockAuditController.EXPECT().
+		History(gomock.Any()).
+		AnyTimes().
+		Return(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
 	mockLoggerMiddleware.EXPECT().
 		Log(gomock.Any()).
 		AnyTimes().
 		DoAndReturn(func(next http.Handler) http.Handler {
 			return next
 		})
+	mockIdempotencyMiddleware.EXPECT().
+		Idempotent(gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(next http.Handler) http.Handler {
+			return next
+		})
 
 	router := NewRouter(
 		cfg,
@@ -67,12 +83,18 @@ func Test_HealthCheck(t *testing.T) {
 		mockAuthorizationMiddleware,
 		mockTelemetryMiddleware,
 		mockLoggerMiddleware,
+		mockIdempotencyMiddleware,
 		mockHealthController,
+		mockAuditController,
+		mockExportsController,
 		mockPermissionsController,
+		mockRBACController,
 		mockRolesController,
 		mockScopesController,
+		mockSnapshotsController,
 		mockTokensController,
 		mockUsersController,
+		mockUserImportsController,
 	)
 
 	req := httptest.NewRequest(http.MethodHead, "/health", nil)
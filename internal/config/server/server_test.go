@@ -28,13 +28,19 @@ func Test_NewServer(t *testing.T) {
 	mockAuthorizationMiddleware := middlewares.NewMockAuthorizationMiddleware(ctrl)
 	mockTelemetryMiddleware := middlewares.NewMockTelemetryMiddleware(ctrl)
 	mockLoggerMiddleware := middlewares.NewMockLoggerMiddleware(ctrl)
+	mockIdempotencyMiddleware := middlewares.NewMockIdempotencyMiddleware(ctrl)
 
 	mockHealthController := controllers.NewMockHealthController(ctrl)
+	mockAuditController := controllers.NewMockAuditController(ctrl)
+	mockExportsController := controllers.NewMockExportsController(ctrl)
 	mockPermissionsController := controllers.NewMockPermissionsController(ctrl)
+	mockRBACController := controllers.NewMockRBACController(ctrl)
 	mockRolesController := controllers.NewMockRolesController(ctrl)
 	mockScopesController := controllers.NewMockScopesController(ctrl)
+	mockSnapshotsController := controllers.NewMockSnapshotsController(ctrl)
 	mockTokensController := controllers.NewMockTokensController(ctrl)
 	mockUsersController := controllers.NewMockUsersController(ctrl)
+	mockUserImportsController := controllers.NewMockUserImportsController(ctrl)
 
 	mockAuthenticationMiddleware.EXPECT().
 		Authenticate(gomock.Any()).
@@ -56,12 +62,22 @@ func Test_NewServer(t *testing.T) {
 		DoAndReturn(func(next http.Handler) http.Handler {
 			return next
 		})
+	mockAuditController.EXPECT().
+		History(gomock.Any()).
+		AnyTimes().
+		Return(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
 	mockLoggerMiddleware.EXPECT().
 		Log(gomock.Any()).
 		AnyTimes().
 		DoAndReturn(func(next http.Handler) http.Handler {
 			return next
 		})
+	mockIdempotencyMiddleware.EXPECT().
+		Idempotent(gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(next http.Handler) http.Handler {
+			return next
+		})
 
 	appRouter := router.NewRouter(
 		cfg,
@@ -69,12 +85,18 @@ func Test_NewServer(t *testing.T) {
 		mockAuthorizationMiddleware,
 		mockTelemetryMiddleware,
 		mockLoggerMiddleware,
+		mockIdempotencyMiddleware,
 		mockHealthController,
+		mockAuditController,
+		mockExportsController,
 		mockPermissionsController,
+		mockRBACController,
 		mockRolesController,
 		mockScopesController,
+		mockSnapshotsController,
 		mockTokensController,
 		mockUsersController,
+		mockUserImportsController,
 	)
 
 	srv := NewServer(cfg, appRouter)
@@ -4,8 +4,10 @@ import (
 	"context"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/semconv/v1.17.0"
@@ -28,11 +30,34 @@ func NewTelemetry(ctx context.Context, cfg *config.Config) (*trace.TracerProvide
 
 	provider := trace.NewTracerProvider(
 		trace.WithBatcher(exporter),
-		trace.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(cfg.AppName),
-		)),
+		trace.WithResource(newResource(cfg)),
 	)
 	otel.SetTracerProvider(provider)
 	return provider, nil
 }
+
+// NewMetrics exports metrics to the same collector as traces, instruments created
+// through otel.Meter before the provider is set are forwarded to it
+func NewMetrics(ctx context.Context, cfg *config.Config) (*metric.MeterProvider, error) {
+	exporter, err := otlpmetricgrpc.New(ctx,
+		otlpmetricgrpc.WithInsecure(),
+		otlpmetricgrpc.WithEndpoint(cfg.TelemetryURI),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := metric.NewMeterProvider(
+		metric.WithReader(metric.NewPeriodicReader(exporter)),
+		metric.WithResource(newResource(cfg)),
+	)
+	otel.SetMeterProvider(provider)
+	return provider, nil
+}
+
+func newResource(cfg *config.Config) *resource.Resource {
+	return resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(cfg.AppName),
+	)
+}
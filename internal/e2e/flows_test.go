@@ -0,0 +1,175 @@
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"loki-backoffice/internal/app/models/dto"
+	"loki-backoffice/internal/app/rpcs/fake"
+	"loki-backoffice/internal/app/rpcs/interceptors"
+	proto "loki-backoffice/internal/app/rpcs/proto/sso/v1"
+	"loki-backoffice/internal/app/serializers"
+	"loki-backoffice/pkg/rbac"
+)
+
+func Test_Permissions_Lifecycle(t *testing.T) {
+	h := Start(t)
+	token := h.AdminToken(t)
+
+	resp := h.Request(t, http.MethodPost, "/api/backoffice/permissions", token, map[string]string{
+		"name":        "read:reports",
+		"description": "Read reports",
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	created := Decode[serializers.PermissionSerializer](t, resp)
+	assert.Equal(t, "read:reports", created.Name)
+
+	resp = h.Request(t, http.MethodGet, "/api/backoffice/permissions?per=10", token, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	list := Decode[serializers.PaginationResponse[serializers.PermissionSerializer]](t, resp)
+	assert.Equal(t, []serializers.PermissionSerializer{created}, list.Data)
+	assert.Equal(t, uint64(1), list.Meta.Total)
+
+	resp = h.Request(t, http.MethodPut, "/api/backoffice/permissions/"+created.ID.String(), token, map[string]string{
+		"name":        "read:reports",
+		"description": "Read monthly reports",
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Read monthly reports", Decode[serializers.PermissionSerializer](t, resp).Description)
+
+	role := h.SSO.AddRole(&proto.Role{Name: "analyst", Description: "Analyst", PermissionIds: []string{created.ID.String()}})
+
+	resp = h.Request(t, http.MethodDelete, "/api/backoffice/permissions/"+created.ID.String(), token, nil)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp = h.Request(t, http.MethodDelete, "/api/backoffice/permissions/"+created.ID.String()+"?force=true", token, nil)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	resp = h.Request(t, http.MethodGet, "/api/backoffice/permissions/"+created.ID.String(), token, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp = h.Request(t, http.MethodGet, "/api/backoffice/roles/"+role.Id, token, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, Decode[serializers.RoleSerializer](t, resp).PermissionIDs)
+}
+
+func Test_Users_Validation(t *testing.T) {
+	h := Start(t)
+	token := h.Token(t, rbac.WriteUsers)
+
+	resp := h.Request(t, http.MethodPost, "/api/backoffice/users", token, map[string]interface{}{
+		"identity_number": "PNOEE-60001018800",
+		"personal_code":   "60001018800",
+		"first_name":      "Jane",
+		"last_name":       "Doe",
+		"role_ids":        []string{id},
+	})
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	problem := Decode[serializers.ProblemSerializer](t, resp)
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "/role_ids/0", problem.Errors[0].Pointer)
+}
+
+func Test_SSO_Forwarding(t *testing.T) {
+	h := Start(t)
+	token := h.Token(t, rbac.ReadUsers)
+
+	h.SSO.AddUser(&proto.User{IdentityNumber: AdminID, PersonalCode: "60001017869", FirstName: "John", LastName: "Doe"})
+
+	var authorization []string
+	h.SSO.Inject(proto.UserService_List_FullMethodName, func(ctx context.Context, _ string) error {
+		md, _ := metadata.FromIncomingContext(ctx)
+		authorization = md.Get("authorization")
+		return nil
+	})
+
+	resp := h.Request(t, http.MethodGet, "/api/backoffice/users", token, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"Bearer " + token}, authorization)
+
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+
+	h.SSO.Reset()
+	h.SSO.Inject(proto.UserService_List_FullMethodName, fake.FailTimes(1, unavailable))
+
+	resp = h.Request(t, http.MethodGet, "/api/backoffice/users", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, h.SSO.Calls(proto.UserService_List_FullMethodName))
+
+	h.SSO.Reset()
+	h.SSO.Inject(proto.UserService_List_FullMethodName, fake.FailTimes(interceptors.RetryMaxAttempts, unavailable))
+
+	resp = h.Request(t, http.MethodGet, "/api/backoffice/users", token, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.True(t, Decode[serializers.ProblemSerializer](t, resp).Retryable)
+	assert.Equal(t, interceptors.RetryMaxAttempts, h.SSO.Calls(proto.UserService_List_FullMethodName))
+}
+
+func Test_SSO_Retry(t *testing.T) {
+	h := Start(t)
+	token := h.Token(t, rbac.WriteUsers)
+
+	h.SSO.Inject(fake.AnyMethod, fake.FailTimes(1, status.Error(codes.Unavailable, "unavailable")))
+
+	resp := h.Request(t, http.MethodPost, "/api/backoffice/users", token, map[string]interface{}{
+		"identity_number": "PNOEE-60001018800",
+		"personal_code":   "60001018800",
+		"first_name":      "Jane",
+		"last_name":       "Doe",
+	})
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 1, h.SSO.Calls(proto.UserService_Create_FullMethodName))
+}
+
+func Test_Snapshots_Current(t *testing.T) {
+	h := Start(t)
+
+	permission := h.SSO.AddPermission(&proto.Permission{Name: "read:users", Description: "Read users"})
+	role := h.SSO.AddRole(&proto.Role{Name: "admin", Description: "Administrator", PermissionIds: []string{permission.Id}})
+	h.SSO.AddUser(&proto.User{IdentityNumber: AdminID, PersonalCode: "60001017869", FirstName: "John", LastName: "Doe", RoleIds: []string{role.Id}})
+
+	resp := h.Request(t, http.MethodGet, "/api/backoffice/snapshots/current", h.Token(t, readAll...), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	archive := Decode[dto.SnapshotArchive](t, resp)
+	assert.Equal(t, AdminID, archive.CreatedBy)
+	assert.Len(t, archive.Permissions, 1)
+	assert.Len(t, archive.Roles, 1)
+	require.Len(t, archive.Users, 1)
+	assert.Equal(t, AdminID, archive.Users[0].IdentityNumber)
+	assert.Equal(t, role.Id, archive.Users[0].RoleIDs[0].String())
+}
+
+func Test_SSO_CircuitBreaker(t *testing.T) {
+	h := Start(t)
+	token := h.Token(t, rbac.ReadUsers, rbac.ReadRoles)
+
+	h.SSO.Inject(proto.UserService_List_FullMethodName, fake.FailTimes(1000, status.Error(codes.Unavailable, "unavailable")))
+
+	for range interceptors.BreakerFailureThreshold {
+		resp := h.Request(t, http.MethodGet, "/api/backoffice/users", token, nil)
+		require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, "unavailable", Decode[serializers.ProblemSerializer](t, resp).Code)
+	}
+	calls := h.SSO.Calls(proto.UserService_List_FullMethodName)
+
+	resp := h.Request(t, http.MethodGet, "/api/backoffice/users", token, nil)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "10", resp.Header.Get(serializers.RetryAfterHeader))
+
+	problem := Decode[serializers.ProblemSerializer](t, resp)
+	assert.Equal(t, "circuit_open", problem.Code)
+	assert.True(t, problem.Retryable)
+	assert.Equal(t, calls, h.SSO.Calls(proto.UserService_List_FullMethodName))
+
+	resp = h.Request(t, http.MethodGet, "/api/backoffice/roles", token, nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,234 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+
+	"loki-backoffice/internal/app"
+	"loki-backoffice/internal/app/rpcs/fake"
+	"loki-backoffice/internal/config"
+	"loki-backoffice/internal/config/logger"
+	"loki-backoffice/pkg/jwt"
+	"loki-backoffice/pkg/rbac"
+	"loki-backoffice/pkg/spec"
+)
+
+const (
+	// AdminID is the subject of the tokens minted by the harness
+	AdminID = "PNOEE-60001017869"
+
+	StartTimeout = 10 * time.Second
+	StopTimeout  = 10 * time.Second
+)
+
+// AllPermissions grants access to every route
+var AllPermissions = []string{
+	rbac.ReadUsers,
+	rbac.WriteUsers,
+	rbac.ReadTokens,
+	rbac.WriteTokens,
+	rbac.RevealTokens,
+	rbac.ReadPermissions,
+	rbac.WritePermissions,
+	rbac.ReadRoles,
+	rbac.WriteRoles,
+	rbac.ReadScopes,
+	rbac.WriteScopes,
+	rbac.ReadAudit,
+	rbac.WriteSnapshots,
+}
+
+// Harness runs the whole application against a fake SSO service over mutual TLS
+type Harness struct {
+	Config *config.Config
+	SSO    *fake.Server
+	URL    string
+
+	client *http.Client
+}
+
+// Start boots app.Module on a random local port and stops it when the test ends.
+// DATABASE_DSN from the test environment is used when set, routes backed by
+// Postgres fail without it while everything served by the SSO service works
+func Start(t *testing.T) *Harness {
+	t.Helper()
+
+	_ = spec.LoadEnv()
+
+	certPath := spec.GenerateCertificates(t)
+	generateKeys(t, certPath)
+	sso, grpcAddr := fake.StartTLS(t, certPath)
+
+	cfg := &config.Config{
+		AppEnv:            "test",
+		AppName:           "loki-backoffice-e2e",
+		AppAddr:           freeAddr(t),
+		GrpcAddr:          grpcAddr,
+		ClientURL:         config.ClientURL,
+		CertPath:          certPath,
+		DatabaseDSN:       os.Getenv("DATABASE_DSN"),
+		CursorSecret:      "e2e-cursor-secret",
+		LogLevel:          logger.ErrorLevel,
+		SnapshotRetention: config.SnapshotRetention,
+	}
+
+	application := fx.New(
+		fx.NopLogger,
+		fx.Supply(cfg),
+		app.Module,
+	)
+
+	startCtx, cancel := context.WithTimeout(context.Background(), StartTimeout)
+	defer cancel()
+	require.NoError(t, application.Start(startCtx))
+
+	t.Cleanup(func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), StopTimeout)
+		defer cancel()
+		_ = application.Stop(stopCtx)
+	})
+
+	h := &Harness{
+		Config: cfg,
+		SSO:    sso,
+		URL:    "http://" + cfg.AppAddr,
+		client: &http.Client{Timeout: StartTimeout},
+	}
+
+	spec.WaitForServerStart(t, h.URL+"/live")
+
+	return h
+}
+
+// Token mints a token for AdminID carrying the permissions, signed with the key the application trusts
+func (h *Harness) Token(t *testing.T, permissions ...string) string {
+	t.Helper()
+
+	return h.sign(t, permissions, time.Hour)
+}
+
+// AdminToken mints a token with AllPermissions
+func (h *Harness) AdminToken(t *testing.T) string {
+	return h.Token(t, AllPermissions...)
+}
+
+// TokenWithout mints a token with AllPermissions except the excluded ones
+func (h *Harness) TokenWithout(t *testing.T, excluded ...string) string {
+	permissions := make([]string, 0, len(AllPermissions))
+	for _, permission := range AllPermissions {
+		if !rbac.HasPermission(excluded, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return h.Token(t, permissions...)
+}
+
+// ExpiredToken mints a token with AllPermissions that expired an hour ago
+func (h *Harness) ExpiredToken(t *testing.T) string {
+	return h.sign(t, AllPermissions, -time.Hour)
+}
+
+// Request sends a request to the application, an empty token sends none and
+// a body other than a string or []byte is encoded as JSON
+func (h *Harness) Request(t *testing.T, method, path, token string, body interface{}) *http.Response {
+	t.Helper()
+
+	var reader io.Reader
+	switch value := body.(type) {
+	case nil:
+	case string:
+		reader = bytes.NewBufferString(value)
+	case []byte:
+		reader = bytes.NewBuffer(value)
+	default:
+		encoded, err := json.Marshal(value)
+		require.NoError(t, err)
+		reader = bytes.NewBuffer(encoded)
+	}
+
+	req, err := http.NewRequest(method, h.URL+path, reader)
+	require.NoError(t, err)
+
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := h.client.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	return resp
+}
+
+// Decode reads a JSON response body into T
+func Decode[T any](t *testing.T, resp *http.Response) T {
+	t.Helper()
+
+	var result T
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+	return result
+}
+
+func (h *Harness) sign(t *testing.T, permissions []string, ttl time.Duration) string {
+	token, err := jwt.Sign(h.Config, h.Config.AppName, &jwt.Payload{
+		ID:          AdminID,
+		Roles:       []string{"admin"},
+		Permissions: permissions,
+		Scope:       []string{rbac.SsoServiceType},
+	}, ttl)
+	require.NoError(t, err)
+
+	return token
+}
+
+// generateKeys writes the JWT key pair where jwt.NewJWT looks for it
+func generateKeys(t *testing.T, certPath string) {
+	dir := filepath.Join(certPath, jwt.Dir)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: publicKeyBytes,
+	})
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, jwt.PrivateKeyFile), privateKeyPEM, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, jwt.PublicKeyFile), publicKeyPEM, 0644))
+}
+
+func freeAddr(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
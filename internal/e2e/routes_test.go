@@ -0,0 +1,143 @@
+package e2e
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"loki-backoffice/pkg/rbac"
+)
+
+const id = "10000000-1000-1000-1000-000000000001"
+
+// routes mirrors router.NewRouter together with the permissions each route checks
+var routes = []struct {
+	method      string
+	path        string
+	permissions []string
+}{
+	{http.MethodGet, "/api/backoffice/audit", []string{rbac.ReadAudit}},
+
+	{http.MethodGet, "/api/backoffice/permissions", []string{rbac.ReadPermissions}},
+	{http.MethodGet, "/api/backoffice/permissions/export", []string{rbac.ReadPermissions}},
+	{http.MethodGet, "/api/backoffice/permissions/" + id, []string{rbac.ReadPermissions}},
+	{http.MethodGet, "/api/backoffice/permissions/" + id + "/history", []string{rbac.ReadAudit}},
+	{http.MethodGet, "/api/backoffice/permissions/" + id + "/roles", []string{rbac.ReadPermissions, rbac.ReadRoles}},
+	{http.MethodPost, "/api/backoffice/permissions", []string{rbac.WritePermissions}},
+	{http.MethodPost, "/api/backoffice/permissions/bulk", []string{rbac.WritePermissions}},
+	{http.MethodPut, "/api/backoffice/permissions/" + id, []string{rbac.WritePermissions}},
+	{http.MethodPatch, "/api/backoffice/permissions/" + id, []string{rbac.WritePermissions}},
+	{http.MethodDelete, "/api/backoffice/permissions/" + id, []string{rbac.WritePermissions}},
+
+	{http.MethodPost, "/api/backoffice/rbac/plan", []string{rbac.ReadPermissions, rbac.ReadRoles, rbac.ReadScopes, rbac.ReadUsers}},
+	{http.MethodPost, "/api/backoffice/rbac/apply", []string{rbac.ReadUsers, rbac.WritePermissions, rbac.WriteRoles, rbac.WriteScopes}},
+
+	{http.MethodGet, "/api/backoffice/roles", []string{rbac.ReadRoles}},
+	{http.MethodGet, "/api/backoffice/roles/export", []string{rbac.ReadRoles}},
+	{http.MethodGet, "/api/backoffice/roles/" + id, []string{rbac.ReadRoles}},
+	{http.MethodGet, "/api/backoffice/roles/" + id + "/history", []string{rbac.ReadAudit}},
+	{http.MethodGet, "/api/backoffice/roles/" + id + "/users", []string{rbac.ReadRoles, rbac.ReadUsers}},
+	{http.MethodPost, "/api/backoffice/roles", []string{rbac.WriteRoles}},
+	{http.MethodPost, "/api/backoffice/roles/bulk", []string{rbac.WriteRoles}},
+	{http.MethodPut, "/api/backoffice/roles/" + id, []string{rbac.WriteRoles}},
+	{http.MethodPatch, "/api/backoffice/roles/" + id, []string{rbac.WriteRoles}},
+	{http.MethodDelete, "/api/backoffice/roles/" + id, []string{rbac.WriteRoles}},
+
+	{http.MethodGet, "/api/backoffice/scopes", []string{rbac.ReadScopes}},
+	{http.MethodGet, "/api/backoffice/scopes/" + id, []string{rbac.ReadScopes}},
+	{http.MethodGet, "/api/backoffice/scopes/" + id + "/history", []string{rbac.ReadAudit}},
+	{http.MethodGet, "/api/backoffice/scopes/" + id + "/users", []string{rbac.ReadScopes, rbac.ReadUsers}},
+	{http.MethodPost, "/api/backoffice/scopes", []string{rbac.WriteScopes}},
+	{http.MethodPut, "/api/backoffice/scopes/" + id, []string{rbac.WriteScopes}},
+	{http.MethodPatch, "/api/backoffice/scopes/" + id, []string{rbac.WriteScopes}},
+	{http.MethodDelete, "/api/backoffice/scopes/" + id, []string{rbac.WriteScopes}},
+
+	{http.MethodGet, "/api/backoffice/snapshots", readAll},
+	{http.MethodGet, "/api/backoffice/snapshots/current", readAll},
+	{http.MethodGet, "/api/backoffice/snapshots/" + id, readAll},
+	{http.MethodPost, "/api/backoffice/snapshots", append([]string{rbac.WriteSnapshots}, readAll...)},
+	{http.MethodPost, "/api/backoffice/snapshots/restore", readWriteAll},
+	{http.MethodPost, "/api/backoffice/snapshots/" + id + "/restore", readWriteAll},
+
+	{http.MethodGet, "/api/backoffice/tokens", []string{rbac.ReadTokens}},
+	{http.MethodGet, "/api/backoffice/tokens/export", []string{rbac.ReadTokens}},
+	{http.MethodGet, "/api/backoffice/tokens/" + id + "/value", []string{rbac.RevealTokens}},
+	{http.MethodDelete, "/api/backoffice/tokens/" + id, []string{rbac.WriteTokens}},
+
+	{http.MethodGet, "/api/backoffice/users", []string{rbac.ReadUsers}},
+	{http.MethodGet, "/api/backoffice/users/export", []string{rbac.ReadUsers, rbac.ReadRoles, rbac.ReadScopes}},
+	{http.MethodGet, "/api/backoffice/users/" + id, []string{rbac.ReadUsers}},
+	{http.MethodGet, "/api/backoffice/users/" + id + "/effective-permissions", []string{rbac.ReadUsers, rbac.ReadRoles, rbac.ReadPermissions}},
+	{http.MethodGet, "/api/backoffice/users/" + id + "/history", []string{rbac.ReadAudit}},
+	{http.MethodPost, "/api/backoffice/users", []string{rbac.WriteUsers}},
+	{http.MethodPost, "/api/backoffice/users/bulk", []string{rbac.WriteUsers}},
+	{http.MethodPost, "/api/backoffice/users/import", []string{rbac.ReadRoles, rbac.ReadScopes, rbac.ReadUsers, rbac.WriteUsers}},
+	{http.MethodGet, "/api/backoffice/users/import/" + id, []string{rbac.WriteUsers}},
+	{http.MethodPost, "/api/backoffice/users/import/" + id + "/confirm", []string{rbac.WriteUsers}},
+	{http.MethodPut, "/api/backoffice/users/" + id, []string{rbac.WriteUsers}},
+	{http.MethodPatch, "/api/backoffice/users/" + id, []string{rbac.WriteUsers}},
+	{http.MethodDelete, "/api/backoffice/users/" + id, []string{rbac.WriteUsers}},
+	{http.MethodDelete, "/api/backoffice/users/" + id + "/tokens", []string{rbac.WriteTokens}},
+}
+
+var (
+	readAll      = []string{rbac.ReadPermissions, rbac.ReadRoles, rbac.ReadScopes, rbac.ReadUsers}
+	writeAll     = []string{rbac.WritePermissions, rbac.WriteRoles, rbac.WriteScopes, rbac.WriteUsers}
+	readWriteAll = append(append([]string{}, readAll...), writeAll...)
+)
+
+func Test_Routes_Authorization(t *testing.T) {
+	h := Start(t)
+
+	admin := h.AdminToken(t)
+	expired := h.ExpiredToken(t)
+
+	for _, route := range routes {
+		t.Run(route.method+" "+strings.TrimPrefix(route.path, "/api/backoffice"), func(t *testing.T) {
+			resp := h.Request(t, route.method, route.path, "", nil)
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "without token")
+
+			resp = h.Request(t, route.method, route.path, expired, nil)
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "with expired token")
+
+			for _, permission := range route.permissions {
+				resp = h.Request(t, route.method, route.path, h.TokenWithout(t, permission), nil)
+				assert.Equal(t, http.StatusForbidden, resp.StatusCode, "without %s", permission)
+			}
+
+			resp = h.Request(t, route.method, route.path, admin, nil)
+			assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, resp.StatusCode, "with admin token")
+			assert.True(t, served(resp), "route is not served, got %d", resp.StatusCode)
+		})
+	}
+}
+
+// served tells handler responses apart from the plain text ones chi writes for unknown routes
+func served(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusMethodNotAllowed {
+		return true
+	}
+
+	return strings.HasPrefix(resp.Header.Get("Content-Type"), "application/problem+json")
+}
+
+func Test_Routes_Public(t *testing.T) {
+	h := Start(t)
+
+	tests := []struct {
+		path     string
+		expected int
+	}{
+		{path: "/live", expected: http.StatusOK},
+		{path: "/health", expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp := h.Request(t, http.MethodGet, tt.path, "", nil)
+			assert.Equal(t, tt.expected, resp.StatusCode)
+		})
+	}
+}
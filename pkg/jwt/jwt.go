@@ -2,8 +2,10 @@ package jwt
 
 import (
 	"crypto/rsa"
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 
@@ -24,6 +26,13 @@ type Payload struct {
 	Scope       []string `json:"scope,omitempty"`
 }
 
+// Metadata holds the non-secret claims of a token
+type Metadata struct {
+	Issuer   string
+	IssuedAt time.Time
+	Scope    []string
+}
+
 type Jwt interface {
 	Decode(token string) (*Payload, error)
 }
@@ -64,7 +73,7 @@ func (j *jwtService) Decode(token string) (*Payload, error) {
 		})
 
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %w", errors.ErrInvalidToken, err)
 	}
 
 	if !result.Valid {
@@ -94,6 +103,52 @@ func loadPrivateKey(cfg *config.Config) (*rsa.PrivateKey, error) {
 	return key, nil
 }
 
+// Sign issues a token for payload with the private key next to the public one,
+// the backoffice never signs tokens itself so this is meant for development and tests
+func Sign(cfg *config.Config, issuer string, payload *Payload, ttl time.Duration) (string, error) {
+	privateKey, err := loadPrivateKey(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        payload.ID,
+			Issuer:    issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		Roles:       payload.Roles,
+		Permissions: payload.Permissions,
+		Scope:       payload.Scope,
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+}
+
+// Inspect reads the non-secret claims of a token without verifying its signature,
+// the result must never be used to authorize a request
+func Inspect(token string) (*Metadata, error) {
+	claims := &Claims{}
+
+	_, _, err := jwt.NewParser().ParseUnverified(token, claims)
+	if err != nil {
+		return nil, errors.ErrInvalidToken
+	}
+
+	metadata := &Metadata{
+		Issuer: claims.Issuer,
+		Scope:  claims.Scope,
+	}
+
+	if claims.IssuedAt != nil {
+		metadata.IssuedAt = claims.IssuedAt.Time
+	}
+
+	return metadata, nil
+}
+
 func loadPublicKey(cfg *config.Config) (*rsa.PublicKey, error) {
 	filePath := filepath.Join(cfg.CertPath, Dir, PublicKeyFile)
 	bytes, err := os.ReadFile(filePath)
@@ -160,6 +160,121 @@ func Test_JWT_Decode_Mocked(t *testing.T) {
 	}
 }
 
+func Test_Sign(t *testing.T) {
+	cfg := &config.Config{
+		CertPath: generateTestKeys(t),
+	}
+	service, err := NewJWT(cfg)
+	require.NoError(t, err)
+
+	payload := &Payload{
+		ID:          "PNOEE-30303039914",
+		Roles:       []string{"admin"},
+		Permissions: []string{"read:users"},
+		Scope:       []string{"sso-service"},
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *config.Config
+		ttl      time.Duration
+		expected *Payload
+		error    error
+	}{
+		{
+			name:     "Success",
+			cfg:      cfg,
+			ttl:      time.Hour,
+			expected: payload,
+		},
+		{
+			name:  "Expired",
+			cfg:   cfg,
+			ttl:   -time.Hour,
+			error: errors.ErrInvalidToken,
+		},
+		{
+			name:  "Missing private key",
+			cfg:   &config.Config{CertPath: "/non-existent-path"},
+			ttl:   time.Hour,
+			error: os.ErrNotExist,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := Sign(tt.cfg, "loki-dev", payload, tt.ttl)
+			if err == nil {
+				var result *Payload
+				result, err = service.Decode(token)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			if tt.error != nil {
+				assert.ErrorIs(t, err, tt.error)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_Inspect(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuedAt := time.Now().Truncate(time.Second)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "PNOEE-30303039914",
+			Issuer:    "loki",
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(-time.Hour)),
+		},
+		Scope: []string{"sso-service"},
+	}).SignedString(privateKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		token    string
+		expected *Metadata
+		error    error
+	}{
+		{
+			name:  "Expired token signed by an unknown key",
+			token: token,
+			expected: &Metadata{
+				Issuer:   "loki",
+				IssuedAt: issuedAt,
+				Scope:    []string{"sso-service"},
+			},
+		},
+		{
+			name:  "Opaque token",
+			token: "refresh-token-value",
+			error: errors.ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Inspect(tt.token)
+
+			if tt.error != nil {
+				assert.Equal(t, tt.error, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.Issuer, result.Issuer)
+				assert.True(t, tt.expected.IssuedAt.Equal(result.IssuedAt))
+				assert.Equal(t, tt.expected.Scope, result.Scope)
+			}
+		})
+	}
+}
+
 func generateToken(id string, permissions, roles, scope []string, privateKey *rsa.PrivateKey) string {
 	claims := Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
@@ -7,12 +7,15 @@ const (
 	WriteUsers       = "write:users"
 	ReadTokens       = "read:tokens"
 	WriteTokens      = "write:tokens"
+	RevealTokens     = "reveal:tokens"
 	ReadPermissions  = "read:permissions"
 	WritePermissions = "write:permissions"
 	ReadRoles        = "read:roles"
 	WriteRoles       = "write:roles"
 	ReadScopes       = "read:scopes"
 	WriteScopes      = "write:scopes"
+	ReadAudit        = "read:audit"
+	WriteSnapshots   = "write:snapshots"
 )
 
 func HasPermission(claimPermissions []string, requiredPermission string) bool {